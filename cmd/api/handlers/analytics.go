@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/analytics"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxAnalyticsBuckets     = 1000
+	defaultAnalyticsBuckets = 30
+	defaultTopBuckets       = 1
+)
+
+// GetContractAnalytics godoc
+// @Summary Get contract analytics
+// @Description Get time series of calls, gas consumption, burned tez and paid storage of the contract. Series is built from transactions and paid storage increases targeting the contract.
+// @Tags contract
+// @ID get-contract-analytics
+// @Param network path string true "Network"
+// @Param address path string true "KT address" minlength(36) maxlength(36)
+// @Param period query string false "Bucket size" Enums(hour, day)
+// @Param from query integer false "Minimal timestamp in seconds (inclusive). Default: 30 buckets before `to`"
+// @Param to query integer false "Maximal timestamp in seconds (exclusive). Default: now"
+// @Param entrypoint query string false "Entrypoint"
+// @Param by_entrypoint query bool false "Split series by entrypoints"
+// @Accept json
+// @Produce json
+// @Success 200 {array} ContractAnalytics
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/contract/{network}/{address}/analytics [get]
+func GetContractAnalytics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getContractRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		var args contractAnalyticsRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		request, err := args.toModel()
+		if handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		acc, err := ctx.Accounts.Get(c.Request.Context(), req.Address)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		request.AccountID = acc.ID
+
+		stats, err := ctx.Analytics.Contract(c.Request.Context(), request)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]ContractAnalytics, len(stats))
+		for i := range stats {
+			response[i] = NewContractAnalytics(stats[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+func (args contractAnalyticsRequest) toModel() (analytics.ContractRequest, error) {
+	request := analytics.ContractRequest{
+		Period:       analytics.NewPeriod(args.Period),
+		Entrypoint:   args.Entrypoint,
+		ByEntrypoint: args.ByEntrypoint,
+	}
+
+	from, to, err := analyticsWindow(args.From, args.To, request.Period.Duration(), defaultAnalyticsBuckets)
+	request.From = from
+	request.To = to
+	return request, err
+}
+
+// analyticsWindow - returns time window of series. `to` is now by default and `from` is `defaultBuckets` buckets before `to` by default.
+func analyticsWindow(fromTs, toTs int64, bucket time.Duration, defaultBuckets int) (from time.Time, to time.Time, err error) {
+	to = time.Now().UTC()
+	if toTs > 0 {
+		to = time.Unix(toTs, 0).UTC()
+	}
+
+	from = to.Add(-bucket * time.Duration(defaultBuckets))
+	if fromTs > 0 {
+		from = time.Unix(fromTs, 0).UTC()
+	}
+
+	if !from.Before(to) {
+		return from, to, errors.New("'from' should be less than 'to'")
+	}
+	if to.Sub(from) > bucket*maxAnalyticsBuckets {
+		return from, to, errors.Errorf("too wide time range: maximum is %d buckets", maxAnalyticsBuckets)
+	}
+	return from, to, nil
+}
+
+// GetNetworkSeries godoc
+// @Summary Get network activity series
+// @Description Get time series of network activity: operations, contract calls, unique senders, new contracts, events, ticket transfers, global constants and smart rollups
+// @Tags statistics
+// @ID get-network-series
+// @Param network path string true "Network"
+// @Param period query string false "Bucket size" Enums(hour, day)
+// @Param from query integer false "Minimal timestamp in seconds (inclusive). Default: 30 buckets before `to`"
+// @Param to query integer false "Maximal timestamp in seconds (exclusive). Default: now"
+// @Accept json
+// @Produce json
+// @Success 200 {array} NetworkSeries
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/stats/{network}/series [get]
+func GetNetworkSeries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var args networkSeriesRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		request := analytics.NetworkRequest{
+			Period: analytics.NewPeriod(args.Period),
+		}
+		from, to, err := analyticsWindow(args.From, args.To, request.Period.Duration(), defaultAnalyticsBuckets)
+		if handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		request.From = from
+		request.To = to
+
+		series, err := ctx.Analytics.Network(c.Request.Context(), request)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]NetworkSeries, len(series))
+		for i := range series {
+			response[i] = NewNetworkSeries(series[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetTopContracts godoc
+// @Summary Get top contracts
+// @Description Get contracts with the most calls or consumed gas in the time window. Window is aligned to hours if it's not wider than a week and to days otherwise.
+// @Tags statistics
+// @ID get-top-contracts
+// @Param network path string true "Network"
+// @Param by query string false "Ranking metric" Enums(calls, gas)
+// @Param from query integer false "Minimal timestamp in seconds (inclusive). Default: a day before `to`"
+// @Param to query integer false "Maximal timestamp in seconds (exclusive). Default: now"
+// @Param size query integer false "Contracts count" mininum(1) maximum(10)
+// @Accept json
+// @Produce json
+// @Success 200 {array} TopContract
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/stats/{network}/top_contracts [get]
+func GetTopContracts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var args topContractsRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		request := analytics.TopRequest{
+			By:    analytics.TopByCalls,
+			Limit: args.Size,
+		}
+		if args.By == string(analytics.TopByGas) {
+			request.By = analytics.TopByGas
+		}
+		from, to, err := analyticsWindow(args.From, args.To, analytics.PeriodDay.Duration(), defaultTopBuckets)
+		if handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		request.From = from
+		request.To = to
+
+		contracts, err := ctx.Analytics.TopContracts(c.Request.Context(), request)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]TopContract, len(contracts))
+		for i := range contracts {
+			response[i] = NewTopContract(contracts[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
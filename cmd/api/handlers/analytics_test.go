@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	stdJSON "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/cmd/api/validations"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/analytics"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_account "github.com/baking-bad/bcdhub/internal/models/mock/account"
+	mock_analytics "github.com/baking-bad/bcdhub/internal/models/mock/analytics"
+)
+
+func TestContractAnalyticsRequest_toModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    contractAnalyticsRequest
+		want    analytics.ContractRequest
+		wantErr bool
+	}{
+		{
+			name: "hourly",
+			args: contractAnalyticsRequest{Period: "hour", To: 1704067200, Entrypoint: "transfer"},
+			want: analytics.ContractRequest{
+				Period:     analytics.PeriodHour,
+				From:       time.Date(2023, 12, 30, 18, 0, 0, 0, time.UTC),
+				To:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Entrypoint: "transfer",
+			},
+		}, {
+			name: "daily by entrypoint",
+			args: contractAnalyticsRequest{From: 1701388800, To: 1704067200, ByEntrypoint: true},
+			want: analytics.ContractRequest{
+				Period:       analytics.PeriodDay,
+				From:         time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+				To:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				ByEntrypoint: true,
+			},
+		}, {
+			name:    "from after to",
+			args:    contractAnalyticsRequest{From: 1704067200, To: 1701388800},
+			wantErr: true,
+		}, {
+			name:    "too wide range",
+			args:    contractAnalyticsRequest{Period: "hour", From: 1, To: 1704067200},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.toModel()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetContractAnalytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	require.True(t, ok)
+	require.NoError(t, validations.Register(v, config.APIConfig{
+		Networks: []string{types.Mainnet.String()},
+	}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accounts := mock_account.NewMockRepository(ctrl)
+	stats := mock_analytics.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+	storage.EXPECT().IsRecordNotFound(gomock.Any()).Return(false).AnyTimes()
+
+	ctxs := config.Contexts{
+		types.Mainnet: &config.Context{Network: types.Mainnet, Accounts: accounts, Analytics: stats, Storage: storage},
+	}
+
+	address := "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	bucket := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	accounts.EXPECT().
+		Get(gomock.Any(), address).
+		Return(account.Account{ID: 42, Address: address}, nil).
+		Times(1)
+	stats.EXPECT().
+		Contract(gomock.Any(), analytics.ContractRequest{
+			AccountID:    42,
+			Period:       analytics.PeriodDay,
+			From:         time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			To:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ByEntrypoint: true,
+		}).
+		Return([]analytics.ContractStats{
+			{Bucket: bucket, Entrypoint: "transfer", Operations: 3, Calls: 3, FailedCalls: 1, TotalGas: 3000, AvgGas: 1000, P95Gas: 1500, Burned: 250},
+			{Bucket: bucket, Operations: 1, PaidStorageSizeDiff: 100, Burned: 25000},
+		}, nil).
+		Times(1)
+
+	router := gin.New()
+	router.SecureJsonPrefix("")
+	router.GET("/v1/contract/:network/:address/analytics", NetworkMiddleware(ctxs), GetContractAnalytics())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/contract/mainnet/"+address+"/analytics?from=1701388800&to=1704067200&by_entrypoint=true", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response []ContractAnalytics
+	require.NoError(t, stdJSON.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 2)
+	require.Equal(t, "transfer", response[0].Entrypoint)
+	require.EqualValues(t, 1500, response[0].P95Gas)
+	require.Equal(t, bucket, response[0].Timestamp)
+	require.Empty(t, response[1].Entrypoint)
+	require.EqualValues(t, 100, response[1].PaidStorageSizeDiff)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/contract/mainnet/"+address+"/analytics?period=week", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNetworkAnalytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	require.True(t, ok)
+	require.NoError(t, validations.Register(v, config.APIConfig{
+		Networks: []string{types.Mainnet.String()},
+	}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stats := mock_analytics.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+	storage.EXPECT().IsRecordNotFound(gomock.Any()).Return(false).AnyTimes()
+
+	ctxs := config.Contexts{
+		types.Mainnet: &config.Context{Network: types.Mainnet, Analytics: stats, Storage: storage},
+	}
+
+	bucket := time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)
+	stats.EXPECT().
+		Network(gomock.Any(), analytics.NetworkRequest{
+			Period: analytics.PeriodHour,
+			From:   time.Date(2023, 12, 30, 18, 0, 0, 0, time.UTC),
+			To:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}).
+		Return([]analytics.NetworkStats{
+			{Bucket: bucket, Operations: 10, Calls: 7, UniqueSenders: 3, NewContracts: 1, Events: 2},
+		}, nil).
+		Times(1)
+	stats.EXPECT().
+		TopContracts(gomock.Any(), analytics.TopRequest{
+			By:    analytics.TopByGas,
+			From:  time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			To:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Limit: 5,
+		}).
+		Return([]analytics.TopContract{
+			{AccountID: 1, Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", Calls: 2, TotalGas: 100000},
+			{AccountID: 2, Address: "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9", Calls: 5, TotalGas: 5000},
+		}, nil).
+		Times(1)
+
+	router := gin.New()
+	router.SecureJsonPrefix("")
+	router.GET("/v1/stats/:network/series", NetworkMiddleware(ctxs), GetNetworkSeries())
+	router.GET("/v1/stats/:network/top_contracts", NetworkMiddleware(ctxs), GetTopContracts())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stats/mainnet/series?period=hour&to=1704067200", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var series []NetworkSeries
+	require.NoError(t, stdJSON.Unmarshal(w.Body.Bytes(), &series))
+	require.Len(t, series, 1)
+	require.Equal(t, bucket, series[0].Timestamp)
+	require.EqualValues(t, 3, series[0].UniqueSenders)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stats/mainnet/top_contracts?by=gas&to=1704067200&size=5", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var top []TopContract
+	require.NoError(t, stdJSON.Unmarshal(w.Body.Bytes(), &top))
+	require.Len(t, top, 2)
+	require.Equal(t, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", top[0].Address)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stats/mainnet/top_contracts?by=fee", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
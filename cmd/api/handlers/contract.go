@@ -48,17 +48,24 @@ func GetContract() gin.HandlerFunc {
 			return
 		}
 
+		metadata, err := contractMetadata(c.Request.Context(), ctx, req.Address)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
 		if args.HasStats() {
 			res, err := contractWithStatsPostprocessing(c.Request.Context(), ctx, contract)
 			if handleError(c, ctx.Storage, err, 0) {
 				return
 			}
+			res.Metadata = metadata
 			c.SecureJSON(http.StatusOK, res)
 		} else {
 			res, err := contractPostprocessing(ctx, contract)
 			if handleError(c, ctx.Storage, err, 0) {
 				return
 			}
+			res.Metadata = metadata
 			c.SecureJSON(http.StatusOK, res)
 		}
 	}
@@ -139,6 +146,17 @@ func contractPostprocessing(ctx *config.Context, contract contract.Contract) (Co
 	return res, nil
 }
 
+func contractMetadata(c context.Context, ctx *config.Context, address string) (*ContractMetadata, error) {
+	metadata, err := ctx.Metadata.ContractMetadata(c, address)
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return NewContractMetadata(metadata), nil
+}
+
 func contractWithStatsPostprocessing(c context.Context, ctx *config.Context, contractModel contract.Contract) (ContractWithStats, error) {
 	contract, err := contractPostprocessing(ctx, contractModel)
 	if err != nil {
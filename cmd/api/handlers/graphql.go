@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	gqlast "github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/pkg/errors"
+)
+
+const (
+	graphQLMaxPageSize  = 100
+	graphQLCursorPrefix = "operation:"
+)
+
+type graphQLContextKey struct{}
+
+// GraphQLRequest -
+type GraphQLRequest struct {
+	Query         string                 `binding:"required" json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQL godoc
+// @Summary Execute GraphQL query
+// @Description Execute GraphQL query over contracts, operations, big maps, tickets, smart rollups and global constants. Queries exceeding depth or complexity limits are rejected.
+// @Tags graphql
+// @ID post-graphql
+// @Param network path string true "Network"
+// @Param request body GraphQLRequest true "GraphQL request"
+// @Accept json
+// @Produce json
+// @Success 200 {object} gin.H
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/graphql/{network} [post]
+func GraphQL(schema graphql.Schema, cfg config.GraphQLConfig) gin.HandlerFunc {
+	maxDepth := cfg.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultGraphQLMaxDepth
+	}
+	maxComplexity := cfg.MaxComplexity
+	if maxComplexity <= 0 {
+		maxComplexity = defaultGraphQLMaxComplexity
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req GraphQLRequest
+		if err := c.ShouldBindJSON(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		result := executeGraphQL(c.Request.Context(), ctx, &schema, req, maxDepth, maxComplexity)
+		c.SecureJSON(http.StatusOK, result)
+	}
+}
+
+func executeGraphQL(c context.Context, ctx *config.Context, schema *graphql.Schema, req GraphQLRequest, maxDepth, maxComplexity int) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if validation := graphql.ValidateDocument(schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	complexity, err := newQueryComplexity(schema, doc, req.Variables, maxDepth).Operation(doc, req.OperationName)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if complexity > maxComplexity {
+		err := errors.Wrapf(ErrQueryTooComplex, "complexity %d exceeds limit %d", complexity, maxComplexity)
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        *schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context: context.WithValue(
+			context.WithValue(c, graphQLContextKey{}, ctx),
+			graphQLLoadersKey{}, newGraphQLLoaders(ctx),
+		),
+	})
+}
+
+func graphQLNetworkContext(p graphql.ResolveParams) (*config.Context, error) {
+	ctx, ok := p.Context.Value(graphQLContextKey{}).(*config.Context)
+	if !ok {
+		return nil, errors.New("network context is not set")
+	}
+	return ctx, nil
+}
+
+func graphQLQueryLoaders(p graphql.ResolveParams) (*graphQLLoaders, error) {
+	loaders, ok := p.Context.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+	if !ok {
+		return nil, errors.New("loaders are not set")
+	}
+	return loaders, nil
+}
+
+func graphQLPage(p graphql.ResolveParams) (first, offset int64, err error) {
+	if value, ok := p.Args["first"].(int); ok {
+		first = int64(value)
+	}
+	if first < 1 || first > graphQLMaxPageSize {
+		return 0, 0, errors.Errorf("`first` should be between 1 and %d", graphQLMaxPageSize)
+	}
+	if value, ok := p.Args["offset"].(int); ok {
+		offset = int64(value)
+	}
+	if offset < 0 {
+		return 0, 0, errors.New("`offset` should be non-negative")
+	}
+	return
+}
+
+func encodeOperationCursor(id int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(graphQLCursorPrefix + strconv.FormatInt(id, 10)))
+}
+
+func decodeOperationCursor(cursor string) (int64, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid cursor")
+	}
+	value, ok := strings.CutPrefix(string(data), graphQLCursorPrefix)
+	if !ok {
+		return 0, errors.Errorf("invalid cursor: %s", cursor)
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid cursor")
+	}
+	return id, nil
+}
+
+var graphQLInt64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Int64",
+	Description: "The `Int64` scalar type represents 64-bit signed integer",
+	Serialize: func(value interface{}) interface{} {
+		switch typ := value.(type) {
+		case int64:
+			return typ
+		case int:
+			return int64(typ)
+		case uint64:
+			return typ
+		case *int64:
+			if typ == nil {
+				return nil
+			}
+			return *typ
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch typ := value.(type) {
+		case int:
+			return int64(typ)
+		case int64:
+			return typ
+		case float64:
+			return int64(typ)
+		case string:
+			if n, err := strconv.ParseInt(typ, 10, 64); err == nil {
+				return n
+			}
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST gqlast.Value) interface{} {
+		switch typ := valueAST.(type) {
+		case *gqlast.IntValue:
+			if n, err := strconv.ParseInt(typ.Value, 10, 64); err == nil {
+				return n
+			}
+		case *gqlast.StringValue:
+			if n, err := strconv.ParseInt(typ.Value, 10, 64); err == nil {
+				return n
+			}
+		}
+		return nil
+	},
+})
+
+var graphQLJSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "The `JSON` scalar type represents arbitrary JSON value",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST gqlast.Value) interface{} {
+		return valueAST.GetValue()
+	},
+})
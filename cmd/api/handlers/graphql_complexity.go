@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	gqlast "github.com/graphql-go/graphql/language/ast"
+	"github.com/pkg/errors"
+)
+
+// default limits of GraphQL queries
+const (
+	defaultGraphQLMaxDepth      = 10
+	defaultGraphQLMaxComplexity = 1000
+
+	// graphQLListMultiplier - estimated size of list fields without `first` argument
+	graphQLListMultiplier = 10
+)
+
+// complexity errors
+var (
+	ErrQueryTooDeep    = errors.New("query is too deep")
+	ErrQueryTooComplex = errors.New("query is too complex")
+)
+
+// queryComplexity - estimates the cost of the operation. Every requested field costs 1.
+// Fields having `first` argument multiply the cost of their selection by its value (or by its default).
+// Other list fields multiply it by graphQLListMultiplier except lists of connections which are already bounded by `first` of the connection.
+type queryComplexity struct {
+	schema    *graphql.Schema
+	fragments map[string]*gqlast.FragmentDefinition
+	variables map[string]interface{}
+	maxDepth  int
+}
+
+func newQueryComplexity(schema *graphql.Schema, doc *gqlast.Document, variables map[string]interface{}, maxDepth int) *queryComplexity {
+	qc := &queryComplexity{
+		schema:    schema,
+		fragments: make(map[string]*gqlast.FragmentDefinition),
+		variables: variables,
+		maxDepth:  maxDepth,
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*gqlast.FragmentDefinition); ok && fragment.Name != nil {
+			qc.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return qc
+}
+
+// Operation - returns the cost of the operation with name `name`. If name is empty the first operation is used.
+func (qc *queryComplexity) Operation(doc *gqlast.Document, name string) (int, error) {
+	for _, def := range doc.Definitions {
+		operation, ok := def.(*gqlast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name != "" && (operation.Name == nil || operation.Name.Value != name) {
+			continue
+		}
+		return qc.selectionSet(qc.schema.QueryType(), operation.SelectionSet, 0)
+	}
+	return 0, errors.Errorf("unknown operation: %s", name)
+}
+
+func (qc *queryComplexity) selectionSet(parent graphql.Type, set *gqlast.SelectionSet, depth int) (int, error) {
+	if set == nil {
+		return 0, nil
+	}
+	if depth >= qc.maxDepth {
+		return 0, errors.Wrapf(ErrQueryTooDeep, "max depth is %d", qc.maxDepth)
+	}
+
+	var total int
+	for _, selection := range set.Selections {
+		var (
+			cost int
+			err  error
+		)
+		switch typ := selection.(type) {
+		case *gqlast.Field:
+			cost, err = qc.field(parent, typ, depth)
+		case *gqlast.FragmentSpread:
+			fragment, ok := qc.fragments[typ.Name.Value]
+			if !ok {
+				return 0, errors.Errorf("unknown fragment: %s", typ.Name.Value)
+			}
+			cost, err = qc.selectionSet(qc.typeCondition(parent, fragment.TypeCondition), fragment.SelectionSet, depth)
+		case *gqlast.InlineFragment:
+			cost, err = qc.selectionSet(qc.typeCondition(parent, typ.TypeCondition), typ.SelectionSet, depth)
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += cost
+	}
+	return total, nil
+}
+
+func (qc *queryComplexity) field(parent graphql.Type, field *gqlast.Field, depth int) (int, error) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 1, nil
+	}
+
+	definition := fieldDefinition(parent, field.Name.Value)
+	if definition == nil {
+		return 1, nil
+	}
+
+	named, _ := graphql.GetNamed(definition.Type).(graphql.Type)
+	cost, err := qc.selectionSet(named, field.SelectionSet, depth+1)
+	if err != nil {
+		return 0, err
+	}
+	return 1 + qc.multiplier(parent, definition, field)*cost, nil
+}
+
+func (qc *queryComplexity) multiplier(parent graphql.Type, definition *graphql.FieldDefinition, field *gqlast.Field) int {
+	for _, arg := range definition.Args {
+		if arg.Name() != "first" {
+			continue
+		}
+		for _, value := range field.Arguments {
+			if value.Name.Value == arg.Name() {
+				if n, ok := qc.intValue(value.Value); ok {
+					return n
+				}
+			}
+		}
+		if n, ok := arg.DefaultValue.(int); ok {
+			return n
+		}
+	}
+
+	if isGraphQLList(definition.Type) && !strings.HasSuffix(parent.Name(), "Connection") {
+		return graphQLListMultiplier
+	}
+	return 1
+}
+
+func isGraphQLList(typ graphql.Type) bool {
+	if nonNull, ok := typ.(*graphql.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	_, ok := typ.(*graphql.List)
+	return ok
+}
+
+func (qc *queryComplexity) intValue(value gqlast.Value) (int, bool) {
+	switch typ := value.(type) {
+	case *gqlast.IntValue:
+		n, err := strconv.Atoi(typ.Value)
+		return n, err == nil
+	case *gqlast.Variable:
+		switch n := qc.variables[typ.Name.Value].(type) {
+		case int:
+			return n, true
+		case float64:
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+func (qc *queryComplexity) typeCondition(parent graphql.Type, condition *gqlast.Named) graphql.Type {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if typ := qc.schema.Type(condition.Name.Value); typ != nil {
+		return typ
+	}
+	return parent
+}
+
+func fieldDefinition(parent graphql.Type, name string) *graphql.FieldDefinition {
+	switch typ := parent.(type) {
+	case *graphql.Object:
+		return typ.Fields()[name]
+	case *graphql.Interface:
+		return typ.Fields()[name]
+	}
+	return nil
+}
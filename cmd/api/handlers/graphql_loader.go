@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+)
+
+type graphQLLoadersKey struct{}
+
+// graphQLLoader - batches loading of values requested by resolvers of one query. Resolvers register keys and return thunks. GraphQL executor calls thunks after all sibling fields are resolved, so the first call loads every registered key by one query.
+type graphQLLoader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mx      sync.Mutex
+	pending []K
+	queued  map[K]struct{}
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newGraphQLLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *graphQLLoader[K, V] {
+	return &graphQLLoader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]struct{}),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// Load - registers the key and returns thunk receiving its value
+func (l *graphQLLoader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mx.Lock()
+	if _, ok := l.queued[key]; !ok {
+		l.queued[key] = struct{}{}
+		l.pending = append(l.pending, key)
+	}
+	l.mx.Unlock()
+
+	return func() (V, error) {
+		l.mx.Lock()
+		defer l.mx.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+
+			values, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+					continue
+				}
+				l.values[k] = values[k]
+			}
+		}
+		return l.values[key], l.errs[key]
+	}
+}
+
+// graphQLLoaders - loaders of the one GraphQL query
+type graphQLLoaders struct {
+	bigMapDiffs   *graphQLLoader[int64, []bigmapdiff.BigMapDiff]
+	ticketUpdates *graphQLLoader[int64, []ticket.TicketUpdate]
+
+	mx          sync.Mutex
+	bigMapTypes map[int64]*ast.BigMap
+}
+
+func newGraphQLLoaders(ctx *config.Context) *graphQLLoaders {
+	return &graphQLLoaders{
+		bigMapDiffs: newGraphQLLoader(func(c context.Context, ids []int64) (map[int64][]bigmapdiff.BigMapDiff, error) {
+			diffs, err := ctx.BigMapDiffs.GetForOperations(c, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[int64][]bigmapdiff.BigMapDiff, len(ids))
+			for i := range diffs {
+				result[diffs[i].OperationID] = append(result[diffs[i].OperationID], diffs[i])
+			}
+			return result, nil
+		}),
+		ticketUpdates: newGraphQLLoader(func(c context.Context, ids []int64) (map[int64][]ticket.TicketUpdate, error) {
+			updates, err := ctx.Tickets.UpdatesForOperations(c, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[int64][]ticket.TicketUpdate, len(ids))
+			for i := range updates {
+				result[updates[i].OperationId] = append(result[updates[i].OperationId], updates[i])
+			}
+			return result, nil
+		}),
+		bigMapTypes: make(map[int64]*ast.BigMap),
+	}
+}
+
+// bigMapType - returns type of the big map cached for the query
+func (l *graphQLLoaders) bigMapType(c context.Context, ctx *config.Context, contract string, ptr int64, symLink string) (*ast.BigMap, error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if typ, ok := l.bigMapTypes[ptr]; ok {
+		return typ, nil
+	}
+	typ, err := getBigMapType(c, ctx, contract, ptr, symLink)
+	if err != nil {
+		return nil, err
+	}
+	l.bigMapTypes[ptr] = typ
+	return typ, nil
+}
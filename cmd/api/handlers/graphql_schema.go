@@ -0,0 +1,818 @@
+package handlers
+
+import (
+	"context"
+	stdJSON "encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/graphql-go/graphql"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+type graphQLOperation struct {
+	Operation
+
+	model    operation.Operation
+	mx       sync.Mutex
+	prepared map[bool]*Operation
+}
+
+func newGraphQLOperation(ctx *config.Context, model operation.Operation) *graphQLOperation {
+	op := &graphQLOperation{
+		model:    model,
+		prepared: make(map[bool]*Operation),
+	}
+	op.FromModel(model)
+	op.Network = ctx.Network.String()
+	return op
+}
+
+// Resolve - resolves scalar fields from the operation response
+func (op *graphQLOperation) Resolve(p graphql.ResolveParams) (interface{}, error) {
+	p.Source = &op.Operation
+	return graphql.DefaultResolveFn(p)
+}
+
+// prepare - decodes parameters, payload and storage diff of the operation once per request
+func (op *graphQLOperation) prepare(p graphql.ResolveParams, withStorageDiff bool) (*Operation, error) {
+	op.mx.Lock()
+	defer op.mx.Unlock()
+
+	if prepared, ok := op.prepared[withStorageDiff]; ok {
+		return prepared, nil
+	}
+
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	response, err := prepareOperation(p.Context, ctx, op.model, withStorageDiff)
+	if err != nil {
+		return nil, err
+	}
+	op.prepared[withStorageDiff] = &response
+	return &response, nil
+}
+
+type graphQLContract struct {
+	Contract
+
+	accountID int64
+}
+
+// Resolve - resolves scalar fields from the contract response
+func (c *graphQLContract) Resolve(p graphql.ResolveParams) (interface{}, error) {
+	p.Source = &c.Contract
+	return graphql.DefaultResolveFn(p)
+}
+
+type graphQLSmartRollup struct {
+	SmartRollup
+
+	typ []byte
+}
+
+// Resolve - resolves scalar fields from the smart rollup response
+func (sr *graphQLSmartRollup) Resolve(p graphql.ResolveParams) (interface{}, error) {
+	p.Source = &sr.SmartRollup
+	return graphql.DefaultResolveFn(p)
+}
+
+type graphQLBigMapDiff struct {
+	ID        int64
+	Ptr       int64
+	Key       interface{}
+	KeyHash   string
+	KeyString string
+	Value     interface{}
+	Level     int64
+	Timestamp time.Time
+	Contract  string
+}
+
+type graphQLOperationConnection struct {
+	Edges    []graphQLOperationEdge
+	PageInfo graphQLPageInfo
+}
+
+type graphQLOperationEdge struct {
+	Cursor string
+	Node   *graphQLOperation
+}
+
+type graphQLPageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+func graphQLPageArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 10,
+		},
+		"offset": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 0,
+		},
+	}
+}
+
+func graphQLList(typ graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typ)))
+}
+
+// NewGraphQLSchema - creates GraphQL schema over contracts, operations, big maps, tickets, smart rollups and global constants
+func NewGraphQLSchema() (graphql.Schema, error) {
+	ticketUpdateType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TicketUpdate",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"level":         &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"ticketId":      &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"ticketer":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"address":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"amount":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"operationHash": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"contentType":   &graphql.Field{Type: graphQLJSON},
+			"content":       &graphql.Field{Type: graphQLJSON},
+		},
+	})
+
+	bigMapDiffType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BigMapDiff",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"ptr":       &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"key":       &graphql.Field{Type: graphQLJSON},
+			"keyHash":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"keyString": &graphql.Field{Type: graphql.String},
+			"value":     &graphql.Field{Type: graphQLJSON},
+			"level":     &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"contract":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	bigMapItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BigMapItem",
+		Fields: graphql.Fields{
+			"key":       &graphql.Field{Type: graphQLJSON},
+			"keyHash":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"keyString": &graphql.Field{Type: graphql.String},
+			"level":     &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"isActive":  &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	bigMapKeyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BigMapKey",
+		Fields: graphql.Fields{
+			"data":  &graphql.Field{Type: graphql.NewNonNull(bigMapItemType)},
+			"count": &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+		},
+	})
+
+	globalConstantType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GlobalConstant",
+		Fields: graphql.Fields{
+			"address":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"level":     &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"value":     &graphql.Field{Type: graphQLJSON},
+			"code": &graphql.Field{
+				Type:    graphql.String,
+				Resolve: resolveGlobalConstantCode,
+			},
+		},
+	})
+
+	smartRollupType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SmartRollup",
+		Fields: graphql.Fields{
+			"id":                    &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"address":               &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"level":                 &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp":             &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"size":                  &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"genesisCommitmentHash": &graphql.Field{Type: graphql.String},
+			"pvmKind":               &graphql.Field{Type: graphql.String},
+			"kernel":                &graphql.Field{Type: graphql.String},
+			"type": &graphql.Field{
+				Type:    graphQLJSON,
+				Resolve: resolveSmartRollupType,
+			},
+		},
+	})
+
+	operationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Operation",
+		Fields: graphql.Fields{
+			"id":                                 &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"hash":                               &graphql.Field{Type: graphql.String},
+			"network":                            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"level":                              &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp":                          &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"kind":                               &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":                             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"source":                             &graphql.Field{Type: graphql.String},
+			"destination":                        &graphql.Field{Type: graphql.String},
+			"delegate":                           &graphql.Field{Type: graphql.String},
+			"entrypoint":                         &graphql.Field{Type: graphql.String},
+			"tag":                                &graphql.Field{Type: graphql.String},
+			"counter":                            &graphql.Field{Type: graphQLInt64},
+			"contentIndex":                       &graphql.Field{Type: graphQLInt64},
+			"amount":                             &graphql.Field{Type: graphQLInt64},
+			"fee":                                &graphql.Field{Type: graphQLInt64},
+			"gasLimit":                           &graphql.Field{Type: graphQLInt64},
+			"storageLimit":                       &graphql.Field{Type: graphQLInt64},
+			"consumedGas":                        &graphql.Field{Type: graphQLInt64},
+			"storageSize":                        &graphql.Field{Type: graphQLInt64},
+			"paidStorageSizeDiff":                &graphql.Field{Type: graphQLInt64},
+			"burned":                             &graphql.Field{Type: graphQLInt64},
+			"allocatedDestinationContractBurned": &graphql.Field{Type: graphQLInt64},
+			"allocatedDestinationContract":       &graphql.Field{Type: graphql.Boolean},
+			"internal":                           &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"bigMapDiffsCount":                   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"ticketUpdatesCount":                 &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"protocol": &graphql.Field{
+				Type:    graphql.String,
+				Resolve: resolveOperationProtocol,
+			},
+			"errors": &graphql.Field{
+				Type: graphQLJSON,
+				Resolve: resolvePreparedOperation(false, func(op *Operation) interface{} {
+					if len(op.Errors) == 0 {
+						return nil
+					}
+					return op.Errors
+				}),
+			},
+			"parameters": &graphql.Field{
+				Type: graphQLJSON,
+				Resolve: resolvePreparedOperation(false, func(op *Operation) interface{} {
+					return op.Parameters
+				}),
+			},
+			"payload": &graphql.Field{
+				Type: graphQLJSON,
+				Resolve: resolvePreparedOperation(false, func(op *Operation) interface{} {
+					if len(op.Payload) == 0 {
+						return nil
+					}
+					return op.Payload
+				}),
+			},
+			"storageDiff": &graphql.Field{
+				Type: graphQLJSON,
+				Resolve: resolvePreparedOperation(true, func(op *Operation) interface{} {
+					return op.StorageDiff
+				}),
+			},
+			"bigMapDiffs": &graphql.Field{
+				Type:    graphQLList(bigMapDiffType),
+				Resolve: resolveOperationBigMapDiffs,
+			},
+			"ticketUpdates": &graphql.Field{
+				Type:    graphQLList(ticketUpdateType),
+				Resolve: resolveOperationTicketUpdates,
+			},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	operationEdgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OperationEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(operationType)},
+		},
+	})
+
+	operationConnectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OperationConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphQLList(operationEdgeType)},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	bigMapKeysArgs := graphQLPageArgs()
+	bigMapKeysArgs["ptr"] = &graphql.ArgumentConfig{
+		Type: graphql.NewNonNull(graphQLInt64),
+	}
+
+	contractType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Contract",
+		Fields: graphql.Fields{
+			"address":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"network":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"level":       &graphql.Field{Type: graphql.NewNonNull(graphQLInt64)},
+			"timestamp":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"hash":        &graphql.Field{Type: graphql.String},
+			"manager":     &graphql.Field{Type: graphql.String},
+			"delegate":    &graphql.Field{Type: graphql.String},
+			"tags":        &graphql.Field{Type: graphQLList(graphql.String)},
+			"entrypoints": &graphql.Field{Type: graphQLList(graphql.String)},
+			"failStrings": &graphql.Field{Type: graphQLList(graphql.String)},
+			"annotations": &graphql.Field{Type: graphQLList(graphql.String)},
+			"operations": &graphql.Field{
+				Type: graphql.NewNonNull(operationConnectionType),
+				Args: graphql.FieldConfigArgument{
+					"first": &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: 10,
+					},
+					"after": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+				},
+				Resolve: resolveContractOperations,
+			},
+			"bigMapKeys": &graphql.Field{
+				Type:    graphQLList(bigMapKeyType),
+				Args:    bigMapKeysArgs,
+				Resolve: resolveBigMapKeys,
+			},
+			"ticketUpdates": &graphql.Field{
+				Type:    graphQLList(ticketUpdateType),
+				Args:    graphQLPageArgs(),
+				Resolve: resolveContractTicketUpdates,
+			},
+			"globalConstants": &graphql.Field{
+				Type:    graphQLList(globalConstantType),
+				Args:    graphQLPageArgs(),
+				Resolve: resolveContractGlobalConstants,
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"contract": &graphql.Field{
+				Type: contractType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolveContract,
+			},
+			"operation": &graphql.Field{
+				Type: operationType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: resolveOperation,
+			},
+			"operations": &graphql.Field{
+				Type: graphQLList(operationType),
+				Args: graphql.FieldConfigArgument{
+					"hash": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolveOperationsByHash,
+			},
+			"bigMapKeys": &graphql.Field{
+				Type:    graphQLList(bigMapKeyType),
+				Args:    bigMapKeysArgs,
+				Resolve: resolveBigMapKeys,
+			},
+			"smartRollup": &graphql.Field{
+				Type: smartRollupType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolveSmartRollup,
+			},
+			"smartRollups": &graphql.Field{
+				Type:    graphQLList(smartRollupType),
+				Args:    graphQLPageArgs(),
+				Resolve: resolveSmartRollups,
+			},
+			"globalConstant": &graphql.Field{
+				Type: globalConstantType,
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolveGlobalConstant,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+func resolveContract(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	address, _ := p.Args["address"].(string)
+	model, err := ctx.Contracts.Get(p.Context, address)
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	response, err := contractPostprocessing(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLContract{
+		Contract:  response,
+		accountID: model.AccountID,
+	}, nil
+}
+
+func resolveContractOperations(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	contract, ok := p.Source.(*graphQLContract)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+
+	first, _, err := graphQLPage(p)
+	if err != nil {
+		return nil, err
+	}
+	var lastID int64
+	if after, ok := p.Args["after"].(string); ok && after != "" {
+		lastID, err = decodeOperationCursor(after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	operations, err := ctx.Operations.ListByAccount(p.Context, contract.accountID, lastID, first+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var connection graphQLOperationConnection
+	if int64(len(operations)) > first {
+		connection.PageInfo.HasNextPage = true
+		operations = operations[:first]
+	}
+	connection.Edges = make([]graphQLOperationEdge, len(operations))
+	for i := range operations {
+		connection.Edges[i] = graphQLOperationEdge{
+			Cursor: encodeOperationCursor(operations[i].ID),
+			Node:   newGraphQLOperation(ctx, operations[i]),
+		}
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection, nil
+}
+
+func resolveOperation(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := p.Args["id"].(string)
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid operation id")
+	}
+	model, err := ctx.Operations.GetByID(p.Context, id)
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newGraphQLOperation(ctx, model), nil
+}
+
+func resolveOperationsByHash(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := p.Args["hash"].(string)
+	hash, err := encoding.DecodeBase58(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid operation hash")
+	}
+	operations, err := ctx.Operations.GetByHash(p.Context, hash)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*graphQLOperation, len(operations))
+	for i := range operations {
+		result[i] = newGraphQLOperation(ctx, operations[i])
+	}
+	return result, nil
+}
+
+func resolveOperationProtocol(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.Source.(*graphQLOperation)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+	proto, err := ctx.Cache.ProtocolByID(p.Context, op.model.ProtocolID)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Hash, nil
+}
+
+func resolvePreparedOperation(withStorageDiff bool, field func(op *Operation) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		op, ok := p.Source.(*graphQLOperation)
+		if !ok {
+			return nil, errors.Errorf("unexpected source: %T", p.Source)
+		}
+		prepared, err := op.prepare(p, withStorageDiff)
+		if err != nil {
+			return nil, err
+		}
+		return field(prepared), nil
+	}
+}
+
+// resolveOperationBigMapDiffs - diffs of all operations of the list are loaded by one query
+func resolveOperationBigMapDiffs(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	loaders, err := graphQLQueryLoaders(p)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.Source.(*graphQLOperation)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+
+	load := loaders.bigMapDiffs.Load(p.Context, op.model.ID)
+	return func() (interface{}, error) {
+		diffs, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return prepareGraphQLBigMapDiffs(p.Context, ctx, loaders, diffs)
+	}, nil
+}
+
+func prepareGraphQLBigMapDiffs(c context.Context, ctx *config.Context, loaders *graphQLLoaders, diffs []bigmapdiff.BigMapDiff) ([]graphQLBigMapDiff, error) {
+	if len(diffs) == 0 {
+		return []graphQLBigMapDiff{}, nil
+	}
+
+	symLink, err := getCurrentSymLink(c, ctx.Blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphQLBigMapDiff, len(diffs))
+	for i := range diffs {
+		result[i] = graphQLBigMapDiff{
+			ID:        diffs[i].ID,
+			Ptr:       diffs[i].Ptr,
+			KeyHash:   diffs[i].KeyHash,
+			Level:     diffs[i].Level,
+			Timestamp: diffs[i].Timestamp.UTC(),
+			Contract:  diffs[i].Contract,
+		}
+
+		// temporary big maps are absent in the contract storage so their diffs are returned as is
+		if diffs[i].Ptr < 0 {
+			result[i].Key = stdJSON.RawMessage(diffs[i].Key)
+			if diffs[i].Value != nil {
+				result[i].Value = stdJSON.RawMessage(diffs[i].Value)
+			}
+			continue
+		}
+
+		bigMapType, err := loaders.bigMapType(c, ctx, diffs[i].Contract, diffs[i].Ptr, symLink)
+		if err != nil {
+			return nil, err
+		}
+
+		key, value, keyString, err := prepareItem(diffs[i].Key, diffs[i].Value, bigMapType)
+		if err != nil {
+			return nil, err
+		}
+		result[i].Key = key
+		result[i].KeyString = keyString
+		result[i].Value = value
+	}
+	return result, nil
+}
+
+// resolveOperationTicketUpdates - updates of all operations of the list are loaded by one query
+func resolveOperationTicketUpdates(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	loaders, err := graphQLQueryLoaders(p)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.Source.(*graphQLOperation)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+
+	load := loaders.ticketUpdates.Load(p.Context, op.model.ID)
+	return func() (interface{}, error) {
+		updates, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return prepareTicketUpdates(p.Context, ctx, updates, op.model.Hash)
+	}, nil
+}
+
+func resolveBigMapKeys(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	first, offset, err := graphQLPage(p)
+	if err != nil {
+		return nil, err
+	}
+	ptr, ok := p.Args["ptr"].(int64)
+	if !ok {
+		return nil, errors.New("invalid big map pointer")
+	}
+
+	req := bigmapdiff.GetContext{
+		Ptr:    &ptr,
+		Size:   first,
+		Offset: offset,
+	}
+	if contract, ok := p.Source.(*graphQLContract); ok {
+		req.Contract = contract.Address
+	}
+
+	keys, err := ctx.BigMapDiffs.Keys(p.Context, req)
+	if err != nil {
+		return nil, err
+	}
+	symLink, err := getCurrentSymLink(p.Context, ctx.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	return prepareBigMapKeys(p.Context, ctx, keys, symLink)
+}
+
+func resolveContractTicketUpdates(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	contract, ok := p.Source.(*graphQLContract)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+	first, offset, err := graphQLPage(p)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := ctx.Tickets.Updates(p.Context, ticket.UpdatesRequest{
+		Ticketer: contract.Address,
+		Limit:    first,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prepareTicketUpdates(p.Context, ctx, updates, nil)
+}
+
+func resolveContractGlobalConstants(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	contract, ok := p.Source.(*graphQLContract)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+	first, offset, err := graphQLPage(p)
+	if err != nil {
+		return nil, err
+	}
+	constants, err := ctx.GlobalConstants.ForContract(p.Context, contract.Address, first, offset)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]GlobalConstant, len(constants))
+	for i := range constants {
+		result[i] = NewGlobalConstantFromModel(constants[i])
+	}
+	return result, nil
+}
+
+func resolveGlobalConstant(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	address, _ := p.Args["address"].(string)
+	constant, err := ctx.GlobalConstants.Get(p.Context, address)
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return NewGlobalConstantFromModel(constant), nil
+}
+
+func resolveGlobalConstantCode(p graphql.ResolveParams) (interface{}, error) {
+	constant, ok := p.Source.(GlobalConstant)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+	return formatter.MichelineToMichelson(gjson.ParseBytes(constant.Value), false, formatter.DefLineSize)
+}
+
+func resolveSmartRollup(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	address, _ := p.Args["address"].(string)
+	rollup, err := ctx.SmartRollups.Get(p.Context, address)
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &graphQLSmartRollup{
+		SmartRollup: NewSmartRollup(rollup),
+		typ:         rollup.Type,
+	}, nil
+}
+
+func resolveSmartRollups(p graphql.ResolveParams) (interface{}, error) {
+	ctx, err := graphQLNetworkContext(p)
+	if err != nil {
+		return nil, err
+	}
+	first, offset, err := graphQLPage(p)
+	if err != nil {
+		return nil, err
+	}
+	rollups, err := ctx.SmartRollups.List(p.Context, first, offset, "desc")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*graphQLSmartRollup, len(rollups))
+	for i := range rollups {
+		result[i] = &graphQLSmartRollup{
+			SmartRollup: NewSmartRollup(rollups[i]),
+			typ:         rollups[i].Type,
+		}
+	}
+	return result, nil
+}
+
+func resolveSmartRollupType(p graphql.ResolveParams) (interface{}, error) {
+	rollup, ok := p.Source.(*graphQLSmartRollup)
+	if !ok {
+		return nil, errors.Errorf("unexpected source: %T", p.Source)
+	}
+	typ, err := ast.NewTypedAstFromBytes(rollup.typ)
+	if err != nil {
+		return nil, err
+	}
+	return typ.Docs("")
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_bmd "github.com/baking-bad/bcdhub/internal/models/mock/bigmapdiff"
+	mock_block "github.com/baking-bad/bcdhub/internal/models/mock/block"
+	mock_contract "github.com/baking-bad/bcdhub/internal/models/mock/contract"
+	mock_operation "github.com/baking-bad/bcdhub/internal/models/mock/operation"
+	mock_ticket "github.com/baking-bad/bcdhub/internal/models/mock/ticket"
+)
+
+const testGraphQLContract = "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"
+
+func TestQueryComplexity(t *testing.T) {
+	schema, err := NewGraphQLSchema()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		maxDepth  int
+		want      int
+		wantErr   error
+	}{
+		{
+			name:     "scalar fields",
+			query:    `{ contract(address: "KT1") { address level } }`,
+			maxDepth: 10,
+			want:     3,
+		}, {
+			name:     "default page size",
+			query:    `{ contract(address: "KT1") { operations { edges { cursor } } } }`,
+			maxDepth: 10,
+			want:     1 + 1 + 10*(1+1),
+		}, {
+			name:     "literal page size",
+			query:    `{ contract(address: "KT1") { operations(first: 3) { edges { node { id kind } } pageInfo { hasNextPage } } } }`,
+			maxDepth: 10,
+			want:     1 + 1 + 3*(1+1+2+1+1),
+		}, {
+			name:      "variable page size",
+			query:     `query($first: Int) { smartRollups(first: $first) { address } }`,
+			variables: map[string]interface{}{"first": float64(5)},
+			maxDepth:  10,
+			want:      1 + 5,
+		}, {
+			name:     "fragments",
+			query:    `{ smartRollups(first: 2) { ...rollup } } fragment rollup on SmartRollup { address level }`,
+			maxDepth: 10,
+			want:     1 + 2*2,
+		}, {
+			name:     "introspection",
+			query:    `{ __typename contract(address: "KT1") { __typename } }`,
+			maxDepth: 10,
+			want:     3,
+		}, {
+			name:     "unbounded lists",
+			query:    `{ operations(hash: "oo") { id bigMapDiffs { key } ticketUpdates { amount } } }`,
+			maxDepth: 10,
+			want:     1 + 10*(1+(1+10*1)+(1+10*1)),
+		}, {
+			name:     "too deep",
+			query:    `{ contract(address: "KT1") { operations { edges { node { id } } } } }`,
+			maxDepth: 3,
+			wantErr:  ErrQueryTooDeep,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			require.NoError(t, err)
+
+			got, err := newQueryComplexity(&schema, doc, tt.variables, tt.maxDepth).Operation(doc, "")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOperationCursor(t *testing.T) {
+	cursor := encodeOperationCursor(1234567)
+	id, err := decodeOperationCursor(cursor)
+	require.NoError(t, err)
+	require.EqualValues(t, 1234567, id)
+
+	_, err = decodeOperationCursor("invalid")
+	require.Error(t, err)
+}
+
+func TestGraphQLContractOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contracts := mock_contract.NewMockRepository(ctrl)
+	operations := mock_operation.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+
+	ctx := &config.Context{
+		Network:    types.Mainnet,
+		Contracts:  contracts,
+		Operations: operations,
+		Storage:    storage,
+	}
+
+	schema, err := NewGraphQLSchema()
+	require.NoError(t, err)
+
+	contracts.EXPECT().
+		Get(gomock.Any(), testGraphQLContract).
+		Return(contract.Contract{
+			AccountID: 10,
+			Account:   account.Account{ID: 10, Address: testGraphQLContract},
+			Level:     100,
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+		}, nil).
+		Times(1)
+
+	operations.EXPECT().
+		ListByAccount(gomock.Any(), int64(10), int64(30), int64(3)).
+		Return([]operation.Operation{
+			{ID: 29, Kind: types.OperationKindTransaction, Status: types.OperationStatusApplied, Source: account.Account{Address: "tz1a"}},
+			{ID: 25, Kind: types.OperationKindTransaction, Status: types.OperationStatusFailed, Source: account.Account{Address: "tz1b"}},
+			{ID: 21, Kind: types.OperationKindOrigination, Status: types.OperationStatusApplied, Source: account.Account{Address: "tz1c"}},
+		}, nil).
+		Times(1)
+
+	result := executeGraphQL(context.Background(), ctx, &schema, GraphQLRequest{
+		Query: `query($after: String) {
+			contract(address: "` + testGraphQLContract + `") {
+				address
+				level
+				operations(first: 2, after: $after) {
+					edges { cursor node { id kind status source network } }
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"after": encodeOperationCursor(30),
+		},
+	}, 10, 100)
+	require.Empty(t, result.Errors)
+
+	data, ok := result.Data.(map[string]interface{})
+	require.True(t, ok)
+	contractData := data["contract"].(map[string]interface{})
+	require.Equal(t, testGraphQLContract, contractData["address"])
+	require.EqualValues(t, 100, contractData["level"])
+
+	connection := contractData["operations"].(map[string]interface{})
+	edges := connection["edges"].([]interface{})
+	require.Len(t, edges, 2)
+
+	node := edges[1].(map[string]interface{})["node"].(map[string]interface{})
+	require.Equal(t, "25", node["id"])
+	require.Equal(t, "transaction", node["kind"])
+	require.Equal(t, "failed", node["status"])
+	require.Equal(t, "tz1b", node["source"])
+	require.Equal(t, "mainnet", node["network"])
+
+	pageInfo := connection["pageInfo"].(map[string]interface{})
+	require.Equal(t, true, pageInfo["hasNextPage"])
+	require.Equal(t, encodeOperationCursor(25), pageInfo["endCursor"])
+}
+
+func TestGraphQLComplexityLimit(t *testing.T) {
+	schema, err := NewGraphQLSchema()
+	require.NoError(t, err)
+
+	result := executeGraphQL(context.Background(), &config.Context{}, &schema, GraphQLRequest{
+		Query: `{ smartRollups(first: 100) { address level timestamp size pvmKind } }`,
+	}, 10, 100)
+	require.Len(t, result.Errors, 1)
+	require.Contains(t, result.Errors[0].Message, ErrQueryTooComplex.Error())
+	require.Nil(t, result.Data)
+}
+
+func TestGraphQLBatchLoading(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operations := mock_operation.NewMockRepository(ctrl)
+	bigMapDiffs := mock_bmd.NewMockRepository(ctrl)
+	tickets := mock_ticket.NewMockRepository(ctrl)
+	blocks := mock_block.NewMockRepository(ctrl)
+
+	ctx := &config.Context{
+		Network:     types.Mainnet,
+		Operations:  operations,
+		BigMapDiffs: bigMapDiffs,
+		Tickets:     tickets,
+		Blocks:      blocks,
+	}
+
+	schema, err := NewGraphQLSchema()
+	require.NoError(t, err)
+
+	hash := "oneDGhZacw99EEFaYDTtWfz5QEhUW3PPVFsHa7GShnLPuDn7gSd"
+	operations.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return([]operation.Operation{
+			{ID: 1, Kind: types.OperationKindTransaction},
+			{ID: 2, Kind: types.OperationKindTransaction},
+			{ID: 3, Kind: types.OperationKindTransaction},
+		}, nil).
+		Times(1)
+
+	bigMapDiffs.EXPECT().
+		GetForOperations(gomock.Any(), []int64{1, 2, 3}).
+		Return([]bigmapdiff.BigMapDiff{
+			{ID: 10, OperationID: 1, Ptr: -1, Key: []byte(`{"int":"1"}`), KeyHash: "expru1"},
+			{ID: 11, OperationID: 3, Ptr: -1, Key: []byte(`{"int":"2"}`), KeyHash: "expru2"},
+			{ID: 12, OperationID: 3, Ptr: -1, Key: []byte(`{"int":"3"}`), KeyHash: "expru3"},
+		}, nil).
+		Times(1)
+	tickets.EXPECT().
+		UpdatesForOperations(gomock.Any(), []int64{1, 2, 3}).
+		Return(nil, nil).
+		Times(1)
+	blocks.EXPECT().
+		Last(gomock.Any()).
+		Return(block.Block{}, nil).
+		AnyTimes()
+
+	result := executeGraphQL(context.Background(), ctx, &schema, GraphQLRequest{
+		Query: `{ operations(hash: "` + hash + `") { id bigMapDiffs { keyHash } ticketUpdates { amount } } }`,
+	}, 10, 1000)
+	require.Empty(t, result.Errors)
+
+	data := result.Data.(map[string]interface{})
+	list := data["operations"].([]interface{})
+	require.Len(t, list, 3)
+
+	counts := make([]int, len(list))
+	for i := range list {
+		counts[i] = len(list[i].(map[string]interface{})["bigMapDiffs"].([]interface{}))
+		require.Empty(t, list[i].(map[string]interface{})["ticketUpdates"])
+	}
+	require.Equal(t, []int{1, 0, 2}, counts)
+}
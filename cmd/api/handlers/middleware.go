@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"strings"
 
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/models/types"
@@ -52,3 +54,29 @@ func ContextsMiddleware(ctxs config.Contexts) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// WebhooksAuthMiddleware - authenticates owner of webhooks by `Authorization: Bearer <token>` header. `tokens` maps owner name to its token.
+func WebhooksAuthMiddleware(tokens map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, Error{Message: "invalid authentication"})
+			return
+		}
+
+		var owner string
+		for name, expected := range tokens {
+			if expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+				owner = name
+			}
+		}
+		if owner == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, Error{Message: "invalid authentication"})
+			return
+		}
+
+		c.Set("webhook_owner", owner)
+
+		c.Next()
+	}
+}
@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 )
@@ -324,6 +329,119 @@ func GetByHashAndCounter() gin.HandlerFunc {
 	}
 }
 
+// SearchOperations godoc
+// @Summary Search operations
+// @Description Search operations by combination of filters. Operations are sorted by timestamp descending. Use `last_id` from response to get the next page.
+// @Tags operations
+// @ID search-operations
+// @Param network path string true "Network"
+// @Param kind query string false "Comma-separated list of operation kinds" example(transaction,origination)
+// @Param status query string false "Comma-separated list of operation statuses" example(failed,backtracked)
+// @Param entrypoint query string false "Entrypoint"
+// @Param source query string false "Source address" minlength(36) maxlength(36)
+// @Param destination query string false "Destination address" minlength(36) maxlength(36)
+// @Param tag query string false "Event tag"
+// @Param min_level query integer false "Minimal level (inclusive)"
+// @Param max_level query integer false "Maximal level (inclusive)"
+// @Param from query integer false "Minimal timestamp in seconds (inclusive)"
+// @Param to query integer false "Maximal timestamp in seconds (inclusive)"
+// @Param min_amount query integer false "Minimal amount in mutez"
+// @Param error_id query string false "Error identifier, e.g. michelson_v1.script_rejected"
+// @Param last_id query string false "Cursor returned in previous response"
+// @Param size query integer false "Operations count" mininum(1) maximum(10)
+// @Param with_storage_diff query bool false "Include storage diff to operations or not"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} OperationResponse
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/operations/{network}/search [get]
+func SearchOperations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var args searchOperationsRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		req := operation.SearchRequest{
+			Entrypoint:  args.Entrypoint,
+			Source:      args.Source,
+			Destination: args.Destination,
+			Tag:         args.Tag,
+			MinLevel:    args.MinLevel,
+			MaxLevel:    args.MaxLevel,
+			MinAmount:   args.MinAmount,
+			ErrorID:     args.ErrorID,
+			Size:        args.Size,
+		}
+		if args.Kind != "" {
+			for _, kind := range strings.Split(args.Kind, ",") {
+				req.Kinds = append(req.Kinds, types.NewOperationKind(kind))
+			}
+		}
+		if args.Status != "" {
+			for _, status := range strings.Split(args.Status, ",") {
+				req.Statuses = append(req.Statuses, types.NewOperationStatus(status))
+			}
+		}
+		if args.From > 0 {
+			req.From = time.Unix(args.From, 0).UTC()
+		}
+		if args.To > 0 {
+			req.To = time.Unix(args.To, 0).UTC()
+		}
+		if args.LastID != "" {
+			timestamp, id, err := parseSearchCursor(args.LastID)
+			if handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+				return
+			}
+			req.LastTimestamp = timestamp
+			req.LastID = id
+		}
+
+		operations, err := ctx.Operations.Search(c.Request.Context(), req)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		prepared, err := PrepareOperations(c.Request.Context(), ctx, operations, args.WithStorageDiff)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := OperationResponse{
+			Operations: prepared,
+		}
+		if len(operations) > 0 {
+			last := operations[len(operations)-1]
+			response.LastID = searchCursor(last.Timestamp, last.ID)
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+func searchCursor(timestamp time.Time, id int64) string {
+	return fmt.Sprintf("%d_%d", timestamp.Unix(), id)
+}
+
+func parseSearchCursor(cursor string) (time.Time, int64, error) {
+	parts := strings.Split(cursor, "_")
+	if len(parts) != 2 {
+		return time.Time{}, 0, errors.Errorf("invalid cursor: %s", cursor)
+	}
+	timestamp, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.Wrap(err, "invalid cursor")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.Wrap(err, "invalid cursor")
+	}
+	return time.Unix(timestamp, 0).UTC(), id, nil
+}
+
 func getOperationFromMempool(c context.Context, ctx *config.Context, hash string) (*Operation, error) {
 	res, err := ctx.Mempool.GetByHash(c, hash)
 	if err != nil {
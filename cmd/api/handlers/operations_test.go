@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCursor(t *testing.T) {
+	timestamp := time.Date(2022, 1, 25, 17, 4, 35, 0, time.UTC)
+
+	cursor := searchCursor(timestamp, 114)
+	require.Equal(t, "1643130275_114", cursor)
+
+	gotTimestamp, gotID, err := parseSearchCursor(cursor)
+	require.NoError(t, err)
+	require.Equal(t, timestamp, gotTimestamp)
+	require.EqualValues(t, 114, gotID)
+
+	for _, invalid := range []string{"", "114", "a_114", "1643130275_b", "1_2_3"} {
+		_, _, err := parseSearchCursor(invalid)
+		require.Error(t, err, invalid)
+	}
+}
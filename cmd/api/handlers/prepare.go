@@ -97,6 +97,32 @@ func prepareSrExecute(ctx context.Context, cfgCtx *config.Context, operation ope
 	return response, nil
 }
 
+const (
+	stakingParameterType               = `{"prim":"unit"}`
+	setDelegateParametersParameterType = `{"prim":"pair","args":[{"prim":"int","annots":["%limit_of_staking_over_baking_millionth"]},{"prim":"pair","args":[{"prim":"int","annots":["%edge_of_baking_over_staking_billionth"]},{"prim":"unit"}]}]}`
+)
+
+func prepareStaking(ctx context.Context, cfgCtx *config.Context, operation operation.Operation) (Operation, error) {
+	response, _, err := newOperationResponse(ctx, cfgCtx, operation)
+	if err != nil {
+		return response, err
+	}
+	if len(operation.Parameters) == 0 || tezerrors.HasParametersError(response.Errors) {
+		return response, nil
+	}
+
+	typ := stakingParameterType
+	if operation.Kind == modelTypes.OperationKindSetDelegateParameters {
+		typ = setDelegateParametersParameterType
+	}
+	parameterType, err := ast.NewTypedAstFromString(typ)
+	if err != nil {
+		return response, err
+	}
+	err = setParameters(operation.Parameters, parameterType, &response)
+	return response, err
+}
+
 func prepareTransaction(ctx context.Context, cfgCtx *config.Context, operation operation.Operation, withStorageDiff bool) (Operation, error) {
 	response, proto, err := newOperationResponse(ctx, cfgCtx, operation)
 	if err != nil {
@@ -196,9 +222,21 @@ func prepareOperation(ctx context.Context, cfgCtx *config.Context, operation ope
 	case modelTypes.OperationKindRegisterGlobalConstant:
 		response, _, err := newOperationResponse(ctx, cfgCtx, operation)
 		return response, err
-	case modelTypes.OperationKindSrOrigination:
+	case modelTypes.OperationKindSrOrigination,
+		modelTypes.OperationKindSrAddMessages,
+		modelTypes.OperationKindSrCement,
+		modelTypes.OperationKindSrPublish,
+		modelTypes.OperationKindSrRefute,
+		modelTypes.OperationKindSrTimeout,
+		modelTypes.OperationKindSrRecoverBond,
+		modelTypes.OperationKindIncreasePaidStorage:
 		response, _, err := newOperationResponse(ctx, cfgCtx, operation)
 		return response, err
+	case modelTypes.OperationKindStake,
+		modelTypes.OperationKindUnstake,
+		modelTypes.OperationKindFinalizeUnstake,
+		modelTypes.OperationKindSetDelegateParameters:
+		return prepareStaking(ctx, cfgCtx, operation)
 	default:
 		return Operation{}, errors.Errorf("unknown operation kind: %s", operation.Kind.String())
 	}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// GetReorgs godoc
+// @Summary Get chain reorganizations
+// @Description Get chain reorganizations detected by indexer: fork point level, count of rolled back blocks and hashes of the diverged blocks. Newest events go first.
+// @Tags reorgs
+// @ID get-reorgs
+// @Param network path  string  true  "network"
+// @Param size    query integer false "Events count" mininum(1) maximum(10)
+// @Param offset  query integer false "Offset"       mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} Reorg
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/reorgs/{network} [get]
+func GetReorgs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var args pageableRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		events, err := ctx.Reorgs.List(c.Request.Context(), args.Size, args.Offset)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]Reorg, len(events))
+		for i := range events {
+			response[i] = NewReorg(events[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 )
 
@@ -194,6 +195,13 @@ type storageRequest struct {
 	Level int `binding:"omitempty,gte=1" form:"level"`
 }
 
+type storageDiffRequest struct {
+	FromLevel     int64 `binding:"required_without=FromOperation,excluded_with=FromOperation,min=0" form:"from_level"`
+	FromOperation int64 `binding:"required_without=FromLevel,excluded_with=FromLevel,min=0"         form:"from_operation"`
+	ToLevel       int64 `binding:"required_without=ToOperation,excluded_with=ToOperation,min=0"     form:"to_level"`
+	ToOperation   int64 `binding:"required_without=ToLevel,excluded_with=ToLevel,min=0"             form:"to_operation"`
+}
+
 // GetTokenStatsRequest -
 type GetTokenStatsRequest struct {
 	Period    string `binding:"oneof=all year month week day hour" example:"year" form:"period"`
@@ -259,9 +267,106 @@ type ticketBalancesRequest struct {
 	WithoutZeroBalances bool `binding:"omitempty" form:"skip_empty"`
 }
 
+type tokenBalancesRequest struct {
+	pageableRequest
+
+	Holder              string  `binding:"omitempty,address" form:"holder"`
+	TokenId             *uint64 `binding:"omitempty"         form:"token_id"`
+	WithoutZeroBalances bool    `binding:"omitempty"         form:"skip_empty"`
+}
+
+type tokenTransfersRequest struct {
+	pageableRequest
+
+	Account string  `binding:"omitempty,address" form:"account"`
+	TokenId *uint64 `binding:"omitempty"         form:"token_id"`
+}
+
 type ticketUpdatesRequest struct {
 	pageableRequest
 
 	Account  string  `binding:"omitempty,address" form:"account"`
 	TicketId *uint64 `binding:"omitempty"         form:"ticket_id"`
 }
+
+type streamOperationsRequest struct {
+	Address         string `binding:"omitempty,address"        form:"address"`
+	Entrypoint      string `binding:"omitempty"                form:"entrypoint"`
+	Kind            string `binding:"omitempty,operation_kind" form:"kind"`
+	Ptr             *int64 `binding:"omitempty,min=0"          form:"ptr"`
+	WithStorageDiff bool   `binding:"omitempty"                form:"with_storage_diff"`
+}
+
+type createWebhookRequest struct {
+	URL        string `binding:"required,url"                                     json:"url"`
+	Address    string `binding:"omitempty,address"                                json:"address,omitempty"`
+	Entrypoint string `binding:"omitempty"                                        json:"entrypoint,omitempty"`
+	Tag        string `binding:"omitempty"                                        json:"tag,omitempty"`
+	Status     string `binding:"omitempty,oneof=applied failed backtracked skipped" json:"status,omitempty"`
+}
+
+type getWebhookRequest struct {
+	getByNetwork
+
+	ID int64 `binding:"required,min=1" uri:"id"`
+}
+
+type contractAnalyticsRequest struct {
+	Period       string `binding:"omitempty,oneof=hour day" form:"period"`
+	From         int64  `binding:"omitempty,min=0"          form:"from"`
+	To           int64  `binding:"omitempty,min=0"          form:"to"`
+	Entrypoint   string `binding:"omitempty"                form:"entrypoint"`
+	ByEntrypoint bool   `binding:"omitempty"                form:"by_entrypoint"`
+}
+
+type networkSeriesRequest struct {
+	Period string `binding:"omitempty,oneof=hour day" form:"period"`
+	From   int64  `binding:"omitempty,min=0"          form:"from"`
+	To     int64  `binding:"omitempty,min=0"          form:"to"`
+}
+
+type topContractsRequest struct {
+	By   string `binding:"omitempty,oneof=calls gas" form:"by"`
+	From int64  `binding:"omitempty,min=0"           form:"from"`
+	To   int64  `binding:"omitempty,min=0"           form:"to"`
+	Size int64  `binding:"min=0,bcd_max_size=10"     form:"size"`
+}
+
+type searchOperationsRequest struct {
+	Kind            string `binding:"omitempty,operation_kind" form:"kind"`
+	Status          string `binding:"omitempty,status"         form:"status"`
+	Entrypoint      string `binding:"omitempty"                form:"entrypoint"`
+	Source          string `binding:"omitempty,address"        form:"source"`
+	Destination     string `binding:"omitempty,address"        form:"destination"`
+	Tag             string `binding:"omitempty"                form:"tag"`
+	MinLevel        int64  `binding:"omitempty,min=0"          form:"min_level"`
+	MaxLevel        int64  `binding:"omitempty,min=0"          form:"max_level"`
+	From            int64  `binding:"omitempty,min=0"          form:"from"`
+	To              int64  `binding:"omitempty,min=0"          form:"to"`
+	MinAmount       int64  `binding:"omitempty,min=0"          form:"min_amount"`
+	ErrorID         string `binding:"omitempty"                form:"error_id"`
+	LastID          string `binding:"omitempty"                form:"last_id"`
+	Size            int64  `binding:"min=0,bcd_max_size=10"    form:"size"`
+	WithStorageDiff bool   `binding:"omitempty"                form:"with_storage_diff"`
+}
+
+type searchRequest struct {
+	pageableRequest
+
+	Query   string `binding:"required,search"        form:"q"`
+	Kinds   string `binding:"omitempty,search_kind" form:"kinds"`
+	Network string `binding:"omitempty,network"     form:"network"`
+}
+
+// SearchKinds -
+func (req searchRequest) SearchKinds() []search.Kind {
+	if req.Kinds == "" {
+		return nil
+	}
+	values := strings.Split(req.Kinds, ",")
+	kinds := make([]search.Kind, 0, len(values))
+	for i := range values {
+		kinds = append(kinds, search.NewKind(values[i]))
+	}
+	return kinds
+}
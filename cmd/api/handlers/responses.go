@@ -10,14 +10,20 @@ import (
 	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/analytics"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/reorg"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 )
 
 // Error -
@@ -169,6 +175,38 @@ type Contract struct {
 	TxCount         int64     `extensions:"x-nullable" json:"tx_count,omitempty"`
 	MigrationsCount int64     `extensions:"x-nullable" json:"migrations_count,omitempty"`
 	Slug            string    `extensions:"x-nullable" json:"slug,omitempty"`
+
+	Metadata *ContractMetadata `extensions:"x-nullable" json:"metadata,omitempty"`
+}
+
+// ContractMetadata - TZIP-16 metadata of the contract
+type ContractMetadata struct {
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Version     string             `json:"version,omitempty"`
+	Homepage    string             `json:"homepage,omitempty"`
+	Authors     []string           `extensions:"x-nullable" json:"authors,omitempty"`
+	Interfaces  []string           `extensions:"x-nullable" json:"interfaces,omitempty"`
+	License     stdJSON.RawMessage `extensions:"x-nullable" json:"license,omitempty"`
+	Views       stdJSON.RawMessage `extensions:"x-nullable" json:"views,omitempty"`
+	URI         string             `json:"uri"`
+	Level       int64              `json:"level"`
+}
+
+// NewContractMetadata -
+func NewContractMetadata(m metadata.ContractMetadata) *ContractMetadata {
+	return &ContractMetadata{
+		Name:        m.Name,
+		Description: m.Description,
+		Version:     m.Version,
+		Homepage:    m.Homepage,
+		Authors:     m.Authors,
+		Interfaces:  m.Interfaces,
+		License:     m.License,
+		Views:       m.Views,
+		URI:         m.URI,
+		Level:       m.Level,
+	}
 }
 
 // FromModel -
@@ -394,6 +432,8 @@ type Stats struct {
 	SrExecutesCount             int `json:"sr_executes_count"`
 	RegisterGlobalConstantCount int `json:"register_global_constants_count"`
 	TransferTicketsCount        int `json:"transfer_tickets_count"`
+	IncreasePaidStorageCount    int `json:"increase_paid_storage_count"`
+	StakingCount                int `json:"staking_count"`
 }
 
 func NewStats(s stats.Stats) *Stats {
@@ -409,6 +449,8 @@ func NewStats(s stats.Stats) *Stats {
 		SrExecutesCount:             s.SrExecutesCount,
 		RegisterGlobalConstantCount: s.RegisterGlobalConstantCount,
 		TransferTicketsCount:        s.TransferTicketsCount,
+		IncreasePaidStorageCount:    s.IncreasePaidStorageCount,
+		StakingCount:                s.StakingCount,
 	}
 }
 
@@ -671,6 +713,77 @@ func NewTicketUpdateFromModel(update ticket.TicketUpdate) TicketUpdate {
 	}
 }
 
+// TokenBalance -
+type TokenBalance struct {
+	Contract string `json:"contract"`
+	Address  string `json:"address"`
+	TokenId  string `json:"token_id"`
+	Amount   string `json:"amount"`
+}
+
+// NewTokenBalance -
+func NewTokenBalance(balance token.Balance) TokenBalance {
+	return TokenBalance{
+		Contract: balance.Contract,
+		Address:  balance.Address,
+		TokenId:  balance.TokenId.String(),
+		Amount:   balance.Amount.String(),
+	}
+}
+
+// TokenMetadata -
+type TokenMetadata struct {
+	TokenId   string             `json:"token_id"`
+	Symbol    string             `json:"symbol,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Decimals  *int64             `extensions:"x-nullable" json:"decimals,omitempty"`
+	URI       string             `json:"uri,omitempty"`
+	Extras    stdJSON.RawMessage `extensions:"x-nullable" json:"extras,omitempty"`
+	Level     int64              `json:"level"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// NewTokenMetadata -
+func NewTokenMetadata(m metadata.TokenMetadata) TokenMetadata {
+	return TokenMetadata{
+		TokenId:   m.TokenId.String(),
+		Symbol:    m.Symbol,
+		Name:      m.Name,
+		Decimals:  m.Decimals,
+		URI:       m.URI,
+		Extras:    m.Extras,
+		Level:     m.Level,
+		Timestamp: m.Timestamp,
+	}
+}
+
+// TokenTransfer -
+type TokenTransfer struct {
+	ID            int64     `json:"id"`
+	Level         int64     `json:"level"`
+	Timestamp     time.Time `json:"timestamp"`
+	Contract      string    `json:"contract"`
+	From          string    `json:"from,omitempty"`
+	To            string    `json:"to,omitempty"`
+	TokenId       string    `json:"token_id"`
+	Amount        string    `json:"amount"`
+	OperationHash string    `json:"operation_hash"`
+}
+
+// NewTokenTransfer -
+func NewTokenTransfer(transfer token.Transfer) TokenTransfer {
+	return TokenTransfer{
+		ID:        transfer.ID,
+		Level:     transfer.Level,
+		Timestamp: transfer.Timestamp.UTC(),
+		Contract:  transfer.Contract,
+		From:      transfer.From,
+		To:        transfer.To,
+		TokenId:   transfer.TokenId.String(),
+		Amount:    transfer.Amount.String(),
+	}
+}
+
 // SmartRollup -
 type SmartRollup struct {
 	ID                    int64         `json:"id"`
@@ -699,6 +812,148 @@ func NewSmartRollup(rollup smartrollup.SmartRollup) SmartRollup {
 	}
 }
 
+// SmartRollupCommitment -
+type SmartRollupCommitment struct {
+	ID               int64     `json:"id"`
+	Level            int64     `json:"level"`
+	Timestamp        time.Time `json:"timestamp"`
+	Action           string    `json:"action"`
+	Hash             string    `json:"hash"`
+	Staker           string    `json:"staker,omitempty"`
+	Predecessor      string    `json:"predecessor,omitempty"`
+	CompressedState  string    `json:"compressed_state,omitempty"`
+	InboxLevel       int64     `json:"inbox_level"`
+	NumberOfTicks    int64     `json:"number_of_ticks,omitempty"`
+	PublishedAtLevel int64     `json:"published_at_level,omitempty"`
+}
+
+// NewSmartRollupCommitment -
+func NewSmartRollupCommitment(commitment smartrollup.Commitment) SmartRollupCommitment {
+	return SmartRollupCommitment{
+		ID:               commitment.ID,
+		Level:            commitment.Level,
+		Timestamp:        commitment.Timestamp.UTC(),
+		Action:           commitment.Action,
+		Hash:             commitment.Hash,
+		Staker:           commitment.Staker,
+		Predecessor:      commitment.Predecessor,
+		CompressedState:  commitment.CompressedState,
+		InboxLevel:       commitment.InboxLevel,
+		NumberOfTicks:    commitment.NumberOfTicks,
+		PublishedAtLevel: commitment.PublishedAtLevel,
+	}
+}
+
+// SmartRollupStaker -
+type SmartRollupStaker struct {
+	Address           string `json:"address"`
+	Status            string `json:"status"`
+	PublicationsCount int64  `json:"publications_count"`
+	FirstLevel        int64  `json:"first_level"`
+	LastLevel         int64  `json:"last_level"`
+	LastCommitment    string `json:"last_commitment"`
+}
+
+// NewSmartRollupStaker -
+func NewSmartRollupStaker(staker smartrollup.Staker) SmartRollupStaker {
+	return SmartRollupStaker{
+		Address:           staker.Address,
+		Status:            staker.Status,
+		PublicationsCount: staker.PublicationsCount,
+		FirstLevel:        staker.FirstLevel,
+		LastLevel:         staker.LastLevel,
+		LastCommitment:    staker.LastCommitment,
+	}
+}
+
+// SmartRollupGame -
+type SmartRollupGame struct {
+	Initiator           string `json:"initiator"`
+	Opponent            string `json:"opponent"`
+	InitiatorCommitment string `json:"initiator_commitment,omitempty"`
+	OpponentCommitment  string `json:"opponent_commitment,omitempty"`
+	StartLevel          int64  `json:"start_level"`
+	LastMoveLevel       int64  `json:"last_move_level"`
+	MovesCount          int64  `json:"moves_count"`
+	Status              string `json:"status"`
+	Loser               string `json:"loser,omitempty"`
+	Reason              string `json:"reason,omitempty"`
+}
+
+// NewSmartRollupGame -
+func NewSmartRollupGame(game smartrollup.Game) SmartRollupGame {
+	return SmartRollupGame{
+		Initiator:           game.Initiator,
+		Opponent:            game.Opponent,
+		InitiatorCommitment: game.InitiatorCommitment,
+		OpponentCommitment:  game.OpponentCommitment,
+		StartLevel:          game.StartLevel,
+		LastMoveLevel:       game.LastMoveLevel,
+		MovesCount:          game.MovesCount,
+		Status:              game.Status,
+		Loser:               game.Loser,
+		Reason:              game.Reason,
+	}
+}
+
+// SmartRollupMessage -
+type SmartRollupMessage struct {
+	ID        int64     `json:"id"`
+	Level     int64     `json:"level"`
+	Timestamp time.Time `json:"timestamp"`
+	Index     int64     `json:"index"`
+	Payload   string    `json:"payload"`
+}
+
+// NewSmartRollupMessage -
+func NewSmartRollupMessage(message smartrollup.Message) SmartRollupMessage {
+	return SmartRollupMessage{
+		ID:        message.ID,
+		Level:     message.Level,
+		Timestamp: message.Timestamp.UTC(),
+		Index:     message.Index,
+		Payload:   hex.EncodeToString(message.Payload),
+	}
+}
+
+// SmartRollupOutboxTransaction - transaction of the outbox message executed on L1 with its internal operation and ticket updates
+type SmartRollupOutboxTransaction struct {
+	ID                int64              `json:"id"`
+	Level             int64              `json:"level"`
+	Timestamp         time.Time          `json:"timestamp"`
+	OperationHash     string             `json:"operation_hash"`
+	OutboxLevel       int64              `json:"outbox_level"`
+	MessageIndex      int64              `json:"message_index"`
+	Index             int64              `json:"index"`
+	Kind              string             `json:"kind"`
+	Destination       string             `json:"destination"`
+	Entrypoint        string             `json:"entrypoint"`
+	Parameters        interface{}        `json:"parameters"`
+	ParametersType    stdJSON.RawMessage `json:"parameters_type,omitempty"`
+	InternalOperation *Operation         `json:"internal_operation,omitempty"`
+	TicketUpdates     []TicketUpdate     `json:"ticket_updates,omitempty"`
+}
+
+// NewSmartRollupOutboxTransaction -
+func NewSmartRollupOutboxTransaction(tx smartrollup.OutboxTransaction) SmartRollupOutboxTransaction {
+	response := SmartRollupOutboxTransaction{
+		ID:           tx.ID,
+		Level:        tx.Level,
+		Timestamp:    tx.Timestamp.UTC(),
+		OutboxLevel:  tx.OutboxLevel,
+		MessageIndex: tx.MessageIndex,
+		Index:        tx.Index,
+		Kind:         tx.Kind,
+		Destination:  tx.Destination,
+		Entrypoint:   tx.Entrypoint,
+		Parameters:   stdJSON.RawMessage(tx.Parameters),
+	}
+	if len(tx.ParametersType) > 0 {
+		response.ParametersType = tx.ParametersType
+	}
+	return response
+}
+
 type TicketBalance struct {
 	Ticketer    string          `json:"ticketer"`
 	Amount      string          `json:"amount"`
@@ -769,3 +1024,190 @@ func NewGlobalConstantItem(item contract.ListGlobalConstantItem) GlobalConstantI
 		LinksCount: item.LinksCount,
 	}
 }
+
+// Webhook -
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	Address    string    `json:"address,omitempty"`
+	Entrypoint string    `json:"entrypoint,omitempty"`
+	Tag        string    `json:"tag,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewWebhook - creates webhook response without secret
+func NewWebhook(hook webhook.Webhook) Webhook {
+	return Webhook{
+		ID:         hook.ID,
+		URL:        hook.URL,
+		Address:    hook.Address,
+		Entrypoint: hook.Entrypoint,
+		Tag:        hook.Tag,
+		Status:     hook.Status.String(),
+		CreatedAt:  hook.CreatedAt,
+	}
+}
+
+// WebhookDeadLetter -
+type WebhookDeadLetter struct {
+	ID          int64              `json:"id"`
+	DeliveryID  int64              `json:"delivery_id"`
+	Event       string             `json:"event"`
+	OperationID int64              `json:"operation_id,omitempty"`
+	Level       int64              `json:"level"`
+	Payload     stdJSON.RawMessage `extensions:"x-nullable" json:"payload,omitempty"`
+	Attempts    int                `json:"attempts"`
+	LastError   string             `json:"last_error,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// NewWebhookDeadLetter -
+func NewWebhookDeadLetter(letter webhook.DeadLetter) WebhookDeadLetter {
+	return WebhookDeadLetter{
+		ID:          letter.ID,
+		DeliveryID:  letter.DeliveryID,
+		Event:       string(letter.Event),
+		OperationID: letter.OperationID,
+		Level:       letter.Level,
+		Payload:     letter.Payload,
+		Attempts:    letter.Attempts,
+		LastError:   letter.LastError,
+		CreatedAt:   letter.CreatedAt,
+	}
+}
+
+// SearchResult -
+type SearchResult struct {
+	ID        int64     `json:"-"`
+	Kind      string    `json:"kind"`
+	Value     string    `json:"value"`
+	Highlight string    `json:"highlight"`
+	Address   string    `json:"address"`
+	Network   string    `json:"network"`
+	Level     int64     `json:"level"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewSearchResult -
+func NewSearchResult(entry search.Entry, network types.Network, query string) SearchResult {
+	return SearchResult{
+		ID:        entry.ID,
+		Kind:      string(entry.Kind),
+		Value:     entry.Value,
+		Highlight: search.Highlight(entry.Value, query),
+		Address:   entry.Address,
+		Network:   network.String(),
+		Level:     entry.Level,
+		Timestamp: entry.Timestamp,
+	}
+}
+
+// Reorg -
+type Reorg struct {
+	ID         int64     `json:"id"`
+	DetectedAt time.Time `json:"detected_at"`
+	Level      int64     `json:"level"`
+	Depth      int64     `json:"depth"`
+	OldHash    string    `json:"old_hash,omitempty"`
+	NewHash    string    `json:"new_hash,omitempty"`
+	OldHead    int64     `json:"old_head"`
+}
+
+// NewReorg -
+func NewReorg(event reorg.Reorg) Reorg {
+	return Reorg{
+		ID:         event.ID,
+		DetectedAt: event.DetectedAt,
+		Level:      event.Level,
+		Depth:      event.Depth,
+		OldHash:    event.OldHash,
+		NewHash:    event.NewHash,
+		OldHead:    event.OldHead,
+	}
+}
+
+// ContractAnalytics -
+type ContractAnalytics struct {
+	Timestamp           time.Time `json:"timestamp"`
+	Entrypoint          string    `extensions:"x-nullable" json:"entrypoint,omitempty"`
+	Operations          int64     `json:"operations"`
+	Calls               int64     `json:"calls"`
+	FailedCalls         int64     `json:"failed_calls"`
+	TotalGas            int64     `json:"total_gas"`
+	AvgGas              float64   `json:"avg_gas"`
+	P95Gas              float64   `json:"p95_gas"`
+	Burned              int64     `json:"burned"`
+	Fee                 int64     `json:"fee"`
+	PaidStorageSizeDiff int64     `json:"paid_storage_size_diff"`
+	StorageSize         int64     `json:"storage_size"`
+}
+
+// NewContractAnalytics -
+func NewContractAnalytics(stats analytics.ContractStats) ContractAnalytics {
+	return ContractAnalytics{
+		Timestamp:           stats.Bucket.UTC(),
+		Entrypoint:          stats.Entrypoint,
+		Operations:          stats.Operations,
+		Calls:               stats.Calls,
+		FailedCalls:         stats.FailedCalls,
+		TotalGas:            stats.TotalGas,
+		AvgGas:              stats.AvgGas,
+		P95Gas:              stats.P95Gas,
+		Burned:              stats.Burned,
+		Fee:                 stats.Fee,
+		PaidStorageSizeDiff: stats.PaidStorageSizeDiff,
+		StorageSize:         stats.StorageSize,
+	}
+}
+
+// NetworkSeries -
+type NetworkSeries struct {
+	Timestamp       time.Time `json:"timestamp"`
+	Operations      int64     `json:"operations"`
+	Calls           int64     `json:"calls"`
+	UniqueSenders   int64     `json:"unique_senders"`
+	NewContracts    int64     `json:"new_contracts"`
+	Events          int64     `json:"events"`
+	TicketTransfers int64     `json:"ticket_transfers"`
+	TicketUpdates   int64     `json:"ticket_updates"`
+	GlobalConstants int64     `json:"global_constants"`
+	SmartRollups    int64     `json:"smart_rollups"`
+}
+
+// NewNetworkSeries -
+func NewNetworkSeries(stats analytics.NetworkStats) NetworkSeries {
+	return NetworkSeries{
+		Timestamp:       stats.Bucket.UTC(),
+		Operations:      stats.Operations,
+		Calls:           stats.Calls,
+		UniqueSenders:   stats.UniqueSenders,
+		NewContracts:    stats.NewContracts,
+		Events:          stats.Events,
+		TicketTransfers: stats.TicketTransfers,
+		TicketUpdates:   stats.TicketUpdates,
+		GlobalConstants: stats.GlobalConstants,
+		SmartRollups:    stats.SmartRollups,
+	}
+}
+
+// TopContract -
+type TopContract struct {
+	Address     string `json:"address"`
+	Calls       int64  `json:"calls"`
+	FailedCalls int64  `json:"failed_calls"`
+	TotalGas    int64  `json:"total_gas"`
+	Burned      int64  `json:"burned"`
+}
+
+// NewTopContract -
+func NewTopContract(contract analytics.TopContract) TopContract {
+	return TopContract{
+		Address:     contract.Address,
+		Calls:       contract.Calls,
+		FailedCalls: contract.FailedCalls,
+		TotalGas:    contract.TotalGas,
+		Burned:      contract.Burned,
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/gin-gonic/gin"
+)
+
+// GetRPCHealth godoc
+// @Summary Show health of the network RPC nodes
+// @Description Get state of circuit breaker, head level lag, error rate and latency of every node in the RPC pool of the network. Empty list is returned if network uses single node.
+// @Tags rpc
+// @ID get-rpc-health
+// @Param network path string true "Network"
+// @Accept json
+// @Produce json
+// @Success 200 {array} noderpc.NodeHealth
+// @Failure 400 {object} Error
+// @Router /v1/rpc/{network}/health [get]
+func GetRPCHealth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		health := make([]noderpc.NodeHealth, 0)
+		if reporter, ok := ctx.RPC.(noderpc.HealthReporter); ok {
+			health = append(health, reporter.Health()...)
+		}
+		c.SecureJSON(http.StatusOK, health)
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultSearchSize = 10
+
+// Search godoc
+// @Summary Search
+// @Description Search by account addresses, contract entrypoints and annotations, global constant hashes, event tags and TZIP metadata names. Matched part of the value is wrapped with `<mark>` tag in `highlight` field.
+// @Tags search
+// @ID search
+// @Param q query string true "Query string" minlength(3) maxlength(255)
+// @Param kinds query string false "Comma-separated list of result kinds" Enums(account, entrypoint, annotation, global_constant, event, contract_metadata, token_metadata)
+// @Param network query string false "Network"
+// @Param size query integer false "Results count" mininum(1) maximum(10)
+// @Param offset query integer false "Offset" mininum(0)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SearchResult
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/search [get]
+func Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctxs := c.MustGet("contexts").(config.Contexts)
+
+		var req searchRequest
+		if err := c.ShouldBindQuery(&req); handleError(c, ctxs.Any().Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		size := req.Size
+		if size == 0 {
+			size = defaultSearchSize
+		}
+
+		networks := ctxs
+		if req.Network != "" {
+			ctx, err := ctxs.Get(types.NewNetwork(req.Network))
+			if handleError(c, ctxs.Any().Storage, err, http.StatusBadRequest) {
+				return
+			}
+			networks = config.Contexts{ctx.Network: ctx}
+		}
+
+		results := make([]SearchResult, 0)
+		for network, ctx := range networks {
+			entries, err := ctx.Search.Search(c.Request.Context(), search.Request{
+				Query: req.Query,
+				Kinds: req.SearchKinds(),
+				Limit: req.Offset + size,
+			})
+			if handleError(c, ctx.Storage, err, 0) {
+				return
+			}
+			for i := range entries {
+				results = append(results, NewSearchResult(entries[i], network, req.Query))
+			}
+		}
+
+		c.SecureJSON(http.StatusOK, pageSearchResults(results, req.Query, req.Offset, size))
+	}
+}
+
+// pageSearchResults - merges results of the networks in the same order as the storage returns them and cuts the requested page
+func pageSearchResults(results []SearchResult, query string, offset, size int64) []SearchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		ri, rj := search.Rank(results[i].Value, query), search.Rank(results[j].Value, query)
+		switch {
+		case ri != rj:
+			return ri < rj
+		case len(results[i].Value) != len(results[j].Value):
+			return len(results[i].Value) < len(results[j].Value)
+		case results[i].Network != results[j].Network:
+			return results[i].Network < results[j].Network
+		default:
+			return results[i].ID < results[j].ID
+		}
+	})
+
+	if offset >= int64(len(results)) {
+		return []SearchResult{}
+	}
+	end := offset + size
+	if end > int64(len(results)) {
+		end = int64(len(results))
+	}
+	return results[offset:end]
+}
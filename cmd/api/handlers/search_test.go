@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	stdJSON "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/cmd/api/validations"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_search "github.com/baking-bad/bcdhub/internal/models/mock/search"
+)
+
+func TestPageSearchResults(t *testing.T) {
+	results := []SearchResult{
+		{ID: 1, Value: "do_transfer", Network: "mainnet"},
+		{ID: 2, Value: "transfer", Network: "mainnet"},
+		{ID: 1, Value: "transfer", Network: "ghostnet"},
+		{ID: 3, Value: "transfers", Network: "mainnet"},
+	}
+
+	page := pageSearchResults(results, "Transfer", 1, 2)
+	require.Len(t, page, 2)
+	require.Equal(t, "transfer", page[0].Value)
+	require.Equal(t, "mainnet", page[0].Network)
+	require.Equal(t, "transfers", page[1].Value)
+
+	require.Empty(t, pageSearchResults(results, "transfer", 10, 2))
+}
+
+func TestSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	require.True(t, ok)
+	require.NoError(t, validations.Register(v, config.APIConfig{
+		Networks: []string{types.Mainnet.String(), types.Ghostnet.String()},
+	}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mainnet := mock_search.NewMockRepository(ctrl)
+	ghostnet := mock_search.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+
+	ctxs := config.Contexts{
+		types.Mainnet:  &config.Context{Network: types.Mainnet, Search: mainnet, Storage: storage},
+		types.Ghostnet: &config.Context{Network: types.Ghostnet, Search: ghostnet, Storage: storage},
+	}
+
+	req := search.Request{
+		Query: "ledger",
+		Kinds: []search.Kind{search.KindAnnotation},
+		Limit: 10,
+	}
+	mainnet.EXPECT().
+		Search(gomock.Any(), req).
+		Return([]search.Entry{
+			{ID: 5, Kind: search.KindAnnotation, Value: "%ledger", Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", Level: 40},
+		}, nil).
+		Times(1)
+	ghostnet.EXPECT().
+		Search(gomock.Any(), req).
+		Return([]search.Entry{
+			{ID: 7, Kind: search.KindAnnotation, Value: "%ledger_map", Address: "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9", Level: 12},
+		}, nil).
+		Times(1)
+
+	router := gin.New()
+	router.SecureJsonPrefix("")
+	router.GET("/v1/search", ContextsMiddleware(ctxs), Search())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/search?q=ledger&kinds=annotation", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var results []SearchResult
+	require.NoError(t, stdJSON.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(t, results, 2)
+	require.Equal(t, "%ledger", results[0].Value)
+	require.Equal(t, "%<mark>ledger</mark>", results[0].Highlight)
+	require.Equal(t, "mainnet", results[0].Network)
+	require.Equal(t, "annotation", results[0].Kind)
+	require.Equal(t, "%<mark>ledger</mark>_map", results[1].Highlight)
+	require.Equal(t, "ghostnet", results[1].Network)
+}
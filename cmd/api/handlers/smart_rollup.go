@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/config"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/gin-gonic/gin"
 )
 
@@ -96,3 +100,254 @@ func ListSmartRollups() gin.HandlerFunc {
 		c.SecureJSON(http.StatusOK, response)
 	}
 }
+
+// GetSmartRollupCommitments godoc
+// @Summary Get smart rollup commitments
+// @Description Get history of commitments published by stakers and cemented
+// @Tags smart-rollups
+// @ID get-smart-rollup-commitments
+// @Param network path string true "network"
+// @Param address path string true "expr address of smart rollup" minlength(36) maxlength(36)
+// @Param size query integer false "Commitments count" mininum(1) maximum(10)
+// @Param offset query integer false "Offset" mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SmartRollupCommitment
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/smart_rollups/{network}/{address}/commitments [get]
+func GetSmartRollupCommitments() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getSmartRollupRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		var args pageableRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		commitments, err := ctx.SmartRollups.Commitments(c.Request.Context(), req.Address, args.Size, args.Offset)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		response := make([]SmartRollupCommitment, len(commitments))
+		for i := range commitments {
+			response[i] = NewSmartRollupCommitment(commitments[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetSmartRollupStakers godoc
+// @Summary Get smart rollup stakers
+// @Description Get stakers which published commitments of the smart rollup with their statuses
+// @Tags smart-rollups
+// @ID get-smart-rollup-stakers
+// @Param network path string true "network"
+// @Param address path string true "expr address of smart rollup" minlength(36) maxlength(36)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SmartRollupStaker
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/smart_rollups/{network}/{address}/stakers [get]
+func GetSmartRollupStakers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getSmartRollupRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		stakers, err := ctx.SmartRollups.Stakers(c.Request.Context(), req.Address)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		response := make([]SmartRollupStaker, len(stakers))
+		for i := range stakers {
+			response[i] = NewSmartRollupStaker(stakers[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetSmartRollupGames godoc
+// @Summary Get smart rollup refutation games
+// @Description Get refutation games between stakers of the smart rollup from the latest one
+// @Tags smart-rollups
+// @ID get-smart-rollup-games
+// @Param network path string true "network"
+// @Param address path string true "expr address of smart rollup" minlength(36) maxlength(36)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SmartRollupGame
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/smart_rollups/{network}/{address}/games [get]
+func GetSmartRollupGames() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getSmartRollupRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		games, err := ctx.SmartRollups.Games(c.Request.Context(), req.Address)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		response := make([]SmartRollupGame, len(games))
+		for i := range games {
+			response[i] = NewSmartRollupGame(games[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetSmartRollupMessages godoc
+// @Summary Get smart rollup inbox messages
+// @Description Get external inbox messages targeted to the smart rollup. Payload is hex-encoded message without framing.
+// @Tags smart-rollups
+// @ID get-smart-rollup-messages
+// @Param network path string true "network"
+// @Param address path string true "expr address of smart rollup" minlength(36) maxlength(36)
+// @Param size query integer false "Messages count" mininum(1) maximum(10)
+// @Param offset query integer false "Offset" mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SmartRollupMessage
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/smart_rollups/{network}/{address}/messages [get]
+func GetSmartRollupMessages() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getSmartRollupRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		var args pageableRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		messages, err := ctx.SmartRollups.Messages(c.Request.Context(), req.Address, args.Size, args.Offset)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		response := make([]SmartRollupMessage, len(messages))
+		for i := range messages {
+			response[i] = NewSmartRollupMessage(messages[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetSmartRollupOutbox godoc
+// @Summary Get executed outbox messages of smart rollup
+// @Description Get transactions of outbox messages executed on L1 with typed parameters, internal operations and ticket updates made by them
+// @Tags smart-rollups
+// @ID get-smart-rollup-outbox
+// @Param network path string true "network"
+// @Param address path string true "expr address of smart rollup" minlength(36) maxlength(36)
+// @Param size query integer false "Transactions count" mininum(1) maximum(10)
+// @Param offset query integer false "Offset" mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SmartRollupOutboxTransaction
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/smart_rollups/{network}/{address}/outbox [get]
+func GetSmartRollupOutbox() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getSmartRollupRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		var args pageableRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		transactions, err := ctx.SmartRollups.OutboxTransactions(c.Request.Context(), req.Address, args.Size, args.Offset)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		response := make([]SmartRollupOutboxTransaction, len(transactions))
+		for i := range transactions {
+			response[i], err = prepareOutboxTransaction(c.Request.Context(), ctx, transactions[i])
+			if handleError(c, ctx.Storage, err, 0) {
+				return
+			}
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+func prepareOutboxTransaction(c context.Context, ctx *config.Context, tx smartrollup.OutboxTransaction) (SmartRollupOutboxTransaction, error) {
+	response := NewSmartRollupOutboxTransaction(tx)
+
+	execution, err := ctx.Operations.GetByID(c, tx.OperationId)
+	if err != nil {
+		return response, err
+	}
+	response.OperationHash = encoding.MustEncodeOperationHash(execution.Hash)
+
+	proto, err := ctx.Cache.ProtocolByID(c, execution.ProtocolID)
+	if err != nil {
+		return response, err
+	}
+	parameterType, err := getParameterType(c, ctx.Contracts, tx.Destination, proto.SymLink)
+	switch {
+	case err == nil:
+		tree, err := parameterType.FromParameters(types.NewParameters(tx.Parameters))
+		if err != nil {
+			return response, err
+		}
+		response.Parameters, err = tree.ToMiguel()
+		if err != nil {
+			return response, err
+		}
+	case !ctx.Storage.IsRecordNotFound(err):
+		return response, err
+	}
+
+	if tx.InternalOperationId == 0 {
+		return response, nil
+	}
+
+	internal, err := ctx.Operations.GetByID(c, tx.InternalOperationId)
+	if err != nil {
+		return response, err
+	}
+	internalResponse, err := prepareOperation(c, ctx, internal, false)
+	if err != nil {
+		return response, err
+	}
+	response.InternalOperation = &internalResponse
+
+	if internal.TicketUpdatesCount > 0 {
+		updates, err := ctx.Tickets.UpdatesForOperation(c, internal.ID)
+		if err != nil {
+			return response, err
+		}
+		response.TicketUpdates, err = prepareTicketUpdates(c, ctx, updates, internal.Hash)
+		if err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
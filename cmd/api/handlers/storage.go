@@ -10,19 +10,21 @@ import (
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
 // GetContractStorage godoc
 // @Summary Get contract storage
-// @Description Get contract storage
+// @Description Get contract storage. If `level` is set, the storage is restored from the last operation at or before the level and big maps contain their keys as of that level.
 // @Tags contract
 // @ID get-contract-storage
 // @Param network path string true "Network"
 // @Param address path string true "KT address" minlength(36) maxlength(36)
-// @Param level query integer false "Level"
+// @Param level query integer false "Level" mininum(1)
 // @Accept json
 // @Produce json
 // @Success 200 {array} ast.MiguelNode
@@ -74,6 +76,16 @@ func GetContractStorage() gin.HandlerFunc {
 			return
 		}
 
+		if sReq.Level > 0 {
+			bmd, err := ctx.BigMapDiffs.StatesAtLevel(c.Request.Context(), req.Address, int64(sReq.Level))
+			if handleError(c, ctx.Storage, err, 0) {
+				return
+			}
+			if err := getEnrichStorage(storageType, bmd); handleError(c, ctx.Storage, err, 0) {
+				return
+			}
+		}
+
 		resp, err := storageType.ToMiguel()
 		if handleError(c, ctx.Storage, err, 0) {
 			return
@@ -90,7 +102,7 @@ func GetContractStorage() gin.HandlerFunc {
 // @ID get-contract-storage-raw
 // @Param network path string true "Network"
 // @Param address path string true "KT address" minlength(36) maxlength(36)
-// @Param level query integer false "Level"
+// @Param level query integer false "Level of the storage. It is restored from indexed operations and protocol migrations. Current storage if not set" minimum(1)
 // @Accept json
 // @Produce json
 // @Success 200 {string} string
@@ -125,12 +137,12 @@ func GetContractStorageRaw() gin.HandlerFunc {
 
 // GetContractStorageRich godoc
 // @Summary Get contract rich storage
-// @Description Get contract rich storage
+// @Description Get contract rich storage. If `level` is set, big maps contain their keys as of that level.
 // @Tags contract
 // @ID get-contract-storage-rich
 // @Param network path string true "Network"
 // @Param address path string true "KT address" minlength(36) maxlength(36)
-// @Param level query integer false "Level"
+// @Param level query integer false "Level" mininum(1)
 // @Accept json
 // @Produce json
 // @Success 200 {object} gin.H
@@ -166,14 +178,22 @@ func GetContractStorageRich() gin.HandlerFunc {
 			return
 		}
 
-		states, err := ctx.BigMapDiffs.GetForAddress(c.Request.Context(), req.Address)
-		if handleError(c, ctx.Storage, err, 0) {
-			return
-		}
+		var bmd []bigmapdiff.BigMapDiff
+		if sReq.Level > 0 {
+			bmd, err = ctx.BigMapDiffs.StatesAtLevel(c.Request.Context(), req.Address, int64(sReq.Level))
+			if handleError(c, ctx.Storage, err, 0) {
+				return
+			}
+		} else {
+			states, err := ctx.BigMapDiffs.GetForAddress(c.Request.Context(), req.Address)
+			if handleError(c, ctx.Storage, err, 0) {
+				return
+			}
 
-		bmd := make([]bigmapdiff.BigMapDiff, 0, len(states))
-		for i := range states {
-			bmd = append(bmd, states[i].ToDiff())
+			bmd = make([]bigmapdiff.BigMapDiff, 0, len(states))
+			for i := range states {
+				bmd = append(bmd, states[i].ToDiff())
+			}
 		}
 
 		if err := prepareStorage(storageType, storage, bmd); handleError(c, ctx.Storage, err, 0) {
@@ -189,6 +209,59 @@ func GetContractStorageRich() gin.HandlerFunc {
 	}
 }
 
+// GetContractStorageDiff godoc
+// @Summary Get contract storage diff
+// @Description Get diff of contract storage between two points of its history. Every point is either the end of the level or the moment right after the operation changed the storage. Big maps contain only keys added, updated or removed between points.
+// @Tags contract
+// @ID get-contract-storage-diff
+// @Param network path string true "Network"
+// @Param address path string true "KT address" minlength(36) maxlength(36)
+// @Param from_level query integer false "Start level. Required if `from_operation` is not set" mininum(0)
+// @Param from_operation query integer false "Internal BCD id of start operation. Required if `from_level` is not set" mininum(1)
+// @Param to_level query integer false "End level. Required if `to_operation` is not set" mininum(1)
+// @Param to_operation query integer false "Internal BCD id of end operation. Required if `to_level` is not set" mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {object} ast.MiguelNode
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/contract/{network}/{address}/storage/diff [get]
+func GetContractStorageDiff() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getContractRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusNotFound) {
+			return
+		}
+		var args storageDiffRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		contract, err := ctx.Contracts.Get(c.Request.Context(), req.Address)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		from, err := getStoragePoint(c.Request.Context(), ctx, contract, args.FromLevel, args.FromOperation)
+		if handleError(c, ctx.Storage, err, storagePointErrorCode(err)) {
+			return
+		}
+		to, err := getStoragePoint(c.Request.Context(), ctx, contract, args.ToLevel, args.ToOperation)
+		if handleError(c, ctx.Storage, err, storagePointErrorCode(err)) {
+			return
+		}
+
+		diff, err := getStorageDiffBetween(c.Request.Context(), ctx, req.Address, from, to)
+		if handleError(c, ctx.Storage, err, storagePointErrorCode(err)) {
+			return
+		}
+		c.SecureJSON(http.StatusOK, diff)
+	}
+}
+
 // GetContractStorageSchema godoc
 // @Summary Get contract storage schema
 // @Description Get contract storage schema
@@ -277,20 +350,127 @@ func GetContractStorageSchema() gin.HandlerFunc {
 	}
 }
 
+var errInvalidStoragePoint = errors.New("invalid storage point")
+
+func storagePointErrorCode(err error) int {
+	if errors.Is(err, errInvalidStoragePoint) {
+		return http.StatusBadRequest
+	}
+	return 0
+}
+
+// storagePoint - contract storage at the point of its history. Storage is empty if the contract was not originated yet.
+type storagePoint struct {
+	bigmapdiff.Point
+
+	storage []byte
+	symLink string
+}
+
+func (p storagePoint) before(other storagePoint) bool {
+	if p.Level != other.Level {
+		return p.Level < other.Level
+	}
+	switch {
+	case other.OperationID == 0:
+		return p.OperationID != 0
+	case p.OperationID == 0:
+		return false
+	default:
+		return p.OperationID < other.OperationID
+	}
+}
+
+func getStoragePoint(c context.Context, ctx *config.Context, contract contract.Contract, level, operationID int64) (storagePoint, error) {
+	if operationID > 0 {
+		operation, err := ctx.Operations.GetByID(c, operationID)
+		if err != nil {
+			return storagePoint{}, err
+		}
+		if operation.DestinationID != contract.AccountID || operation.Status != types.OperationStatusApplied || len(operation.DeffatedStorage) == 0 {
+			return storagePoint{}, errors.Wrapf(errInvalidStoragePoint, "operation %d did not change storage of %s", operationID, contract.Account.Address)
+		}
+		proto, err := ctx.Cache.ProtocolByID(c, operation.ProtocolID)
+		if err != nil {
+			return storagePoint{}, err
+		}
+		return storagePoint{
+			Point:   bigmapdiff.Point{Level: operation.Level, OperationID: operation.ID},
+			storage: operation.DeffatedStorage,
+			symLink: proto.SymLink,
+		}, nil
+	}
+
+	header, err := ctx.Blocks.Get(c, level)
+	if err != nil {
+		return storagePoint{}, err
+	}
+	point := storagePoint{
+		Point:   bigmapdiff.Point{Level: level},
+		symLink: header.Protocol.SymLink,
+	}
+	if level < contract.Level {
+		return point, nil
+	}
+	point.storage, err = getDeffattedStorageAtLevel(c, ctx, contract.AccountID, contract.Account.Address, level)
+	return point, err
+}
+
+func getStorageDiffBetween(c context.Context, ctx *config.Context, address string, from, to storagePoint) (*ast.MiguelNode, error) {
+	if !from.before(to) {
+		return nil, errors.Wrap(errInvalidStoragePoint, "start point should be before end point")
+	}
+	if len(to.storage) == 0 {
+		return nil, errors.Wrapf(errInvalidStoragePoint, "%s is not originated at level %d", address, to.Level)
+	}
+	if len(from.storage) > 0 && from.symLink != to.symLink {
+		return nil, errors.Wrap(errInvalidStoragePoint, "storage type was changed by protocol migration between points")
+	}
+
+	storageType, err := getStorageType(c, ctx.Contracts, address, to.symLink)
+	if err != nil {
+		return nil, err
+	}
+
+	before, after, err := ctx.BigMapDiffs.Changes(c, address, from.Point, to.Point)
+	if err != nil {
+		return nil, err
+	}
+
+	currentStorage := &ast.TypedAst{
+		Nodes: []ast.Node{ast.Copy(storageType.Nodes[0])},
+	}
+	if err := prepareStorage(currentStorage, to.storage, after); err != nil {
+		return nil, err
+	}
+
+	var prevStorage *ast.TypedAst
+	if len(from.storage) > 0 {
+		prevStorage = &ast.TypedAst{
+			Nodes: []ast.Node{ast.Copy(storageType.Nodes[0])},
+		}
+		if err := prepareStorage(prevStorage, from.storage, before); err != nil {
+			return nil, err
+		}
+	}
+
+	return currentStorage.Diff(prevStorage)
+}
+
 func getDeffattedStorage(c context.Context, ctx *config.Context, address string, level int64) ([]byte, error) {
 	destination, err := ctx.Accounts.Get(c, address)
 	if err != nil {
 		return nil, err
 	}
 
-	filters := map[string]interface{}{
-		"destination_id": destination.ID,
-		"status":         types.OperationStatusApplied,
-	}
 	if level > 0 {
-		filters["level"] = level
+		return getDeffattedStorageAtLevel(c, ctx, destination.ID, address, level)
 	}
-	operation, err := ctx.Operations.Last(c, filters, 0)
+
+	operation, err := ctx.Operations.Last(c, map[string]interface{}{
+		"destination_id": destination.ID,
+		"status":         types.OperationStatusApplied,
+	}, 0)
 	switch {
 	case err != nil && !ctx.Storage.IsRecordNotFound(err):
 		return nil, err
@@ -315,6 +495,34 @@ func getDeffattedStorage(c context.Context, ctx *config.Context, address string,
 	}
 }
 
+// getDeffattedStorageAtLevel - returns storage of the last operation at or before `level`. If the contract was migrated by protocol after the operation, storage stored by the migration is returned.
+// Migrations indexed before their storage was stored don't have it, so the storage is requested from the node.
+func getDeffattedStorageAtLevel(c context.Context, ctx *config.Context, accountID int64, address string, level int64) ([]byte, error) {
+	operation, err := ctx.Operations.LastStorage(c, accountID, level)
+	if err != nil && !ctx.Storage.IsRecordNotFound(err) {
+		return nil, err
+	}
+	operationFound := err == nil && len(operation.DeffatedStorage) > 0
+
+	migration, err := ctx.Migrations.LastStorage(c, accountID, level)
+	switch {
+	case err == nil:
+		if !operationFound || migration.Level > operation.Level {
+			if len(migration.DeffatedStorage) == 0 {
+				return ctx.RPC.GetScriptStorageRaw(c, address, level)
+			}
+			return migration.DeffatedStorage, nil
+		}
+	case !ctx.Storage.IsRecordNotFound(err):
+		return nil, err
+	case !operationFound:
+		return nil, err
+	}
+
+	// protocol switch without migration of the contract doesn't change its storage
+	return operation.DeffatedStorage, nil
+}
+
 func getInitialOperation(c context.Context, ctx *config.Context, address string) (operation.Operation, error) {
 	destination, err := ctx.Accounts.Get(c, address)
 	if err != nil {
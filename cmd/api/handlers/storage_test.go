@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_migration "github.com/baking-bad/bcdhub/internal/models/mock/migration"
+	mock_operation "github.com/baking-bad/bcdhub/internal/models/mock/operation"
+)
+
+func TestGetDeffattedStorageAtLevel(t *testing.T) {
+	const level = 100
+	indexed := []byte(`{"int":"1"}`)
+	migrated := []byte(`{"int":"2"}`)
+	node := []byte(`{"int":"3"}`)
+
+	tests := []struct {
+		name         string
+		operation    operation.Operation
+		operationErr error
+		migration    migration.Migration
+		migrationErr error
+		rpcCalls     int
+		want         []byte
+		wantErr      bool
+	}{
+		{
+			name:         "indexed storage",
+			operation:    operation.Operation{Level: 90, DeffatedStorage: indexed},
+			migrationErr: sql.ErrNoRows,
+			want:         indexed,
+		}, {
+			name:      "migrated after operation",
+			operation: operation.Operation{Level: 90, DeffatedStorage: indexed},
+			migration: migration.Migration{Level: 95, DeffatedStorage: migrated},
+			want:      migrated,
+		}, {
+			name:      "migrated before operation",
+			operation: operation.Operation{Level: 90, DeffatedStorage: indexed},
+			migration: migration.Migration{Level: 80, DeffatedStorage: migrated},
+			want:      indexed,
+		}, {
+			name:         "bootstrapped contract without operations",
+			operationErr: sql.ErrNoRows,
+			migration:    migration.Migration{Level: 95, DeffatedStorage: migrated},
+			want:         migrated,
+		}, {
+			name:      "migration indexed without storage",
+			operation: operation.Operation{Level: 90, DeffatedStorage: indexed},
+			migration: migration.Migration{Level: 95},
+			rpcCalls:  1,
+			want:      node,
+		}, {
+			name:         "no storage before level",
+			operationErr: sql.ErrNoRows,
+			migrationErr: sql.ErrNoRows,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			operations := mock_operation.NewMockRepository(ctrl)
+			migrations := mock_migration.NewMockRepository(ctrl)
+			storage := mock_general.NewMockGeneralRepository(ctrl)
+			rpc := noderpc.NewMockINode(ctrl)
+
+			ctx := &config.Context{
+				Operations: operations,
+				Migrations: migrations,
+				Storage:    storage,
+				RPC:        rpc,
+			}
+
+			operations.EXPECT().
+				LastStorage(gomock.Any(), int64(10), int64(level)).
+				Return(tt.operation, tt.operationErr).
+				Times(1)
+			migrations.EXPECT().
+				LastStorage(gomock.Any(), int64(10), int64(level)).
+				Return(tt.migration, tt.migrationErr).
+				Times(1)
+			storage.EXPECT().
+				IsRecordNotFound(sql.ErrNoRows).
+				Return(true).
+				AnyTimes()
+			rpc.EXPECT().
+				GetScriptStorageRaw(gomock.Any(), "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", int64(level)).
+				Return(node, nil).
+				Times(tt.rpcCalls)
+
+			got, err := getDeffattedStorageAtLevel(context.Background(), ctx, 10, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", level)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStoragePointBefore(t *testing.T) {
+	point := func(level, operationID int64) storagePoint {
+		return storagePoint{Point: bigmapdiff.Point{Level: level, OperationID: operationID}}
+	}
+
+	tests := []struct {
+		name string
+		from storagePoint
+		to   storagePoint
+		want bool
+	}{
+		{name: "levels", from: point(10, 0), to: point(11, 0), want: true},
+		{name: "same level", from: point(10, 0), to: point(10, 0), want: false},
+		{name: "operation before end of level", from: point(10, 5), to: point(10, 0), want: true},
+		{name: "end of level before operation", from: point(10, 0), to: point(10, 5), want: false},
+		{name: "operations", from: point(10, 5), to: point(10, 6), want: true},
+		{name: "operations reversed", from: point(10, 6), to: point(10, 5), want: false},
+		{name: "operation in previous level", from: point(9, 100), to: point(10, 5), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.from.before(tt.to))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/stream"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const streamHeartbeatInterval = 30 * time.Second
+
+// StreamOperations godoc
+// @Summary Subscribe to new operations
+// @Description Server-sent events stream of operations committed by the indexer. Event `operation` contains operation, event `rollback` contains level the indexer state was rolled back to: operations above the level were removed. Event `heartbeat` is sent periodically to keep connection alive.
+// @Tags stream
+// @ID stream-operations
+// @Param network           path  string  true  "Network"
+// @Param address           query string  false "Source or destination address" minlength(36) maxlength(36)
+// @Param entrypoint        query string  false "Entrypoint"
+// @Param kind              query string  false "Comma-separated list of operation kinds"
+// @Param ptr               query integer false "Big map pointer changed by operation" mininum(0)
+// @Param with_storage_diff query bool    false "Include storage diff to operations or not"
+// @Produce text/event-stream
+// @Success 200 {object} Operation
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/stream/{network}/operations [get]
+func StreamOperations(hubs map[types.Network]*stream.Hub) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var args streamOperationsRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		hub, ok := hubs[ctx.Network]
+		if !ok {
+			c.SecureJSON(http.StatusNotFound, Error{Message: "stream is unavailable for network " + ctx.Network.String()})
+			return
+		}
+
+		filter := newOperationsFilter(args)
+		sub := hub.Subscribe()
+		defer hub.Unsubscribe(sub)
+
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case <-heartbeat.C:
+				c.SSEvent("heartbeat", gin.H{"time": time.Now().UTC()})
+				return true
+			case msg, ok := <-sub.Messages():
+				if !ok {
+					return false
+				}
+				if msg.Network != ctx.Network.String() {
+					return true
+				}
+
+				switch msg.Type {
+				case stream.MessageTypeRollback:
+					c.SSEvent("rollback", gin.H{"level": msg.Level})
+				case stream.MessageTypeBlock:
+					operations, err := filter.operations(c.Request.Context(), ctx, msg.Level)
+					if err != nil {
+						log.Err(err).Str("network", msg.Network).Int64("level", msg.Level).Msg("stream operations")
+						return true
+					}
+					for i := range operations {
+						c.SSEvent("operation", operations[i])
+					}
+				}
+				return true
+			}
+		})
+	}
+}
+
+type operationsFilter struct {
+	address         string
+	entrypoint      string
+	kinds           map[types.OperationKind]struct{}
+	ptr             *int64
+	withStorageDiff bool
+}
+
+func newOperationsFilter(req streamOperationsRequest) operationsFilter {
+	filter := operationsFilter{
+		address:         req.Address,
+		entrypoint:      req.Entrypoint,
+		ptr:             req.Ptr,
+		withStorageDiff: req.WithStorageDiff,
+	}
+	if req.Kind != "" {
+		filter.kinds = make(map[types.OperationKind]struct{})
+		for _, kind := range strings.Split(req.Kind, ",") {
+			filter.kinds[types.NewOperationKind(kind)] = struct{}{}
+		}
+	}
+	return filter
+}
+
+// operations - returns operations of the block matched by the filter
+func (f operationsFilter) operations(c context.Context, ctx *config.Context, level int64) ([]Operation, error) {
+	operations, err := ctx.Operations.ListByLevel(c, level)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Operation, 0)
+	for i := range operations {
+		ok, err := f.match(c, ctx, &operations[i])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		op, err := prepareOperation(c, ctx, operations[i], f.withStorageDiff)
+		if err != nil {
+			log.Err(err).Int64("id", operations[i].ID).Msg("prepare stream operation")
+			continue
+		}
+		result = append(result, op)
+	}
+	return result, nil
+}
+
+func (f operationsFilter) match(c context.Context, ctx *config.Context, op *operation.Operation) (bool, error) {
+	if f.address != "" && op.Source.Address != f.address && op.Destination.Address != f.address {
+		return false, nil
+	}
+	if f.entrypoint != "" && op.Entrypoint.String() != f.entrypoint {
+		return false, nil
+	}
+	if f.kinds != nil {
+		if _, ok := f.kinds[op.Kind]; !ok {
+			return false, nil
+		}
+	}
+	if f.ptr == nil {
+		return true, nil
+	}
+	if op.BigMapDiffsCount == 0 {
+		return false, nil
+	}
+
+	diffs, err := ctx.BigMapDiffs.GetForOperation(c, op.ID)
+	if err != nil {
+		return false, err
+	}
+	op.BigMapDiffs = make([]*bigmapdiff.BigMapDiff, len(diffs))
+	var found bool
+	for i := range diffs {
+		op.BigMapDiffs[i] = &diffs[i]
+		found = found || diffs[i].Ptr == *f.ptr
+	}
+	return found, nil
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTokenBalances godoc
+// @Summary Get token balances of FA1.2 or FA2 contract
+// @Description Get token balances of FA1.2 or FA2 contract
+// @Tags tokens
+// @ID get-token-balances
+// @Param network    path  string  true  "network"
+// @Param address    path  string  true  "KT address"     minlength(36) maxlength(36)
+// @Param holder     query string  false "Holder address" minlength(36) maxlength(36)
+// @Param token_id   query integer false "Token id"       mininum(0)
+// @Param skip_empty query boolean false "Skip zero balances"
+// @Param size       query integer false "Balances count" mininum(1) maximum(10)
+// @Param offset     query integer false "Offset"         mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} TokenBalance
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/tokens/{network}/{address} [get]
+func GetTokenBalances() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getContractRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		var args tokenBalancesRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		balances, err := ctx.Tokens.Balances(c.Request.Context(), req.Address, token.BalanceRequest{
+			Holder:              args.Holder,
+			TokenId:             args.TokenId,
+			Limit:               args.Size,
+			Offset:              args.Offset,
+			WithoutZeroBalances: args.WithoutZeroBalances,
+		})
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]TokenBalance, len(balances))
+		for i := range balances {
+			response[i] = NewTokenBalance(balances[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetTokenTransfers godoc
+// @Summary Get token transfers of FA1.2 or FA2 contract
+// @Description Get token transfers of FA1.2 or FA2 contract. Transfer without sender is mint and transfer without receiver is burn.
+// @Tags tokens
+// @ID get-token-transfers
+// @Param network  path  string  true  "network"
+// @Param address  path  string  true  "KT address"      minlength(36) maxlength(36)
+// @Param account  query string  false "Sender or receiver address" minlength(36) maxlength(36)
+// @Param token_id query integer false "Token id"        mininum(0)
+// @Param size     query integer false "Transfers count" mininum(1) maximum(10)
+// @Param offset   query integer false "Offset"          mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} TokenTransfer
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/tokens/{network}/{address}/transfers [get]
+func GetTokenTransfers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getContractRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		var args tokenTransfersRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		transfers, err := ctx.Tokens.Transfers(c.Request.Context(), req.Address, token.TransfersRequest{
+			Account: args.Account,
+			TokenId: args.TokenId,
+			Limit:   args.Size,
+			Offset:  args.Offset,
+		})
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response, err := prepareTokenTransfers(c.Request.Context(), ctx, transfers)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// GetTokenTransfersForOperation -
+// @Router /v1/operation/{network}/{id}/token_transfers [get]
+func GetTokenTransfersForOperation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getOperationByIDRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		transfers, err := ctx.Tokens.TransfersForOperation(c.Request.Context(), req.ID)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		response, err := prepareTokenTransfers(c.Request.Context(), ctx, transfers)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+func prepareTokenTransfers(c context.Context, ctx *config.Context, transfers []token.Transfer) ([]TokenTransfer, error) {
+	hashes := make(map[int64]string)
+	response := make([]TokenTransfer, 0, len(transfers))
+	for i := range transfers {
+		transfer := NewTokenTransfer(transfers[i])
+
+		hash, ok := hashes[transfers[i].OperationId]
+		if !ok {
+			operation, err := ctx.Operations.GetByID(c, transfers[i].OperationId)
+			if err != nil {
+				return nil, err
+			}
+			hash = encoding.MustEncodeOperationHash(operation.Hash)
+			hashes[transfers[i].OperationId] = hash
+		}
+		transfer.OperationHash = hash
+
+		response = append(response, transfer)
+	}
+	return response, nil
+}
+
+// GetContractTokens godoc
+// @Summary Get tokens of FA2 contract
+// @Description Get TZIP-21 metadata of tokens of FA2 contract ordered by token id
+// @Tags contract
+// @ID get-contract-tokens
+// @Param network path  string  true  "network"
+// @Param address path  string  true  "KT address"   minlength(36) maxlength(36)
+// @Param size    query integer false "Tokens count" mininum(1) maximum(10)
+// @Param offset  query integer false "Offset"       mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} TokenMetadata
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/contract/{network}/{address}/tokens [get]
+func GetContractTokens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getContractRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		var args pageableRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		tokens, err := ctx.Metadata.TokenMetadata(c.Request.Context(), req.Address, metadata.TokenMetadataRequest{
+			Limit:  args.Size,
+			Offset: args.Offset,
+		})
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]TokenMetadata, len(tokens))
+		for i := range tokens {
+			response[i] = NewTokenMetadata(tokens[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+	delivery "github.com/baking-bad/bcdhub/internal/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+const webhookSecretLength = 32
+
+// ownedWebhook - returns webhook by id if it's registered by authenticated owner. Webhooks of other owners are reported as not found.
+func ownedWebhook(c *gin.Context, ctx *config.Context, id int64) (webhook.Webhook, bool) {
+	hook, err := ctx.Webhooks.Get(c.Request.Context(), id)
+	if handleError(c, ctx.Storage, err, 0) {
+		return hook, false
+	}
+	if hook.Owner != c.GetString("webhook_owner") {
+		c.AbortWithStatusJSON(http.StatusNotFound, Error{Message: "webhook not found"})
+		return hook, false
+	}
+	return hook, true
+}
+
+// CreateWebhook godoc
+// @Summary Register webhook
+// @Description Register webhook receiving operations matched by filter. Empty filter field matches any value. `address` is compared with source and destination of operation, `tag` is compared with event tag and contract tags of operation. Every request is signed: header `X-BCD-Signature` contains `sha256=` and hex-encoded HMAC-SHA256 of request body with webhook secret. Secret is returned only in the response of this method. URL must be `https` and must not point to loopback, private or link-local address.
+// @Tags webhooks
+// @ID create-webhook
+// @Param Authorization header string               true "Bearer token of webhook owner"
+// @Param network       path   string               true "network"
+// @Param body          body   createWebhookRequest true "Webhook"
+// @Accept json
+// @Produce json
+// @Success 200 {object} Webhook
+// @Failure 400 {object} Error
+// @Failure 401 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/webhooks/{network} [post]
+func CreateWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req createWebhookRequest
+		if err := c.ShouldBindJSON(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+		if err := delivery.ValidateURL(c.Request.Context(), req.URL); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		secret := make([]byte, webhookSecretLength)
+		if _, err := rand.Read(secret); handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		hook := webhook.Webhook{
+			Owner:      c.GetString("webhook_owner"),
+			URL:        req.URL,
+			Secret:     hex.EncodeToString(secret),
+			Address:    req.Address,
+			Entrypoint: req.Entrypoint,
+			Tag:        req.Tag,
+			Status:     types.NewOperationStatus(req.Status),
+			CreatedAt:  time.Now().UTC(),
+		}
+		if err := ctx.Webhooks.Create(c.Request.Context(), &hook); handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := NewWebhook(hook)
+		response.Secret = hook.Secret
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description List webhooks of the network registered by authenticated owner
+// @Tags webhooks
+// @ID list-webhooks
+// @Param Authorization header string true "Bearer token of webhook owner"
+// @Param network       path   string true "network"
+// @Accept json
+// @Produce json
+// @Success 200 {array} Webhook
+// @Failure 400 {object} Error
+// @Failure 401 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/webhooks/{network} [get]
+func ListWebhooks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		hooks, err := ctx.Webhooks.ListByOwner(c.Request.Context(), c.GetString("webhook_owner"))
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]Webhook, len(hooks))
+		for i := range hooks {
+			response[i] = NewWebhook(hooks[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook
+// @Description Delete webhook with its undelivered events and dead letters
+// @Tags webhooks
+// @ID delete-webhook
+// @Param Authorization header string  true "Bearer token of webhook owner"
+// @Param network       path   string  true "network"
+// @Param id            path   integer true "Webhook id" mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200
+// @Failure 400 {object} Error
+// @Failure 401 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/webhooks/{network}/{id} [delete]
+func DeleteWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getWebhookRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		if _, ok := ownedWebhook(c, ctx, req.ID); !ok {
+			return
+		}
+		if err := ctx.Webhooks.Delete(c.Request.Context(), req.ID); handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+// GetWebhookDeadLetters godoc
+// @Summary Get dead letters of webhook
+// @Description Get events which were not accepted by webhook receiver after maximum count of attempts
+// @Tags webhooks
+// @ID get-webhook-dead-letters
+// @Param Authorization header string  true  "Bearer token of webhook owner"
+// @Param network       path   string  true  "network"
+// @Param id            path   integer true  "Webhook id"    mininum(1)
+// @Param size          query  integer false "Letters count" mininum(1) maximum(10)
+// @Param offset        query  integer false "Offset"        mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} WebhookDeadLetter
+// @Failure 400 {object} Error
+// @Failure 401 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/webhooks/{network}/{id}/dead_letters [get]
+func GetWebhookDeadLetters() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.MustGet("context").(*config.Context)
+
+		var req getWebhookRequest
+		if err := c.ShouldBindUri(&req); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		var args pageableRequest
+		if err := c.ShouldBindQuery(&args); handleError(c, ctx.Storage, err, http.StatusBadRequest) {
+			return
+		}
+
+		if _, ok := ownedWebhook(c, ctx, req.ID); !ok {
+			return
+		}
+
+		letters, err := ctx.Webhooks.DeadLetters(c.Request.Context(), req.ID, args.Size, args.Offset)
+		if handleError(c, ctx.Storage, err, 0) {
+			return
+		}
+
+		response := make([]WebhookDeadLetter, len(letters))
+		for i := range letters {
+			response[i] = NewWebhookDeadLetter(letters[i])
+		}
+		c.SecureJSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/cmd/api/validations"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_webhook "github.com/baking-bad/bcdhub/internal/models/mock/webhook"
+)
+
+func TestWebhooksAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	require.True(t, ok)
+	require.NoError(t, validations.Register(v, config.APIConfig{
+		Networks: []string{types.Mainnet.String()},
+	}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hooks := mock_webhook.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+	storage.EXPECT().IsRecordNotFound(gomock.Any()).Return(false).AnyTimes()
+
+	ctxs := config.Contexts{
+		types.Mainnet: &config.Context{Network: types.Mainnet, Webhooks: hooks, Storage: storage},
+	}
+
+	tokens := map[string]string{
+		"alice": "alice-token",
+		"bob":   "bob-token",
+	}
+
+	router := gin.New()
+	router.SecureJsonPrefix("")
+	group := router.Group("/v1/webhooks/:network")
+	group.Use(WebhooksAuthMiddleware(tokens), NetworkMiddleware(ctxs))
+	group.GET("", ListWebhooks())
+	group.POST("", CreateWebhook())
+	group.DELETE(":id", DeleteWebhook())
+	group.GET(":id/dead_letters", GetWebhookDeadLetters())
+
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("without token", func(t *testing.T) {
+		w := request(http.MethodGet, "/v1/webhooks/mainnet", "", "")
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		w := request(http.MethodGet, "/v1/webhooks/mainnet", "unknown", "")
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("list by owner", func(t *testing.T) {
+		hooks.EXPECT().
+			ListByOwner(gomock.Any(), "alice").
+			Return([]webhook.Webhook{{ID: 1, Owner: "alice", URL: "https://example.com/hook"}}, nil).
+			Times(1)
+
+		w := request(http.MethodGet, "/v1/webhooks/mainnet", "alice-token", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "https://example.com/hook")
+	})
+
+	t.Run("delete webhook of other owner", func(t *testing.T) {
+		hooks.EXPECT().
+			Get(gomock.Any(), int64(1)).
+			Return(webhook.Webhook{ID: 1, Owner: "alice"}, nil).
+			Times(1)
+
+		w := request(http.MethodDelete, "/v1/webhooks/mainnet/1", "bob-token", "")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("dead letters of other owner", func(t *testing.T) {
+		hooks.EXPECT().
+			Get(gomock.Any(), int64(1)).
+			Return(webhook.Webhook{ID: 1, Owner: "alice"}, nil).
+			Times(1)
+
+		w := request(http.MethodGet, "/v1/webhooks/mainnet/1/dead_letters", "bob-token", "")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete own webhook", func(t *testing.T) {
+		hooks.EXPECT().
+			Get(gomock.Any(), int64(1)).
+			Return(webhook.Webhook{ID: 1, Owner: "alice"}, nil).
+			Times(1)
+		hooks.EXPECT().
+			Delete(gomock.Any(), int64(1)).
+			Return(nil).
+			Times(1)
+
+		w := request(http.MethodDelete, "/v1/webhooks/mainnet/1", "alice-token", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	for _, url := range []string{
+		"http://1.1.1.1/hook",
+		"https://127.0.0.1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.0.0.1/hook",
+	} {
+		t.Run("create "+url, func(t *testing.T) {
+			w := request(http.MethodPost, "/v1/webhooks/mainnet", "alice-token", `{"url":"`+url+`"}`)
+			require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("create", func(t *testing.T) {
+		hooks.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, hook *webhook.Webhook) error {
+				require.Equal(t, "alice", hook.Owner)
+				require.Equal(t, "https://1.1.1.1/hook", hook.URL)
+				hook.ID = 2
+				return nil
+			}).
+			Times(1)
+
+		w := request(http.MethodPost, "/v1/webhooks/mainnet", "alice-token", `{"url":"https://1.1.1.1/hook"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
@@ -12,8 +12,11 @@ import (
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/metrics"
+	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/periodic"
 	"github.com/baking-bad/bcdhub/internal/profiler"
+	"github.com/baking-bad/bcdhub/internal/stream"
 	sentrygin "github.com/getsentry/sentry-go/gin"
 	"github.com/gin-contrib/cache"
 	"github.com/gin-contrib/cache/persistence"
@@ -35,6 +38,7 @@ type app struct {
 	cancel   context.CancelFunc
 	worker   *periodic.GeneralWorker
 	profiler *pyroscope.Profiler
+	streams  map[types.Network]*stream.Hub
 }
 
 func newApp() *app {
@@ -56,6 +60,9 @@ func newApp() *app {
 	app := new(app)
 	app.Config = cfg
 
+	ctx, cancel := context.WithCancel(context.Background())
+	app.cancel = cancel
+
 	runtime.SetMutexProfileFraction(5)
 	runtime.SetBlockProfileRate(5)
 
@@ -73,9 +80,6 @@ func newApp() *app {
 			panic(err)
 		}
 		app.worker = worker
-
-		ctx, cancel := context.WithCancel(context.Background())
-		app.cancel = cancel
 		app.worker.Start(ctx)
 
 		for len(app.worker.URLs()) == 0 {
@@ -90,6 +94,16 @@ func newApp() *app {
 		config.WithLoadErrorDescriptions(),
 		config.WithConfigCopy(cfg))
 
+	app.streams = make(map[types.Network]*stream.Hub)
+	for network, networkCtx := range app.Contexts {
+		hub := stream.NewHub(networkCtx.StorageDB.DB)
+		if err := hub.Start(ctx); err != nil {
+			log.Err(err).Str("network", network.String()).Msg("can't start operations stream")
+			continue
+		}
+		app.streams[network] = hub
+	}
+
 	app.makeRouter()
 
 	return app
@@ -126,6 +140,16 @@ func (api *app) makeRouter() {
 	}
 
 	r.Use(ginLogger.SetLogger())
+
+	// streaming endpoint is registered before timeout middleware because the middleware buffers response and limits its duration
+	r.GET("v1/stream/:network/operations", handlers.NetworkMiddleware(api.Contexts), handlers.StreamOperations(api.streams))
+
+	// long-lived streaming requests and scrapes are not measured
+	if api.Config.Metrics != nil {
+		r.GET("metrics", gin.WrapH(metrics.Handler()))
+		r.Use(metricsMiddleware())
+	}
+
 	r.Use(timeout.New(
 		timeout.WithTimeout(30*time.Second),
 		timeout.WithHandler(func(c *gin.Context) {
@@ -133,6 +157,11 @@ func (api *app) makeRouter() {
 		}),
 	))
 
+	graphQLSchema, err := handlers.NewGraphQLSchema()
+	if err != nil {
+		panic(err)
+	}
+
 	v1 := r.Group("v1")
 	{
 		v1.GET("config", handlers.ContextsMiddleware(api.Contexts), handlers.GetConfig())
@@ -148,6 +177,9 @@ func (api *app) makeRouter() {
 		v1.POST("off_chain_view", handlers.MainnetMiddleware(api.Contexts), handlers.OffChainView())
 		v1.POST("michelson", handlers.ContextsMiddleware(api.Contexts), handlers.CodeFromMichelson())
 		v1.POST("fork", handlers.ForkContract(api.Contexts))
+		v1.GET("search", handlers.ContextsMiddleware(api.Contexts), handlers.Search())
+		v1.GET("rpc/:network/health", handlers.NetworkMiddleware(api.Contexts), handlers.GetRPCHealth())
+		v1.GET("reorgs/:network", handlers.NetworkMiddleware(api.Contexts), handlers.GetReorgs())
 
 		operation := v1.Group("operation/:network/:id")
 		operation.Use(handlers.NetworkMiddleware(api.Contexts))
@@ -155,7 +187,9 @@ func (api *app) makeRouter() {
 			operation.GET("error_location", handlers.GetOperationErrorLocation())
 			operation.GET("diff", handlers.GetOperationDiff())
 			operation.GET("ticket_updates", handlers.GetTicketUpdatesForOperation())
+			operation.GET("token_transfers", handlers.GetTokenTransfersForOperation())
 		}
+		v1.GET("operations/:network/search", handlers.NetworkMiddleware(api.Contexts), handlers.SearchOperations())
 
 		stats := v1.Group("stats")
 		{
@@ -166,6 +200,8 @@ func (api *app) makeRouter() {
 			{
 				networkStats.GET("recently_called_contracts", cache.CachePage(store, time.Second*10, handlers.RecentlyCalledContracts()))
 				networkStats.GET("contracts_count", cache.CachePage(store, time.Second*10, handlers.ContractsCount()))
+				networkStats.GET("series", cache.CachePage(store, time.Second*30, handlers.GetNetworkSeries()))
+				networkStats.GET("top_contracts", cache.CachePage(store, time.Second*30, handlers.GetTopContracts()))
 			}
 		}
 
@@ -198,7 +234,9 @@ func (api *app) makeRouter() {
 			contract.GET("global_constants", handlers.GetContractGlobalConstants())
 			contract.GET("ticket_updates", handlers.GetContractTicketUpdates())
 			contract.GET("tickets", handlers.GetContractTickets())
+			contract.GET("tokens", handlers.GetContractTokens())
 			contract.GET("events", handlers.ListEvents())
+			contract.GET("analytics", handlers.GetContractAnalytics())
 
 			storage := contract.Group("storage")
 			{
@@ -206,6 +244,7 @@ func (api *app) makeRouter() {
 				storage.GET("raw", handlers.GetContractStorageRaw())
 				storage.GET("rich", handlers.GetContractStorageRich())
 				storage.GET("schema", handlers.GetContractStorageSchema())
+				storage.GET("diff", handlers.GetContractStorageDiff())
 			}
 
 			contract.GET("mempool", handlers.GetMempool())
@@ -251,7 +290,35 @@ func (api *app) makeRouter() {
 		{
 			smartRollups.GET("", handlers.ListSmartRollups())
 			smartRollups.GET(":address", handlers.GetSmartRollup())
+			smartRollups.GET(":address/commitments", handlers.GetSmartRollupCommitments())
+			smartRollups.GET(":address/stakers", handlers.GetSmartRollupStakers())
+			smartRollups.GET(":address/games", handlers.GetSmartRollupGames())
+			smartRollups.GET(":address/messages", handlers.GetSmartRollupMessages())
+			smartRollups.GET(":address/outbox", handlers.GetSmartRollupOutbox())
+		}
+
+		tokens := v1.Group("tokens/:network/:address")
+		tokens.Use(handlers.NetworkMiddleware(api.Contexts))
+		{
+			tokens.GET("", handlers.GetTokenBalances())
+			tokens.GET("transfers", handlers.GetTokenTransfers())
+		}
+
+		if len(api.Config.API.Webhooks.Tokens) > 0 {
+			webhooks := v1.Group("webhooks/:network")
+			webhooks.Use(
+				handlers.WebhooksAuthMiddleware(api.Config.API.Webhooks.Tokens),
+				handlers.NetworkMiddleware(api.Contexts),
+			)
+			{
+				webhooks.GET("", handlers.ListWebhooks())
+				webhooks.POST("", handlers.CreateWebhook())
+				webhooks.DELETE(":id", handlers.DeleteWebhook())
+				webhooks.GET(":id/dead_letters", handlers.GetWebhookDeadLetters())
+			}
 		}
+
+		v1.POST("graphql/:network", handlers.NetworkMiddleware(api.Contexts), handlers.GraphQL(graphQLSchema, api.Config.API.GraphQL))
 	}
 	api.Router = r
 }
@@ -266,6 +333,12 @@ func (api *app) Close() error {
 		}
 	}
 
+	for _, hub := range api.streams {
+		if err := hub.Close(); err != nil {
+			return err
+		}
+	}
+
 	if err := api.Contexts.Close(); err != nil {
 		return err
 	}
@@ -303,13 +376,27 @@ func main() {
 func corsSettings() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PATCH"},
+		AllowMethods:     []string{"GET", "POST", "PATCH", "DELETE"},
 		AllowHeaders:     []string{"X-Requested-With", "Authorization", "Origin", "Content-Length", "Content-Type", "Referer", "Cache-Control", "User-Agent"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
 }
 
+func metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// unknown routes are merged to one label value to keep cardinality of the metric low
+		route := c.FullPath()
+		if route == "" {
+			route = "unknown"
+		}
+		metrics.APIRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
 func (api *app) handleUrlChanged(ctx context.Context, network, url string) error {
 	if value, ok := api.Config.RPC[network]; ok {
 		value.URI = url
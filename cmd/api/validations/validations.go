@@ -8,6 +8,8 @@ import (
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/go-playground/validator/v10"
 )
@@ -66,6 +68,14 @@ func Register(v *validator.Validate, cfg config.APIConfig) error {
 		return err
 	}
 
+	if err := v.RegisterValidation("operation_kind", operationKindValidator()); err != nil {
+		return err
+	}
+
+	if err := v.RegisterValidation("search_kind", searchKindValidator()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -123,6 +133,30 @@ func statusValidator() validator.Func {
 	}
 }
 
+func operationKindValidator() validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		kinds := strings.Split(fl.Field().String(), ",")
+		for i := range kinds {
+			if types.NewOperationKind(kinds[i]) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func searchKindValidator() validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		kinds := strings.Split(fl.Field().String(), ",")
+		for i := range kinds {
+			if search.NewKind(kinds[i]) == "" {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func faVersionValidator() validator.Func {
 	return func(fl validator.FieldLevel) bool {
 		version := fl.Field().String()
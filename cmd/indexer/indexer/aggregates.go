@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/dipdup-io/workerpool"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+)
+
+const aggregatesRetryInterval = time.Minute
+
+// aggregatesRefresher - refreshes continuous aggregates in background. Refresh policies materialize only recent buckets, so history indexed
+// during synchronization is materialized when the indexer reaches the head. Failed refresh is retried until it succeeds.
+type aggregatesRefresher struct {
+	db      bun.IDB
+	network string
+	retry   time.Duration
+	refresh func(ctx context.Context, db bun.IDB, from time.Time) error
+
+	mx      sync.Mutex
+	pending bool
+	from    time.Time
+	wake    chan struct{}
+
+	g workerpool.Group
+}
+
+func newAggregatesRefresher(network string, db bun.IDB) *aggregatesRefresher {
+	return &aggregatesRefresher{
+		db:      db,
+		network: network,
+		retry:   aggregatesRetryInterval,
+		refresh: core.RefreshContinuousAggregates,
+		wake:    make(chan struct{}, 1),
+		g:       workerpool.NewGroup(),
+	}
+}
+
+// Start -
+func (r *aggregatesRefresher) Start(ctx context.Context) {
+	r.g.GoCtx(ctx, r.run)
+}
+
+// Close -
+func (r *aggregatesRefresher) Close() error {
+	r.g.Wait()
+	return nil
+}
+
+// Schedule - requests refresh of buckets starting from `from`. Zero `from` means the whole history. Scheduled requests are merged into the widest one.
+func (r *aggregatesRefresher) Schedule(from time.Time) {
+	r.mx.Lock()
+	r.merge(from)
+	r.mx.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// merge - adds the request to the pending one. It has to be called under the lock.
+func (r *aggregatesRefresher) merge(from time.Time) {
+	switch {
+	case !r.pending:
+		r.from = from
+	case r.from.IsZero():
+	case from.IsZero() || from.Before(r.from):
+		r.from = from
+	}
+	r.pending = true
+}
+
+// moveTo - schedules pending refresh on the other refresher. It's called when the refresher is replaced after it's closed.
+func (r *aggregatesRefresher) moveTo(other *aggregatesRefresher) {
+	r.mx.Lock()
+	pending, from := r.pending, r.from
+	r.pending = false
+	r.mx.Unlock()
+
+	if pending {
+		other.Schedule(from)
+	}
+}
+
+func (r *aggregatesRefresher) run(ctx context.Context) {
+	ticker := time.NewTicker(r.retry)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+		r.process(ctx)
+	}
+}
+
+// process - refreshes scheduled buckets. Failed request is scheduled again, so it's retried by the next tick.
+func (r *aggregatesRefresher) process(ctx context.Context) {
+	r.mx.Lock()
+	if !r.pending {
+		r.mx.Unlock()
+		return
+	}
+	from := r.from
+	r.pending = false
+	r.mx.Unlock()
+
+	start := time.Now()
+	if err := r.refresh(ctx, r.db, from); err != nil {
+		log.Err(err).Str("network", r.network).Time("from", from).Msg("refreshing aggregates")
+		if ctx.Err() == nil {
+			r.mx.Lock()
+			r.merge(from)
+			r.mx.Unlock()
+		}
+		return
+	}
+	log.Info().Str("network", r.network).Time("from", from).Dur("duration", time.Since(start)).Msg("aggregates are refreshed")
+}
@@ -0,0 +1,94 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestAggregatesRefresher_Schedule(t *testing.T) {
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	var refreshed []time.Time
+	r := newAggregatesRefresher("test", nil)
+	r.refresh = func(_ context.Context, _ bun.IDB, from time.Time) error {
+		refreshed = append(refreshed, from)
+		return nil
+	}
+
+	r.process(context.Background())
+	require.Empty(t, refreshed)
+
+	r.Schedule(late)
+	r.Schedule(early)
+	r.process(context.Background())
+	require.Equal(t, []time.Time{early}, refreshed)
+
+	// whole history isn't narrowed by later requests
+	r.Schedule(time.Time{})
+	r.Schedule(early)
+	r.process(context.Background())
+	require.Equal(t, []time.Time{early, {}}, refreshed)
+
+	r.process(context.Background())
+	require.Len(t, refreshed, 2)
+}
+
+func TestAggregatesRefresher_Retry(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var (
+		calls int
+		fail  = true
+	)
+	r := newAggregatesRefresher("test", nil)
+	r.refresh = func(_ context.Context, _ bun.IDB, got time.Time) error {
+		calls++
+		require.Equal(t, from, got)
+		if fail {
+			return errors.New("refresh failed")
+		}
+		return nil
+	}
+
+	r.Schedule(from)
+	r.process(context.Background())
+	require.Equal(t, 1, calls)
+	require.True(t, r.pending)
+
+	fail = false
+	r.process(context.Background())
+	require.Equal(t, 2, calls)
+	require.False(t, r.pending)
+}
+
+func TestBlockchainIndexer_initAggregates(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bi := &BlockchainIndexer{
+		Context: &config.Context{StorageDB: &core.Postgres{DB: &bun.DB{}}},
+		Network: types.Mainnet,
+	}
+	bi.initAggregates()
+	previous := bi.aggregates
+	previous.Schedule(from)
+
+	// context is recreated after switching of the node
+	db := &bun.DB{}
+	bi.Context = &config.Context{StorageDB: &core.Postgres{DB: db}}
+	bi.initAggregates()
+
+	require.NotSame(t, previous, bi.aggregates)
+	require.Same(t, db, bi.aggregates.db)
+	require.True(t, bi.aggregates.pending)
+	require.Equal(t, from, bi.aggregates.from)
+	require.False(t, previous.pending)
+}
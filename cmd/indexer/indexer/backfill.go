@@ -0,0 +1,199 @@
+package indexer
+
+import (
+	"context"
+
+	modelsFilter "github.com/baking-bad/bcdhub/internal/models/filter"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers/operations"
+	"github.com/baking-bad/bcdhub/internal/postgres/store"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// filterChanges - changes of the filter whose history isn't indexed: contracts of the allow-list which are not indexed
+// and code hashes or tags which were added to the filter
+type filterChanges struct {
+	addresses []string
+	rules     []modelsFilter.Rule
+}
+
+func (c *filterChanges) empty() bool {
+	return len(c.addresses) == 0 && len(c.rules) == 0
+}
+
+// diffFilter - compares the filter with indexed contracts and stored rules. It's called when the filter is created from the config,
+// so the changes are backfilled by `Start` both after the start and after reinitialization of the indexer.
+func (bi *BlockchainIndexer) diffFilter(ctx context.Context) error {
+	bi.filterChanges = nil
+	if bi.filter == nil {
+		return nil
+	}
+
+	missing, err := bi.filter.Missing(ctx)
+	if err != nil {
+		return errors.Wrap(err, "receiving missing contracts of the filter")
+	}
+	indexed, err := bi.FilterRules.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "receiving rules of the filter")
+	}
+
+	bi.filterChanges = &filterChanges{
+		addresses: missing,
+		rules:     bi.filter.NewRules(indexed),
+	}
+	return nil
+}
+
+// backfill - indexes history of the contracts which were added to the filter after they had been originated. Already indexed blocks
+// are received again and only operations which touch missing contracts of the allow-list, originate contracts matched by new code hashes
+// or tags, or touch contracts originated by them are saved. Operations which touch other indexed contracts are saved already.
+// Contracts of the allow-list are backfilled from the earliest origination of them. Originations matched by new rules may be anywhere,
+// so the whole history is received in that case. Rules of the filter are stored after backfill, so it isn't repeated after restart.
+func (bi *BlockchainIndexer) backfill(ctx context.Context) error {
+	if bi.filter == nil || bi.filterChanges == nil {
+		return nil
+	}
+
+	bi.mx.Lock()
+	defer bi.mx.Unlock()
+
+	if !bi.filterChanges.empty() && bi.state.Level > 1 {
+		if err := bi.backfillChanges(ctx, *bi.filterChanges); err != nil {
+			return err
+		}
+	}
+
+	if err := bi.FilterRules.Replace(ctx, bi.filter.Rules()); err != nil {
+		return errors.Wrap(err, "saving rules of the filter")
+	}
+	bi.filterChanges = nil
+	return nil
+}
+
+func (bi *BlockchainIndexer) backfillChanges(ctx context.Context, changes filterChanges) error {
+	from := bi.state.Level + 1
+	if len(changes.rules) > 0 {
+		from = 2
+	}
+
+	addresses := make([]string, 0, len(changes.addresses))
+	for _, address := range changes.addresses {
+		level, err := originationLevel(ctx, bi.RPC, address, bi.state.Level)
+		if err != nil {
+			return errors.Wrap(err, address)
+		}
+		if level == 0 {
+			continue
+		}
+		addresses = append(addresses, address)
+		from = min(from, level)
+	}
+	if len(addresses) == 0 && len(changes.rules) == 0 {
+		return nil
+	}
+
+	rules := make([]string, len(changes.rules))
+	for i := range changes.rules {
+		rules[i] = changes.rules[i].Kind + ":" + changes.rules[i].Value
+	}
+
+	log.Info().
+		Str("network", bi.Network.String()).
+		Strs("contracts", addresses).
+		Strs("rules", rules).
+		Int64("from", from).
+		Int64("to", bi.state.Level).
+		Msg("backfill of contracts history")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	window, threads := int64(defaultBulkWindow), int64(defaultBulkThreads)
+	if bi.bulk != nil {
+		window, threads = bi.bulk.window, bi.bulk.threads
+	}
+
+	filter := bi.filter.Backfill(addresses, changes.rules)
+	level := from
+	for block := range fetchBlocks(ctx, bi.RPC, from, bi.state.Level, window, threads) {
+		if err := bi.backfillBlock(ctx, block, filter); err != nil {
+			return errors.Wrapf(err, "backfill of block %d", block.Header.Level)
+		}
+		level++
+	}
+	if level <= bi.state.Level {
+		if ctx.Err() != nil {
+			return errBcdQuit
+		}
+		return errors.Errorf("backfill is stopped at block %d", level)
+	}
+
+	log.Info().Str("network", bi.Network.String()).Strs("contracts", addresses).Strs("rules", rules).Msg("backfill is completed")
+	return nil
+}
+
+func (bi *BlockchainIndexer) backfillBlock(ctx context.Context, block *Block, filter *operations.Filter) error {
+	level := block.Header.Level
+	proto, err := bi.Protocols.Get(ctx, block.Header.Protocol, level)
+	if err != nil {
+		return err
+	}
+
+	params, err := operations.NewParseParams(
+		ctx,
+		bi.Context,
+		operations.WithProtocol(&proto),
+		operations.WithHead(block.Header),
+		operations.WithRemoteMetadata(bi.metadataResolver != nil),
+		operations.WithFilter(filter),
+	)
+	if err != nil {
+		return err
+	}
+
+	indexed, err := bi.Blocks.Get(ctx, level)
+	if err != nil {
+		return err
+	}
+
+	// block is saved already and webhooks are not notified about history
+	s := store.NewStore(bi.StorageDB.DB, bi.Stats)
+	s.SetSavedBlock(&indexed)
+	for i := range block.OPG {
+		if err := operations.NewGroup(params).Parse(ctx, block.OPG[i], s); err != nil {
+			return err
+		}
+	}
+	return s.Save(ctx)
+}
+
+// originationLevel - finds level of the contract origination by binary search of the first level where the node returns its script.
+// Returns 0 if the contract doesn't exist at level `to`.
+func originationLevel(ctx context.Context, rpc noderpc.INode, address string, to int64) (int64, error) {
+	if to < 2 {
+		return 0, nil
+	}
+	if _, err := rpc.GetRawScript(ctx, address, to); err != nil {
+		if noderpc.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	low, high := int64(2), to
+	for low < high {
+		middle := low + (high-low)/2
+		_, err := rpc.GetRawScript(ctx, address, middle)
+		switch {
+		case err == nil:
+			high = middle
+		case noderpc.IsNotFound(err):
+			low = middle + 1
+		default:
+			return 0, err
+		}
+	}
+	return low, nil
+}
@@ -0,0 +1,145 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	modelsFilter "github.com/baking-bad/bcdhub/internal/models/filter"
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_contract "github.com/baking-bad/bcdhub/internal/models/mock/contract"
+	mock_filter "github.com/baking-bad/bcdhub/internal/models/mock/filter"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOriginationLevel(t *testing.T) {
+	tests := []struct {
+		name        string
+		originated  int64
+		to          int64
+		want        int64
+		wantErr     bool
+		unavailable bool
+	}{
+		{
+			name:       "originated in the middle",
+			originated: 37,
+			to:         100,
+			want:       37,
+		}, {
+			name:       "originated at the first level",
+			originated: 2,
+			to:         100,
+			want:       2,
+		}, {
+			name:       "originated at the last level",
+			originated: 100,
+			to:         100,
+			want:       100,
+		}, {
+			name:       "originated after the last level",
+			originated: 101,
+			to:         100,
+			want:       0,
+		}, {
+			name:        "node error",
+			originated:  37,
+			to:          100,
+			wantErr:     true,
+			unavailable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			rpc := noderpc.NewMockINode(ctrl)
+			rpc.EXPECT().
+				GetRawScript(gomock.Any(), "KT1", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, level int64) ([]byte, error) {
+					switch {
+					case tt.unavailable:
+						return nil, noderpc.NewNodeUnavailiableError("node", 502)
+					case level < tt.originated:
+						return nil, errors.Wrap(noderpc.ErrNotFound, "script")
+					default:
+						return []byte(`{}`), nil
+					}
+				}).
+				AnyTimes()
+
+			got, err := originationLevel(context.Background(), rpc, "KT1", tt.to)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBlockchainIndexer_diffFilter(t *testing.T) {
+	const (
+		indexed = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+		missing = "KT1BRudFZEXLYANgmZTka1xCDN5nWTMWY7SZ"
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contracts := mock_contract.NewMockRepository(ctrl)
+	contracts.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, address string) (contract.Contract, error) {
+			if address == indexed {
+				return contract.Contract{}, nil
+			}
+			return contract.Contract{}, sql.ErrNoRows
+		}).
+		AnyTimes()
+	general := mock_general.NewMockGeneralRepository(ctrl)
+	general.EXPECT().
+		IsRecordNotFound(gomock.Any()).
+		DoAndReturn(func(err error) bool { return errors.Is(err, sql.ErrNoRows) }).
+		AnyTimes()
+
+	rules := mock_filter.NewMockRepository(ctrl)
+	rules.EXPECT().
+		List(gomock.Any()).
+		Return([]modelsFilter.Rule{{ID: 1, Kind: modelsFilter.RuleKindScriptHash, Value: "old"}}, nil).
+		Times(1)
+
+	bi := &BlockchainIndexer{
+		Context: &config.Context{Contracts: contracts, Storage: general, FilterRules: rules},
+	}
+	bi.initFilter(&config.FilterConfig{
+		Addresses:    []string{indexed, missing},
+		ScriptHashes: []string{"old", "new"},
+		Tags:         []string{"fa2"},
+	})
+	require.NoError(t, bi.diffFilter(context.Background()))
+	require.NotNil(t, bi.filterChanges)
+	require.Equal(t, []string{missing}, bi.filterChanges.addresses)
+	require.Equal(t, []modelsFilter.Rule{
+		{Kind: modelsFilter.RuleKindScriptHash, Value: "new"},
+		{Kind: modelsFilter.RuleKindTag, Value: "fa2"},
+	}, bi.filterChanges.rules)
+
+	// nothing is indexed yet, so history isn't received and rules are stored
+	rules.EXPECT().
+		Replace(gomock.Any(), bi.filter.Rules()).
+		Return(nil).
+		Times(1)
+	require.NoError(t, bi.backfill(context.Background()))
+	require.Nil(t, bi.filterChanges)
+
+	// filter is removed from the config of reinitialized indexer
+	bi.initFilter(nil)
+	require.NoError(t, bi.diffFilter(context.Background()))
+	require.Nil(t, bi.filterChanges)
+}
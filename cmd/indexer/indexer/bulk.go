@@ -0,0 +1,400 @@
+package indexer
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/metrics"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers/operations"
+	"github.com/baking-bad/bcdhub/internal/postgres/store"
+	"github.com/baking-bad/bcdhub/internal/stream"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultBulkThreshold = 1000
+	defaultBulkWindow    = 2000
+	defaultBulkThreads   = 32
+	defaultBulkParsers   = 4
+	defaultBulkBatch     = 100
+)
+
+var errBulkPredecessor = errors.New("predecessor mismatch")
+
+type bulkSettings struct {
+	threshold int64
+	window    int64
+	threads   int64
+	parsers   int64
+	copy      bool
+	batch     int64
+}
+
+func newBulkSettings(cfg *config.BulkSyncConfig) *bulkSettings {
+	if cfg == nil {
+		return nil
+	}
+	settings := &bulkSettings{
+		threshold: cfg.Threshold,
+		window:    cfg.Window,
+		threads:   cfg.Threads,
+		parsers:   cfg.Parsers,
+		copy:      cfg.Copy,
+		batch:     cfg.Batch,
+	}
+	if settings.threshold <= 0 {
+		settings.threshold = defaultBulkThreshold
+	}
+	if settings.window <= 0 {
+		settings.window = defaultBulkWindow
+	}
+	if settings.threads <= 0 {
+		settings.threads = defaultBulkThreads
+	}
+	if settings.parsers <= 0 {
+		settings.parsers = defaultBulkParsers
+	}
+	if settings.batch <= 0 {
+		settings.batch = defaultBulkBatch
+	}
+	return settings
+}
+
+// parsedBlock - block with parsed data. Store is empty if the block depends on the state of previous blocks and has to be parsed after their saving.
+type parsedBlock struct {
+	block    *Block
+	store    *store.Store
+	duration time.Duration
+	err      error
+}
+
+// needBulkSync - bulk synchronization is used if it's enabled, indexer is far behind the head and there are no blocks requested by the receiver
+func (bi *BlockchainIndexer) needBulkSync(head noderpc.Header) bool {
+	return bi.bulk != nil && head.Level-bi.state.Level >= bi.bulk.threshold && bi.receiver.Idle()
+}
+
+// bulkSync - indexes blocks up to the head. The gain of the mode is parallel prefetching: blocks are received concurrently out of order
+// while previous blocks are handled. Blocks with indexed operations depend on the state of previous blocks, so they are parsed and saved
+// one by one as in head-following mode. Only blocks without such operations are parsed in parallel. All blocks are saved strictly in order.
+// If batched saving is enabled, the pending batch is saved before parsing of the dependent block and the block is added to the next batch.
+// Levels are processed by chunks of the window size, so protocol migration is applied before parsing of the next chunk.
+// Indexer returns to head-following mode after the call.
+func (bi *BlockchainIndexer) bulkSync(ctx context.Context, head noderpc.Header) error {
+	bi.mx.Lock()
+	defer bi.mx.Unlock()
+
+	log.Info().Str("network", bi.Network.String()).Int64("indexer", bi.state.Level).Int64("node", head.Level).Msg("bulk synchronization started")
+
+	for bi.state.Level < head.Level {
+		select {
+		case <-ctx.Done():
+			return errBcdQuit
+		default:
+		}
+
+		start := time.Now()
+		from := bi.state.Level + 1
+		to := min(from+bi.bulk.window-1, head.Level)
+
+		if err := bi.bulkSyncChunk(ctx, from, to); err != nil {
+			if errors.Is(err, errBulkPredecessor) {
+				log.Warn().Str("network", bi.Network.String()).Int64("block", bi.state.Level+1).Msg("bulk synchronization is stopped: chain is reorganized")
+				return nil
+			}
+			if errors.Is(err, context.Canceled) {
+				return errBcdQuit
+			}
+			return err
+		}
+
+		log.Info().
+			Str("network", bi.Network.String()).
+			Int64("from", from).
+			Int64("to", to).
+			Int64("processing_time_ms", time.Since(start).Milliseconds()).
+			Msg("bulk indexed")
+	}
+
+	bi.indicesInit.Do(func() {
+		if err := bi.createIndices(ctx); err != nil {
+			log.Err(err).Str("network", bi.Network.String()).Msg("can't create index")
+		}
+	})
+
+	log.Info().Str("network", bi.Network.String()).Int64("indexer", bi.state.Level).Msg("bulk synchronization finished")
+	return nil
+}
+
+func (bi *BlockchainIndexer) bulkSyncChunk(ctx context.Context, from, to int64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var batch *store.BulkStore
+	if bi.bulk.copy {
+		batch = store.NewBulkStore(bi.StorageDB.DB, bi.Stats, int(bi.bulk.batch))
+	}
+
+	blocks := fetchBlocks(ctx, bi.RPC, from, to, bi.bulk.window, bi.bulk.threads)
+	parsed := bi.parseBlocks(ctx, blocks, bi.currentProtocol)
+
+	level, hash := bi.state.Level, bi.state.Hash
+	for result := range parsed {
+		if result.err != nil {
+			return result.err
+		}
+
+		block := result.block
+		if level > 0 && block.Header.Predecessor != hash {
+			if err := bi.flushBulkStore(ctx, batch); err != nil {
+				return err
+			}
+			return errBulkPredecessor
+		}
+		level, hash = block.Header.Level, block.Header.Hash
+
+		if result.store == nil {
+			// block is parsed with the state of previous blocks, so they have to be saved before
+			if err := bi.flushBulkStore(ctx, batch); err != nil {
+				return err
+			}
+			if batch == nil || !bi.canBatch(block) {
+				if err := bi.handleBlock(ctx, block); err != nil {
+					return errors.Wrapf(err, "block %d", block.Header.Level)
+				}
+				continue
+			}
+
+			start := time.Now()
+			store, err := bi.newStore(ctx)
+			if err != nil {
+				return err
+			}
+			if err := bi.parseBlock(ctx, block, bi.currentProtocol, store); err != nil {
+				return errors.Wrapf(err, "block %d", block.Header.Level)
+			}
+			result.store = store
+			result.duration = time.Since(start)
+		}
+
+		if batch != nil {
+			if batch.Add(result.store) {
+				if err := bi.flushBulkStore(ctx, batch); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		start := time.Now()
+		if err := bi.saveBlock(ctx, result.store); err != nil {
+			return errors.Wrapf(err, "block %d", block.Header.Level)
+		}
+		bi.notify(ctx, stream.NewBlockMessage(bi.Network.String(), block.Header.Level))
+		metrics.BlockProcessed(bi.Network.String(), result.duration+time.Since(start))
+	}
+
+	if err := bi.flushBulkStore(ctx, batch); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// flushBulkStore - saves accumulated blocks if batched saving is enabled
+func (bi *BlockchainIndexer) flushBulkStore(ctx context.Context, batch *store.BulkStore) error {
+	if batch == nil || batch.Len() == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	saved, err := batch.Flush(ctx)
+	if err != nil {
+		return errors.Wrap(err, "batch saving")
+	}
+
+	// blocks of the batch are saved together, so saving time is shared between them
+	duration := time.Since(start) / time.Duration(len(saved))
+	for i := range saved {
+		bi.setState(*saved[i])
+		bi.notify(ctx, stream.NewBlockMessage(bi.Network.String(), saved[i].Level))
+		metrics.BlockProcessed(bi.Network.String(), duration)
+	}
+	return nil
+}
+
+// parseBlocks - parses independent blocks concurrently keeping order of the blocks in output channel. Dependent blocks are passed unparsed.
+func (bi *BlockchainIndexer) parseBlocks(ctx context.Context, blocks <-chan *Block, proto protocol.Protocol) <-chan parsedBlock {
+	futures := make(chan chan parsedBlock, bi.bulk.window)
+	output := make(chan parsedBlock)
+
+	go func() {
+		defer close(futures)
+
+		semaphore := make(chan struct{}, bi.bulk.parsers)
+		for block := range blocks {
+			result := make(chan parsedBlock, 1)
+			select {
+			case <-ctx.Done():
+				return
+			case futures <- result:
+			}
+
+			if !isIndependentBlock(block, proto) {
+				result <- parsedBlock{block: block}
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case semaphore <- struct{}{}:
+			}
+			go func(block *Block) {
+				defer func() { <-semaphore }()
+
+				start := time.Now()
+				store, err := bi.newStore(ctx)
+				if err == nil {
+					err = bi.parseBlock(ctx, block, proto, store)
+				}
+				result <- parsedBlock{block: block, store: store, duration: time.Since(start), err: err}
+			}(block)
+		}
+	}()
+
+	go func() {
+		defer close(output)
+
+		for future := range futures {
+			select {
+			case <-ctx.Done():
+				return
+			case result := <-future:
+				select {
+				case <-ctx.Done():
+					return
+				case output <- result:
+				}
+			}
+		}
+	}()
+
+	return output
+}
+
+// isIndependentBlock - checks the block can be parsed before previous blocks are saved: it doesn't change protocol, doesn't originate contracts implicitly
+// and doesn't contain operations which are parsed with the indexed state of contracts.
+func isIndependentBlock(block *Block, proto protocol.Protocol) bool {
+	if block.Header.Level <= 1 || block.Header.Protocol != proto.Hash {
+		return false
+	}
+	if block.Metadata != nil {
+		for i := range block.Metadata.ImplicitOperationsResults {
+			if block.Metadata.ImplicitOperationsResults[i].Kind == consts.Origination {
+				return false
+			}
+		}
+	}
+	for i := range block.OPG {
+		if operations.NeedParse(block.OPG[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// canBatch - checks the block which is parsed with the state of previous blocks can be saved by the batch. Protocol migration is done
+// before parsing of the block, so such blocks are handled as in head-following mode.
+func (bi *BlockchainIndexer) canBatch(block *Block) bool {
+	return block.Header.Level > 1 && block.Header.Protocol == bi.currentProtocol.Hash
+}
+
+// fetchBlocks - receives blocks of levels from `from` to `to` by `threads` concurrent requests. Blocks are sent to output channel in order of levels.
+// Not more than `window` blocks are received ahead of the block which is expected by the reader.
+func fetchBlocks(ctx context.Context, rpc noderpc.INode, from, to, window, threads int64) <-chan *Block {
+	type task struct {
+		level  int64
+		result chan *Block
+	}
+
+	tasks := make(chan task)
+	futures := make(chan chan *Block, window)
+	output := make(chan *Block)
+
+	for i := int64(0); i < threads; i++ {
+		go func() {
+			for t := range tasks {
+				block, err := fetchBlock(ctx, rpc, t.level)
+				if err != nil {
+					return
+				}
+				t.result <- block
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(tasks)
+			close(futures)
+		}()
+
+		for level := from; level <= to; level++ {
+			t := task{level: level, result: make(chan *Block, 1)}
+			select {
+			case <-ctx.Done():
+				return
+			case futures <- t.result:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case tasks <- t:
+			}
+		}
+	}()
+
+	go func() {
+		defer close(output)
+
+		for future := range futures {
+			select {
+			case <-ctx.Done():
+				return
+			case block := <-future:
+				select {
+				case <-ctx.Done():
+					return
+				case output <- block:
+				}
+			}
+		}
+	}()
+
+	return output
+}
+
+// fetchBlock - receives block retrying on failures until context is cancelled
+func fetchBlock(ctx context.Context, rpc noderpc.INode, level int64) (*Block, error) {
+	for {
+		block, err := getBlock(ctx, rpc, level)
+		if err == nil {
+			return &block, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		log.Err(err).Int64("block", level).Msg("bulk receiving")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package indexer
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var failed atomic.Bool
+	rpc := noderpc.NewMockINode(ctrl)
+	rpc.EXPECT().
+		Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, level int64) (noderpc.Block, error) {
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+			if level == 10 && failed.CompareAndSwap(false, true) {
+				return noderpc.Block{}, errors.New("node is unavailable")
+			}
+			return noderpc.Block{
+				Header:     noderpc.Header{Level: level},
+				Operations: make([][]noderpc.LightOperationGroup, 4),
+			}, nil
+		}).
+		AnyTimes()
+
+	var expected int64 = 2
+	for block := range fetchBlocks(context.Background(), rpc, 2, 100, 10, 8) {
+		require.Equal(t, expected, block.Header.Level)
+		expected++
+	}
+	require.EqualValues(t, 101, expected)
+	require.True(t, failed.Load())
+}
+
+func TestFetchBlocks_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rpc := noderpc.NewMockINode(ctrl)
+	rpc.EXPECT().
+		Block(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, level int64) (noderpc.Block, error) {
+			return noderpc.Block{
+				Header:     noderpc.Header{Level: level},
+				Operations: make([][]noderpc.LightOperationGroup, 4),
+			}, nil
+		}).
+		AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks := fetchBlocks(ctx, rpc, 2, 1_000_000, 10, 4)
+	<-blocks
+	cancel()
+
+	for range blocks {
+	}
+}
+
+func TestIsIndependentBlock(t *testing.T) {
+	proto := protocol.Protocol{Hash: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"}
+	contract := "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	implicit := "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+
+	tests := []struct {
+		name  string
+		block Block
+		want  bool
+	}{
+		{
+			name: "transfers between implicit accounts",
+			block: Block{
+				Header: noderpc.Header{Level: 100, Protocol: proto.Hash},
+				OPG: []noderpc.LightOperationGroup{
+					{Contents: []noderpc.LightOperation{{Kind: consts.Transaction, Source: implicit, Destination: &implicit}}},
+				},
+			},
+			want: true,
+		}, {
+			name: "contract call",
+			block: Block{
+				Header: noderpc.Header{Level: 100, Protocol: proto.Hash},
+				OPG: []noderpc.LightOperationGroup{
+					{Contents: []noderpc.LightOperation{{Kind: consts.Transaction, Source: implicit, Destination: &contract}}},
+				},
+			},
+			want: false,
+		}, {
+			name:  "protocol migration",
+			block: Block{Header: noderpc.Header{Level: 100, Protocol: "PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi"}},
+			want:  false,
+		}, {
+			name: "implicit origination",
+			block: Block{
+				Header: noderpc.Header{Level: 100, Protocol: proto.Hash},
+				Metadata: &noderpc.Metadata{
+					ImplicitOperationsResults: []noderpc.ImplicitOperationsResult{{Kind: consts.Origination}},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isIndependentBlock(&tt.block, proto))
+		})
+	}
+}
+
+func TestBlockchainIndexer_canBatch(t *testing.T) {
+	proto := protocol.Protocol{Hash: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"}
+	contract := "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	implicit := "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+	bi := &BlockchainIndexer{currentProtocol: proto}
+
+	// contract call produces big map diffs, so the block is parsed after saving of previous blocks, but it's saved by the batch
+	call := Block{
+		Header: noderpc.Header{Level: 100, Protocol: proto.Hash},
+		OPG: []noderpc.LightOperationGroup{
+			{Contents: []noderpc.LightOperation{{Kind: consts.Transaction, Source: implicit, Destination: &contract}}},
+		},
+	}
+	require.False(t, isIndependentBlock(&call, proto))
+	require.True(t, bi.canBatch(&call))
+
+	migration := Block{Header: noderpc.Header{Level: 100, Protocol: "PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi"}}
+	require.False(t, bi.canBatch(&migration))
+
+	genesis := Block{Header: noderpc.Header{Level: 1, Protocol: proto.Hash}}
+	require.False(t, bi.canBatch(&genesis))
+}
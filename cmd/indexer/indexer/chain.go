@@ -0,0 +1,43 @@
+package indexer
+
+const defaultChainSize = 128
+
+// hashChain - hashes of the last indexed blocks. It's used to find fork point of chain reorganization without database requests.
+type hashChain struct {
+	size   int64
+	head   int64
+	hashes map[int64]string
+}
+
+func newHashChain(size int64) *hashChain {
+	return &hashChain{
+		size:   size,
+		hashes: make(map[int64]string, size),
+	}
+}
+
+// push - adds hash of the next indexed block. Chain is restarted if the block doesn't follow the head.
+func (c *hashChain) push(level int64, hash string) {
+	if c.head != 0 && level != c.head+1 {
+		c.hashes = make(map[int64]string, c.size)
+	}
+	c.hashes[level] = hash
+	c.head = level
+	delete(c.hashes, level-c.size)
+}
+
+// get - returns hash of the indexed block at `level` if the chain contains it
+func (c *hashChain) get(level int64) (string, bool) {
+	hash, ok := c.hashes[level]
+	return hash, ok
+}
+
+// rollback - removes hashes of the blocks after `level`
+func (c *hashChain) rollback(level int64) {
+	for l := c.head; l > level; l-- {
+		delete(c.hashes, l)
+	}
+	if level < c.head {
+		c.head = level
+	}
+}
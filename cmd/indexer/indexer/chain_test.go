@@ -0,0 +1,94 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	mock_block "github.com/baking-bad/bcdhub/internal/models/mock/block"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHashChain(t *testing.T) {
+	chain := newHashChain(3)
+	for level := int64(1); level <= 5; level++ {
+		chain.push(level, fmt.Sprintf("hash_%d", level))
+	}
+
+	_, ok := chain.get(2)
+	require.False(t, ok)
+	hash, ok := chain.get(3)
+	require.True(t, ok)
+	require.Equal(t, "hash_3", hash)
+
+	chain.rollback(3)
+	_, ok = chain.get(4)
+	require.False(t, ok)
+
+	chain.push(4, "new_hash_4")
+	hash, ok = chain.get(4)
+	require.True(t, ok)
+	require.Equal(t, "new_hash_4", hash)
+
+	// not consecutive block restarts the chain
+	chain.push(10, "hash_10")
+	_, ok = chain.get(4)
+	require.False(t, ok)
+}
+
+func TestFindFork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rpc := noderpc.NewMockINode(ctrl)
+	blocks := mock_block.NewMockRepository(ctrl)
+
+	bi := &BlockchainIndexer{
+		Context: &config.Context{
+			RPC:    rpc,
+			Blocks: blocks,
+		},
+		chain: newHashChain(3),
+	}
+	for level := int64(1); level <= 10; level++ {
+		bi.setState(block.Block{Level: level, Hash: fmt.Sprintf("hash_%d", level)})
+	}
+
+	// node's chain differs from level 7
+	for level := int64(7); level <= 10; level++ {
+		rpc.EXPECT().
+			GetHeader(gomock.Any(), level).
+			Return(noderpc.Header{
+				Level:       level,
+				Hash:        fmt.Sprintf("new_hash_%d", level),
+				Predecessor: predecessor(level),
+			}, nil).
+			Times(1)
+	}
+	// levels below in-memory chain are read from database
+	for level := int64(6); level <= 7; level++ {
+		blocks.EXPECT().
+			Get(gomock.Any(), level).
+			Return(block.Block{Level: level, Hash: fmt.Sprintf("hash_%d", level)}, nil).
+			Times(1)
+	}
+
+	event, err := bi.findFork(context.Background(), 10, "new_hash_10")
+	require.NoError(t, err)
+	require.EqualValues(t, 6, event.Level)
+	require.EqualValues(t, 4, event.Depth)
+	require.EqualValues(t, 10, event.OldHead)
+	require.Equal(t, "hash_7", event.OldHash)
+	require.Equal(t, "new_hash_7", event.NewHash)
+}
+
+func predecessor(level int64) string {
+	if level-1 <= 6 {
+		return fmt.Sprintf("hash_%d", level-1)
+	}
+	return fmt.Sprintf("new_hash_%d", level-1)
+}
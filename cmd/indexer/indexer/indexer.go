@@ -8,12 +8,15 @@ import (
 
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/metrics"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/reorg"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/baking-bad/bcdhub/internal/parsers/metadata"
 	"github.com/baking-bad/bcdhub/internal/parsers/migrations"
 	"github.com/baking-bad/bcdhub/internal/parsers/operations"
 	"github.com/baking-bad/bcdhub/internal/parsers/protocols"
@@ -21,6 +24,8 @@ import (
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/baking-bad/bcdhub/internal/postgres/store"
 	"github.com/baking-bad/bcdhub/internal/rollback"
+	"github.com/baking-bad/bcdhub/internal/stream"
+	"github.com/baking-bad/bcdhub/internal/webhook"
 	"github.com/dipdup-io/workerpool"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -35,6 +40,7 @@ type BlockchainIndexer struct {
 
 	receiver        *Receiver
 	state           block.Block
+	chain           *hashChain
 	currentProtocol protocol.Protocol
 	blocks          map[int64]*Block
 
@@ -43,8 +49,19 @@ type BlockchainIndexer struct {
 
 	refreshTimer chan struct{}
 
-	isPeriodic  bool
-	indicesInit sync.Once
+	isPeriodic     bool
+	indicesInit    sync.Once
+	aggregatesInit sync.Once
+
+	metadataResolver *metadata.Resolver
+	aggregates       *aggregatesRefresher
+	webhooks         *webhook.Worker
+	bulk             *bulkSettings
+	filter           *operations.Filter
+	filterChanges    *filterChanges
+
+	// mx - guards indexer state which is changed by receiver blocks handling and bulk synchronization
+	mx sync.Mutex
 
 	g workerpool.Group
 }
@@ -68,23 +85,93 @@ func NewBlockchainIndexer(ctx context.Context, cfg config.Config, network string
 		Context:      internalCtx,
 		receiver:     NewReceiver(internalCtx.RPC, 20, indexerConfig.ReceiverThreads),
 		blocks:       make(map[int64]*Block),
+		chain:        newHashChain(defaultChainSize),
 		Network:      networkType,
 		isPeriodic:   indexerConfig.Periodic != nil,
 		refreshTimer: make(chan struct{}, 10),
 		g:            workerpool.NewGroup(),
 	}
 
+	bi.initWebhooks(indexerConfig.Webhooks)
+	bi.initMetadataResolver(indexerConfig.Metadata)
+	bi.initAggregates()
+	bi.bulk = newBulkSettings(indexerConfig.BulkSync)
+	bi.initFilter(indexerConfig.Filter)
+
 	if err := bi.init(ctx, bi.Context.StorageDB); err != nil {
 		return nil, err
 	}
+	if err := bi.diffFilter(ctx); err != nil {
+		return nil, err
+	}
 
 	return bi, nil
 }
 
+func (bi *BlockchainIndexer) initWebhooks(cfg *config.WebhooksConfig) {
+	if cfg == nil {
+		return
+	}
+	bi.webhooks = webhook.NewWorker(
+		bi.Network.String(),
+		bi.Webhooks,
+		webhook.WithMaxAttempts(cfg.MaxAttempts),
+		webhook.WithBackoff(
+			time.Duration(cfg.Backoff)*time.Second,
+			time.Duration(cfg.MaxBackoff)*time.Second,
+		),
+		webhook.WithTimeout(time.Duration(cfg.Timeout)*time.Second),
+	)
+}
+
+// initMetadataResolver - resolver uses metadata repository of the current context, so it's recreated with the context
+func (bi *BlockchainIndexer) initMetadataResolver(cfg *config.MetadataConfig) {
+	if cfg == nil || !cfg.RemoteFetch {
+		bi.metadataResolver = nil
+		return
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	bi.metadataResolver = metadata.NewResolver(bi.Network.String(), bi.Metadata, metadata.NewHTTPFetcher(timeout))
+}
+
+// initAggregates - creates refresher of aggregates on the database of the current context. Refresh scheduled on the previous database is moved to the new refresher.
+func (bi *BlockchainIndexer) initAggregates() {
+	refresher := newAggregatesRefresher(bi.Network.String(), bi.StorageDB.DB)
+	if bi.aggregates != nil {
+		bi.aggregates.moveTo(refresher)
+	}
+	bi.aggregates = refresher
+}
+
+func (bi *BlockchainIndexer) initFilter(cfg *config.FilterConfig) {
+	if cfg == nil {
+		bi.filter = nil
+		return
+	}
+	bi.filter = operations.NewFilter(*cfg, bi.Contracts, bi.Storage)
+}
+
 // Close -
 func (bi *BlockchainIndexer) Close() error {
 	bi.g.Wait()
 
+	if bi.webhooks != nil {
+		if err := bi.webhooks.Close(); err != nil {
+			return err
+		}
+	}
+	if bi.metadataResolver != nil {
+		if err := bi.metadataResolver.Close(); err != nil {
+			return err
+		}
+	}
+	if err := bi.aggregates.Close(); err != nil {
+		return err
+	}
+
 	close(bi.refreshTimer)
 	if err := bi.receiver.Close(); err != nil {
 		return nil
@@ -101,7 +188,7 @@ func (bi *BlockchainIndexer) init(ctx context.Context, db *core.Postgres) error
 	if err != nil {
 		return err
 	}
-	bi.state = currentState
+	bi.setState(currentState)
 	log.Info().Str("network", bi.Network.String()).Msgf("Current indexer state: %d", currentState.Level)
 
 	currentProtocol, err := bi.Protocols.Get(ctx, "", currentState.Level)
@@ -158,8 +245,21 @@ func (bi *BlockchainIndexer) Start(ctx context.Context) {
 	localSentry := helpers.GetLocalSentry()
 	helpers.SetLocalTagSentry(localSentry, "network", bi.Network.String())
 
+	if err := bi.backfill(ctx); err != nil {
+		log.Err(err).Str("network", bi.Network.String()).Msg("backfill")
+		helpers.LocalCatchErrorSentry(localSentry, err)
+	}
+
 	bi.g.GoCtx(ctx, bi.indexBlock)
 
+	if bi.webhooks != nil {
+		bi.webhooks.Start(ctx)
+	}
+	if bi.metadataResolver != nil {
+		bi.metadataResolver.Start(ctx)
+	}
+	bi.aggregates.Start(ctx)
+
 	bi.receiver.Start(ctx)
 
 	// First tick
@@ -225,12 +325,21 @@ func (bi *BlockchainIndexer) indexBlock(ctx context.Context) {
 			return
 
 		case newBlock := <-bi.receiver.Blocks():
+			metrics.SetReceiverQueue(bi.Network.String(), bi.receiver.QueueLength())
+			bi.mx.Lock()
 			bi.blocks[newBlock.Header.Level] = newBlock
 
+			// blocks requested before bulk synchronization may be already saved
+			for level := range bi.blocks {
+				if level <= bi.state.Level {
+					delete(bi.blocks, level)
+				}
+			}
+
 			block, ok := bi.blocks[bi.state.Level+1]
 			for ok {
 				if bi.state.Level > 0 && block.Header.Predecessor != bi.state.Hash {
-					if err := bi.Rollback(ctx); err != nil {
+					if err := bi.rollback(ctx, block.Header.Level-1, block.Header.Predecessor); err != nil {
 						log.Err(err).Msg("rollback")
 					}
 				} else {
@@ -246,6 +355,7 @@ func (bi *BlockchainIndexer) indexBlock(ctx context.Context) {
 				delete(bi.blocks, block.Header.Level)
 				block, ok = bi.blocks[bi.state.Level+1]
 			}
+			bi.mx.Unlock()
 		}
 	}
 }
@@ -262,6 +372,7 @@ func (bi *BlockchainIndexer) Index(ctx context.Context, head noderpc.Header) err
 			bi.receiver.AddTask(level)
 		}
 	}
+	metrics.SetReceiverQueue(bi.Network.String(), bi.receiver.QueueLength())
 
 	bi.indicesInit.Do(func() {
 		if err := bi.createIndices(ctx); err != nil {
@@ -272,6 +383,32 @@ func (bi *BlockchainIndexer) Index(ctx context.Context, head noderpc.Header) err
 	return nil
 }
 
+// IndexTo - synchronously indexes blocks from the current state up to `level` without the receiver. It's used to re-index rolled back blocks.
+func (bi *BlockchainIndexer) IndexTo(ctx context.Context, level int64) error {
+	bi.mx.Lock()
+	defer bi.mx.Unlock()
+
+	for bi.state.Level < level {
+		select {
+		case <-ctx.Done():
+			return errBcdQuit
+		default:
+		}
+
+		block, err := getBlock(ctx, bi.RPC, bi.state.Level+1)
+		if err != nil {
+			return errors.Wrapf(err, "receiving block %d", bi.state.Level+1)
+		}
+		if bi.state.Level > 0 && block.Header.Predecessor != bi.state.Hash {
+			return errors.Errorf("block %d doesn't follow indexed block %s", block.Header.Level, bi.state.Hash)
+		}
+		if err := bi.handleBlock(ctx, &block); err != nil {
+			return errors.Wrapf(err, "indexing block %d", block.Header.Level)
+		}
+	}
+	return nil
+}
+
 func (bi *BlockchainIndexer) handleBlock(ctx context.Context, block *Block) error {
 	start := time.Now()
 
@@ -283,9 +420,14 @@ func (bi *BlockchainIndexer) handleBlock(ctx context.Context, block *Block) erro
 		return errors.Wrap(err, "block processing")
 	}
 
+	bi.notify(ctx, stream.NewBlockMessage(bi.Network.String(), block.Header.Level))
+
+	duration := time.Since(start)
+	metrics.BlockProcessed(bi.Network.String(), duration)
+
 	log.Info().
 		Str("network", bi.Network.String()).
-		Int64("processing_time_ms", time.Since(start).Milliseconds()).
+		Int64("processing_time_ms", duration.Milliseconds()).
 		Int64("block", block.Header.Level).
 		Msg("indexed")
 
@@ -293,24 +435,46 @@ func (bi *BlockchainIndexer) handleBlock(ctx context.Context, block *Block) erro
 }
 
 func (bi *BlockchainIndexer) parseAndSaveBlock(ctx context.Context, block *Block) error {
-	store := store.NewStore(bi.StorageDB.DB, bi.Stats)
-	if err := bi.parseImplicitOperations(ctx, block, bi.currentProtocol, store); err != nil {
+	store, err := bi.newStore(ctx)
+	if err != nil {
+		return err
+	}
+	if err := bi.parseBlock(ctx, block, bi.currentProtocol, store); err != nil {
 		return err
 	}
+	return bi.saveBlock(ctx, store)
+}
 
-	if err := bi.getDataFromBlock(ctx, block, store); err != nil {
+func (bi *BlockchainIndexer) newStore(ctx context.Context) (*store.Store, error) {
+	store := store.NewStore(bi.StorageDB.DB, bi.Stats)
+	if bi.webhooks != nil {
+		hooks, err := bi.Webhooks.List(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "receiving webhooks")
+		}
+		store.SetWebhooks(hooks)
+	}
+	return store, nil
+}
+
+func (bi *BlockchainIndexer) parseBlock(ctx context.Context, block *Block, proto protocol.Protocol, store parsers.Store) error {
+	if err := bi.parseImplicitOperations(ctx, block, proto, store); err != nil {
 		return err
 	}
 
-	if err := bi.createBlock(ctx, block.Header, store); err != nil {
+	if err := bi.getDataFromBlock(ctx, block, proto, store); err != nil {
 		return err
 	}
 
+	return bi.createBlock(ctx, block.Header, proto, store)
+}
+
+func (bi *BlockchainIndexer) saveBlock(ctx context.Context, store *store.Store) error {
 	if err := store.Save(ctx); err != nil {
 		return err
 	}
 
-	bi.state = *store.Block
+	bi.setState(*store.Block)
 	return nil
 }
 
@@ -353,56 +517,136 @@ func (bi *BlockchainIndexer) migrate(ctx context.Context, head noderpc.Header) e
 	return nil
 }
 
-// Rollback -
+// Rollback - rolls back indexed blocks which are absent in the node's chain
 func (bi *BlockchainIndexer) Rollback(ctx context.Context) error {
-	log.Warn().Str("network", bi.Network.String()).Msgf("Rollback from %8d", bi.state.Level)
-
-	lastLevel, err := bi.getLastRollbackBlock(ctx)
+	header, err := bi.RPC.GetHeader(ctx, bi.state.Level)
 	if err != nil {
 		return err
 	}
+	return bi.rollback(ctx, header.Level, header.Hash)
+}
+
+// rollback - rolls back indexed blocks to the fork point with the node's chain in one step and records reorganization event.
+// `hash` is the hash of the node's block at `level` which is compared with the indexed one first.
+func (bi *BlockchainIndexer) rollback(ctx context.Context, level int64, hash string) error {
+	event, err := bi.findFork(ctx, level, hash)
+	if err != nil {
+		return errors.Wrap(err, "fork point searching")
+	}
+	if event.Depth <= 0 {
+		return nil
+	}
+
+	log.Warn().
+		Str("network", bi.Network.String()).
+		Int64("from", bi.state.Level).
+		Int64("fork", event.Level).
+		Int64("depth", event.Depth).
+		Str("old_hash", event.OldHash).
+		Str("new_hash", event.NewHash).
+		Msg("rollback")
 
 	saver, err := postgres.NewRollback(bi.StorageDB.DB)
 	if err != nil {
 		return err
 	}
-	manager := rollback.NewManager(bi.Storage, bi.Blocks, saver, bi.Stats)
-	if err := manager.Rollback(ctx, bi.Network, bi.state, lastLevel); err != nil {
+	manager := rollback.NewManager(bi.Storage, bi.Blocks, saver, bi.Stats, rollback.WithRefreshScheduler(bi.aggregates.Schedule))
+	if err := manager.Rollback(ctx, bi.Network, bi.state, event.Level); err != nil {
 		return err
 	}
+	if bi.filter != nil {
+		// contracts originated in the rolled back blocks are not indexed anymore
+		bi.filter.Reset()
+	}
+	if cached, ok := bi.RPC.(*noderpc.CachedNode); ok {
+		if err := cached.Rollback(event.Level); err != nil {
+			return err
+		}
+	}
 
 	newState, err := bi.Blocks.Last(ctx)
 	if err != nil {
 		return err
 	}
 	bi.state = newState
+	bi.chain.rollback(newState.Level)
+	metrics.SetIndexedLevel(bi.Network.String(), newState.Level)
+	metrics.Rollback(bi.Network.String(), event.Depth)
 	log.Info().Str("network", bi.Network.String()).Msgf("New indexer state: %8d", bi.state.Level)
 	log.Info().Str("network", bi.Network.String()).Msg("Rollback finished")
+
+	// reorganization history is used for monitoring only, so indexing isn't stopped if it's not saved
+	event.DetectedAt = time.Now().UTC()
+	if err := bi.Reorgs.Save(ctx, &event); err != nil {
+		log.Err(err).Str("network", bi.Network.String()).Msg("saving reorganization event")
+	}
+
+	bi.notify(ctx, stream.NewRollbackMessage(bi.Network.String(), bi.state.Level))
 	return nil
 }
 
-func (bi *BlockchainIndexer) getLastRollbackBlock(ctx context.Context) (int64, error) {
-	var lastLevel int64
-	level := bi.state.Level
+// notify - sends notification to stream subscribers. Indexing must not be stopped by notification failure, so error is only logged.
+func (bi *BlockchainIndexer) notify(ctx context.Context, msg stream.Message) {
+	if err := stream.Notify(ctx, bi.StorageDB.DB, msg); err != nil {
+		log.Err(err).Str("network", bi.Network.String()).Str("type", string(msg.Type)).Msg("stream notification")
+	}
+}
 
-	for end := false; !end; level-- {
-		headAtLevel, err := bi.RPC.GetHeader(ctx, level)
+// findFork - walks back the node's chain by predecessors from the block `hash` at `level` until the block which is equal to the indexed one.
+// Indexed hashes are taken from the in-memory chain of recent blocks, database is requested only if reorganization is deeper.
+func (bi *BlockchainIndexer) findFork(ctx context.Context, level int64, hash string) (reorg.Reorg, error) {
+	event := reorg.Reorg{
+		OldHead: bi.state.Level,
+	}
+
+	for ; level > 0; level-- {
+		indexed, err := bi.indexedHash(ctx, level)
 		if err != nil {
-			return 0, err
+			return event, err
+		}
+		if indexed == hash {
+			break
+		}
+		if indexed != "" {
+			event.OldHash = indexed
+			event.NewHash = hash
 		}
 
-		block, err := bi.Blocks.Get(ctx, level-1)
+		header, err := bi.RPC.GetHeader(ctx, level)
 		if err != nil {
-			return 0, err
+			return event, err
 		}
+		hash = header.Predecessor
+	}
 
-		if block.Hash == headAtLevel.Predecessor {
-			log.Warn().Str("network", bi.Network.String()).Msgf("Found equal predecessors at level: %7d", block.Level)
-			end = true
-			lastLevel = block.Level
+	event.Level = level
+	event.Depth = bi.state.Level - level
+	return event, nil
+}
+
+// indexedHash - returns hash of the indexed block at `level` or empty string if the block isn't indexed
+func (bi *BlockchainIndexer) indexedHash(ctx context.Context, level int64) (string, error) {
+	if level > bi.state.Level {
+		return "", nil
+	}
+	if hash, ok := bi.chain.get(level); ok {
+		return hash, nil
+	}
+	b, err := bi.Blocks.Get(ctx, level)
+	if err != nil {
+		if bi.Storage.IsRecordNotFound(err) {
+			return "", nil
 		}
+		return "", err
 	}
-	return lastLevel, nil
+	return b.Hash, nil
+}
+
+// setState - sets the last indexed block
+func (bi *BlockchainIndexer) setState(state block.Block) {
+	bi.state = state
+	bi.chain.push(state.Level, state.Hash)
+	metrics.SetIndexedLevel(bi.Network.String(), state.Level)
 }
 
 func (bi *BlockchainIndexer) process(ctx context.Context) error {
@@ -416,8 +660,17 @@ func (bi *BlockchainIndexer) process(ctx context.Context) error {
 	}
 
 	log.Info().Str("network", bi.Network.String()).Int64("node", head.Level).Int64("indexer", bi.state.Level).Msg("current state")
+	metrics.SetHeadLevel(bi.Network.String(), head.Level)
 
 	switch {
+	case bi.needBulkSync(head):
+		if err := bi.bulkSync(ctx, head); err != nil {
+			if errors.Is(err, errBcdQuit) {
+				return nil
+			}
+			return err
+		}
+		return nil
 	case head.Level > bi.state.Level:
 		if err := bi.Index(ctx, head); err != nil {
 			if errors.Is(err, errBcdQuit) {
@@ -429,15 +682,19 @@ func (bi *BlockchainIndexer) process(ctx context.Context) error {
 		log.Info().Str("network", bi.Network.String()).Msg("Synced")
 		return nil
 	case head.Level < bi.state.Level:
-		return bi.Rollback(ctx)
+		return bi.rollback(ctx, head.Level, head.Hash)
 	default:
+		// history indexed by synchronization isn't covered by refresh policies of aggregates
+		bi.aggregatesInit.Do(func() {
+			bi.aggregates.Schedule(time.Time{})
+		})
 		return errSameLevel
 	}
 }
-func (bi *BlockchainIndexer) createBlock(ctx context.Context, head noderpc.Header, store parsers.Store) error {
+func (bi *BlockchainIndexer) createBlock(ctx context.Context, head noderpc.Header, proto protocol.Protocol, store parsers.Store) error {
 	newBlock := block.Block{
 		Hash:       head.Hash,
-		ProtocolID: bi.currentProtocol.ID,
+		ProtocolID: proto.ID,
 		Level:      head.Level,
 		Timestamp:  head.Timestamp,
 	}
@@ -445,15 +702,17 @@ func (bi *BlockchainIndexer) createBlock(ctx context.Context, head noderpc.Heade
 	return nil
 }
 
-func (bi *BlockchainIndexer) getDataFromBlock(ctx context.Context, block *Block, store parsers.Store) error {
+func (bi *BlockchainIndexer) getDataFromBlock(ctx context.Context, block *Block, proto protocol.Protocol, store parsers.Store) error {
 	if block.Header.Level <= 1 {
 		return nil
 	}
 	parserParams, err := operations.NewParseParams(
 		ctx,
 		bi.Context,
-		operations.WithProtocol(&bi.currentProtocol),
+		operations.WithProtocol(&proto),
 		operations.WithHead(block.Header),
+		operations.WithRemoteMetadata(bi.metadataResolver != nil),
+		operations.WithFilter(bi.filter),
 	)
 	if err != nil {
 		return err
@@ -494,7 +753,15 @@ func (bi *BlockchainIndexer) reinit(ctx context.Context, cfg config.Config, inde
 	)
 	log.Info().Str("network", bi.Context.Network.String()).Msg("Creating indexer object...")
 	bi.receiver = NewReceiver(bi.Context.RPC, 20, indexerConfig.ReceiverThreads)
+	bi.initWebhooks(indexerConfig.Webhooks)
+	bi.initMetadataResolver(indexerConfig.Metadata)
+	bi.initAggregates()
+	bi.bulk = newBulkSettings(indexerConfig.BulkSync)
+	bi.initFilter(indexerConfig.Filter)
 
 	bi.refreshTimer = make(chan struct{}, 10)
-	return bi.init(ctx, bi.Context.StorageDB)
+	if err := bi.init(ctx, bi.Context.StorageDB); err != nil {
+		return err
+	}
+	return bi.diffFilter(ctx)
 }
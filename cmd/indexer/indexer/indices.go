@@ -8,7 +8,11 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/rs/zerolog/log"
 )
 
@@ -95,6 +99,9 @@ func (bi *BlockchainIndexer) createIndices(ctx context.Context) error {
 	if err := bi.Storage.CreateIndex(ctx, "operations_source_timestamp_idx", "source_id, timestamp", operation); err != nil {
 		return err
 	}
+	if err := bi.Storage.CreateIndex(ctx, "operations_timestamp_id_idx", "timestamp, id", operation); err != nil {
+		return err
+	}
 
 	// Scripts to global constants
 	scriptConstants := (*contract.ScriptConstants)(nil)
@@ -129,6 +136,54 @@ func (bi *BlockchainIndexer) createIndices(ctx context.Context) error {
 		return err
 	}
 
+	// Token transfers
+	transfer := (*token.Transfer)(nil)
+	if err := bi.Storage.CreateIndex(ctx, "token_transfers_level_idx", "level", transfer); err != nil {
+		return err
+	}
+	if err := bi.Storage.CreateIndex(ctx, "token_transfers_operation_id_idx", "operation_id", transfer); err != nil {
+		return err
+	}
+	if err := bi.Storage.CreateIndex(ctx, "token_transfers_contract_idx", "contract, token_id", transfer); err != nil {
+		return err
+	}
+	if err := bi.Storage.CreateIndex(ctx, "token_transfers_from_idx", `"from"`, transfer); err != nil {
+		return err
+	}
+	if err := bi.Storage.CreateIndex(ctx, "token_transfers_to_idx", `"to"`, transfer); err != nil {
+		return err
+	}
+
+	// Smart rollup entities
+	for name, model := range map[string]any{
+		"smart_rollup_commitments":         (*smartrollup.Commitment)(nil),
+		"smart_rollup_game_moves":          (*smartrollup.GameMove)(nil),
+		"smart_rollup_bond_recoveries":     (*smartrollup.BondRecovery)(nil),
+		"smart_rollup_messages":            (*smartrollup.Message)(nil),
+		"smart_rollup_outbox_transactions": (*smartrollup.OutboxTransaction)(nil),
+	} {
+		if err := bi.Storage.CreateIndex(ctx, name+"_level_idx", "level", model); err != nil {
+			return err
+		}
+		if err := bi.Storage.CreateIndex(ctx, name+"_rollup_idx", "rollup", model); err != nil {
+			return err
+		}
+	}
+
+	// Webhook deliveries
+	delivery := (*webhook.Delivery)(nil)
+	if err := bi.Storage.CreateIndex(ctx, "webhook_deliveries_level_idx", "level", delivery); err != nil {
+		return err
+	}
+	if err := bi.Storage.CreateIndex(ctx, "webhook_deliveries_next_attempt_idx", "delivered, next_attempt_at", delivery); err != nil {
+		return err
+	}
+
+	// Search entries
+	if err := bi.Storage.CreateIndex(ctx, "search_entries_level_idx", "level", (*search.Entry)(nil)); err != nil {
+		return err
+	}
+
 	log.Info().Str("network", bi.Network.String()).Msg("database indices was created")
 
 	return nil
@@ -70,9 +70,23 @@ func (r *Receiver) Blocks() <-chan *Block {
 	return r.blocks
 }
 
+// Idle - returns true if receiver has no requested blocks which are not handled yet
+func (r *Receiver) Idle() bool {
+	return r.inProcess.Len() == 0 && len(r.blocks) == 0
+}
+
+// QueueLength - returns count of requested blocks which are not received yet or not read from `Blocks` channel
+func (r *Receiver) QueueLength() int {
+	return r.inProcess.Len() + len(r.blocks)
+}
+
 func (r *Receiver) get(ctx context.Context, level int64) (Block, error) {
+	return getBlock(ctx, r.rpc, level)
+}
+
+func getBlock(ctx context.Context, rpc noderpc.INode, level int64) (Block, error) {
 	var block Block
-	header, err := r.rpc.Block(ctx, level)
+	header, err := rpc.Block(ctx, level)
 	if err != nil {
 		return block, err
 	}
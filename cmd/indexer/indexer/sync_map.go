@@ -45,3 +45,10 @@ func (m Map[K, V]) Delete(key K) {
 	delete(m.m, key)
 	m.mx.Unlock()
 }
+
+// Len -
+func (m Map[K, V]) Len() int {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return len(m.m)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/metrics"
 	"github.com/baking-bad/bcdhub/internal/profiler"
 	"github.com/dipdup-io/workerpool"
 	"github.com/grafana/pyroscope-go"
@@ -42,6 +43,12 @@ func main() {
 		}
 	}
 
+	var metricsServer *metrics.Server
+	if cfg.Metrics != nil && cfg.Metrics.Bind != "" {
+		metricsServer = metrics.NewServer(cfg.Metrics.Bind)
+		metricsServer.Start()
+	}
+
 	g := workerpool.NewGroup()
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -66,6 +73,12 @@ func main() {
 		}
 	}
 
+	if metricsServer != nil {
+		if err := metricsServer.Close(); err != nil {
+			log.Err(err).Msg("closing metrics server")
+		}
+	}
+
 	if prof != nil {
 		if err := prof.Stop(); err != nil {
 			panic(err)
@@ -13,7 +13,22 @@ const (
 	TransferTicket         = "transfer_ticket"
 	SrOriginate            = "smart_rollup_originate"
 	SrExecuteOutboxMessage = "smart_rollup_execute_outbox_message"
+	SrAddMessages          = "smart_rollup_add_messages"
+	SrCement               = "smart_rollup_cement"
+	SrPublish              = "smart_rollup_publish"
+	SrRefute               = "smart_rollup_refute"
+	SrTimeout              = "smart_rollup_timeout"
+	SrRecoverBond          = "smart_rollup_recover_bond"
 	Delegation             = "delegation"
+	IncreasePaidStorage    = "increase_paid_storage"
+)
+
+// Staking pseudo-operations. They are transactions from implicit account to itself with special entrypoints.
+const (
+	Stake                 = "stake"
+	Unstake               = "unstake"
+	FinalizeUnstake       = "finalize_unstake"
+	SetDelegateParameters = "set_delegate_parameters"
 )
 
 // Error IDs
@@ -0,0 +1,5 @@
+package trees
+
+import "github.com/baking-bad/bcdhub/internal/bcd/ast"
+
+var TokenMetadata, _ = ast.NewTypedAstFromString(`{"prim":"big_map","args":[{"prim":"nat"},{"prim":"pair","args":[{"prim":"nat"},{"prim":"map","args":[{"prim":"string"},{"prim":"bytes"}]}]}]}`)
@@ -0,0 +1,275 @@
+package rollup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/pkg/errors"
+)
+
+// Outbox message kinds
+const (
+	KindUntyped         = "untyped"
+	KindTyped           = "typed"
+	KindWhitelistUpdate = "whitelist_update"
+)
+
+const (
+	tagUntypedBatch    = 0x00
+	tagTypedBatch      = 0x01
+	tagWhitelistUpdate = 0x02
+
+	contractHashSize       = 20
+	publicKeyHashSize      = 21
+	stateHashSize          = 32
+	contextProofHeaderSize = 35
+	maxMessageIndexLength  = 9
+)
+
+// errors
+var (
+	ErrOutputNotFound = errors.New("output is not found in the proof")
+	ErrInvalidMessage = errors.New("invalid outbox message")
+)
+
+// Transaction - transaction of the outbox message batch. Parameters and parameters type are Micheline JSON.
+// Parameters type is set for typed batches only.
+type Transaction struct {
+	Destination    string
+	Entrypoint     string
+	Parameters     []byte
+	ParametersType []byte
+}
+
+// Output - outbox message executed on L1 with its position in the rollup's outbox
+type Output struct {
+	OutboxLevel  int64
+	MessageIndex int64
+	Kind         string
+	Transactions []Transaction
+	Whitelist    []string
+}
+
+// DecodeOutput - decodes output from the output proof of `smart_rollup_execute_outbox_message`. The proof is a concatenation
+// of PVM proof, state hash and output: outbox level (int32), message index (zarith natural) and the message.
+// PVM proof's encoding depends on the PVM kind, so the output is placed by the state hash: L1 accepts the proof only if its state is the compressed state
+// of the cemented commitment. The output has to follow the state hash and occupy the rest of the proof. Outbox level has to be positive and not greater than `maxLevel`.
+func DecodeOutput(proof, state []byte, maxLevel int64) (Output, error) {
+	if len(state) != stateHashSize {
+		return Output{}, ErrOutputNotFound
+	}
+	for end := len(proof); end > 0; {
+		start := bytes.LastIndex(proof[:end], state)
+		if start < 0 {
+			break
+		}
+		if output, err := decodeOutput(proof[start+stateHashSize:], maxLevel); err == nil {
+			return output, nil
+		}
+		end = start + stateHashSize - 1
+	}
+	return Output{}, ErrOutputNotFound
+}
+
+// ProofState - returns state hash of the context proof which is the PVM proof of `wasm_2_0_0` rollups.
+// The context proof starts with version (int16) and the kinded hash of the state before the proof: 1-byte tag and 32-byte hash.
+func ProofState(proof []byte) ([]byte, bool) {
+	if len(proof) < contextProofHeaderSize || proof[2] > 0x01 {
+		return nil, false
+	}
+	return proof[3:contextProofHeaderSize], true
+}
+
+// decodeOutput - decodes outbox level, message index and the message. The output has to occupy the whole `data`.
+func decodeOutput(data []byte, maxLevel int64) (Output, error) {
+	if len(data) < 4 {
+		return Output{}, ErrInvalidMessage
+	}
+	level := int64(int32(binary.BigEndian.Uint32(data[:4])))
+	if level <= 0 || level > maxLevel {
+		return Output{}, ErrInvalidMessage
+	}
+
+	var (
+		index int64
+		size  int
+	)
+	for size < maxMessageIndexLength {
+		if 4+size >= len(data) {
+			return Output{}, ErrInvalidMessage
+		}
+		b := data[4+size]
+		index |= int64(b&0x7f) << (7 * size)
+		size++
+		if b < 0x80 {
+			break
+		}
+	}
+	if data[3+size] >= 0x80 {
+		return Output{}, ErrInvalidMessage
+	}
+
+	output, err := decodeMessage(data[4+size:])
+	if err != nil {
+		return Output{}, err
+	}
+	output.OutboxLevel = level
+	output.MessageIndex = index
+	return output, nil
+}
+
+// decodeMessage - decodes outbox message. The message has to occupy the whole `data`.
+func decodeMessage(data []byte) (Output, error) {
+	if len(data) == 0 {
+		return Output{}, ErrInvalidMessage
+	}
+
+	switch data[0] {
+	case tagUntypedBatch, tagTypedBatch:
+		output := Output{Kind: KindUntyped}
+		if data[0] == tagTypedBatch {
+			output.Kind = KindTyped
+		}
+		batch, err := dynamic(data[1:])
+		if err != nil || len(batch) != len(data)-5 {
+			return Output{}, ErrInvalidMessage
+		}
+		for len(batch) > 0 {
+			tx, n, err := decodeTransaction(batch, output.Kind == KindTyped)
+			if err != nil {
+				return Output{}, err
+			}
+			output.Transactions = append(output.Transactions, tx)
+			batch = batch[n:]
+		}
+		if len(output.Transactions) == 0 {
+			return Output{}, ErrInvalidMessage
+		}
+		return output, nil
+	case tagWhitelistUpdate:
+		whitelist, err := decodeWhitelist(data[1:])
+		if err != nil {
+			return Output{}, err
+		}
+		return Output{
+			Kind:      KindWhitelistUpdate,
+			Whitelist: whitelist,
+		}, nil
+	default:
+		return Output{}, ErrInvalidMessage
+	}
+}
+
+func decodeTransaction(data []byte, typed bool) (tx Transaction, offset int, err error) {
+	var n int
+	tx.Parameters, n, err = decodeExpr(data)
+	if err != nil {
+		return
+	}
+	offset += n
+
+	if typed {
+		tx.ParametersType, n, err = decodeExpr(data[offset:])
+		if err != nil {
+			return
+		}
+		offset += n
+	}
+
+	if len(data[offset:]) < contractHashSize {
+		return tx, offset, ErrInvalidMessage
+	}
+	tx.Destination, err = encoding.EncodeBase58(data[offset:offset+contractHashSize], []byte(encoding.PrefixPublicKeyKT1))
+	if err != nil {
+		return
+	}
+	offset += contractHashSize
+
+	entrypoint, n, err := decodeEntrypoint(data[offset:])
+	if err != nil {
+		return
+	}
+	tx.Entrypoint = entrypoint
+	offset += n
+	return
+}
+
+func decodeExpr(data []byte) ([]byte, int, error) {
+	unforger := forge.NewMichelson()
+	n, err := unforger.Unforge(data)
+	if err != nil {
+		return nil, 0, errors.Wrap(ErrInvalidMessage, err.Error())
+	}
+	if len(unforger.Nodes) != 1 || n > len(data) {
+		return nil, 0, ErrInvalidMessage
+	}
+	expr, err := unforger.Nodes[0].MarshalJSON()
+	if err != nil {
+		return nil, 0, err
+	}
+	return expr, n, nil
+}
+
+// decodeEntrypoint - entrypoint is limited by 31 symbols, so L1 prefixes it with 1-byte length while kernel SDKs write 4-byte length.
+// Entrypoint can't be empty, so zero first byte means 4-byte length.
+func decodeEntrypoint(data []byte) (string, int, error) {
+	if len(data) == 0 {
+		return "", 0, ErrInvalidMessage
+	}
+	if data[0] != 0 {
+		size := int(data[0])
+		if len(data) < size+1 {
+			return "", 0, ErrInvalidMessage
+		}
+		return string(data[1 : size+1]), size + 1, nil
+	}
+	value, err := dynamic(data)
+	if err != nil || len(value) == 0 {
+		return "", 0, ErrInvalidMessage
+	}
+	return string(value), len(value) + 4, nil
+}
+
+func decodeWhitelist(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidMessage
+	}
+	switch data[0] {
+	case 0x00:
+		if len(data) != 1 {
+			return nil, ErrInvalidMessage
+		}
+		return nil, nil
+	case 0xff:
+		list, err := dynamic(data[1:])
+		if err != nil || len(list) != len(data)-5 || len(list)%publicKeyHashSize != 0 {
+			return nil, ErrInvalidMessage
+		}
+		whitelist := make([]string, 0, len(list)/publicKeyHashSize)
+		for i := 0; i < len(list); i += publicKeyHashSize {
+			address, err := forge.UnforgeAddress(hex.EncodeToString(list[i : i+publicKeyHashSize]))
+			if err != nil {
+				return nil, errors.Wrap(ErrInvalidMessage, err.Error())
+			}
+			whitelist = append(whitelist, address)
+		}
+		return whitelist, nil
+	default:
+		return nil, ErrInvalidMessage
+	}
+}
+
+// dynamic - returns value prefixed by 4-byte length
+func dynamic(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, ErrInvalidMessage
+	}
+	size := int(binary.BigEndian.Uint32(data[:4]))
+	if size > len(data)-4 {
+		return nil, ErrInvalidMessage
+	}
+	return data[4 : 4+size], nil
+}
@@ -0,0 +1,153 @@
+package rollup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDestination = "KT1BRudFZEXLYANgmZTka1xCDN5nWTMWY7SZ"
+	testLevel       = 1234567
+)
+
+var testState = bytes.Repeat([]byte{0xaa}, 32)
+
+// testProof - context proof's header with states before and after the proof, its tree, state hash and output
+func testProof(t *testing.T, index []byte, message []byte) []byte {
+	proof := []byte{0x00, 0x02, 0x01}
+	proof = append(proof, testState...)
+	proof = append(proof, 0x01)
+	proof = append(proof, bytes.Repeat([]byte{0xbb}, 32)...)
+	proof = append(proof, bytes.Repeat([]byte{0x81}, 100)...)
+	proof = append(proof, testState...)
+	proof = binary.BigEndian.AppendUint32(proof, testLevel)
+	proof = append(proof, index...)
+	return append(proof, message...)
+}
+
+func testBatch(t *testing.T, tag byte, entrypoint []byte, typed bool) []byte {
+	destination, err := encoding.DecodeBase58(testDestination)
+	require.NoError(t, err)
+
+	// Pair "hello" 5
+	tx := []byte{0x07, 0x07, 0x01, 0x00, 0x00, 0x00, 0x05, 'h', 'e', 'l', 'l', 'o', 0x00, 0x05}
+	if typed {
+		// pair string nat
+		tx = append(tx, 0x07, 0x65, 0x03, 0x68, 0x03, 0x62)
+	}
+	tx = append(tx, destination...)
+	tx = append(tx, entrypoint...)
+
+	batch := append(tx, tx...)
+	message := []byte{tag}
+	message = binary.BigEndian.AppendUint32(message, uint32(len(batch)))
+	return append(message, batch...)
+}
+
+func TestDecodeOutput(t *testing.T) {
+	// whitelist with the address which ends with bytes of another output: level 256, index 5 and empty whitelist update
+	whitelist := []byte{tagWhitelistUpdate, 0xff, 0x00, 0x00, 0x00, 0x15, 0x00}
+	whitelist = append(whitelist, bytes.Repeat([]byte{0x11}, 13)...)
+	whitelist = append(whitelist, 0x00, 0x00, 0x01, 0x00, 0x05, tagWhitelistUpdate, 0x00)
+
+	tests := []struct {
+		name    string
+		proof   []byte
+		state   []byte
+		want    Output
+		wantErr bool
+	}{
+		{
+			name:  "untyped batch",
+			proof: testProof(t, []byte{0x03}, testBatch(t, tagUntypedBatch, []byte{0x04, 'm', 'i', 'n', 't'}, false)),
+			want: Output{
+				OutboxLevel:  testLevel,
+				MessageIndex: 3,
+				Kind:         KindUntyped,
+				Transactions: []Transaction{
+					{
+						Destination: testDestination,
+						Entrypoint:  "mint",
+						Parameters:  []byte(`{"prim":"Pair","args":[{"string":"hello"},{"int":"5"}]}`),
+					}, {
+						Destination: testDestination,
+						Entrypoint:  "mint",
+						Parameters:  []byte(`{"prim":"Pair","args":[{"string":"hello"},{"int":"5"}]}`),
+					},
+				},
+			},
+		}, {
+			name:  "typed batch with long index and 4-byte entrypoint length",
+			proof: testProof(t, []byte{0x96, 0x01}, testBatch(t, tagTypedBatch, []byte{0x00, 0x00, 0x00, 0x04, 'b', 'u', 'r', 'n'}, true)),
+			want: Output{
+				OutboxLevel:  testLevel,
+				MessageIndex: 150,
+				Kind:         KindTyped,
+				Transactions: []Transaction{
+					{
+						Destination:    testDestination,
+						Entrypoint:     "burn",
+						Parameters:     []byte(`{"prim":"Pair","args":[{"string":"hello"},{"int":"5"}]}`),
+						ParametersType: []byte(`{"prim":"pair","args":[{"prim":"string"},{"prim":"nat"}]}`),
+					}, {
+						Destination:    testDestination,
+						Entrypoint:     "burn",
+						Parameters:     []byte(`{"prim":"Pair","args":[{"string":"hello"},{"int":"5"}]}`),
+						ParametersType: []byte(`{"prim":"pair","args":[{"prim":"string"},{"prim":"nat"}]}`),
+					},
+				},
+			},
+		}, {
+			name:  "whitelist update",
+			proof: testProof(t, []byte{0x00}, []byte{tagWhitelistUpdate, 0x00}),
+			want: Output{
+				OutboxLevel: testLevel,
+				Kind:        KindWhitelistUpdate,
+			},
+		}, {
+			name:  "whitelist update containing tail of another output",
+			proof: testProof(t, []byte{0x07}, whitelist),
+			want: Output{
+				OutboxLevel:  testLevel,
+				MessageIndex: 7,
+				Kind:         KindWhitelistUpdate,
+				Whitelist:    []string{"tz1MCGdC9qYbSjtWEbup9dmeizdhGDCf7Cxj"},
+			},
+		}, {
+			name:    "unknown state",
+			proof:   testProof(t, []byte{0x00}, []byte{tagWhitelistUpdate, 0x00}),
+			state:   bytes.Repeat([]byte{0xcc}, 32),
+			wantErr: true,
+		}, {
+			name:    "truncated message",
+			proof:   testProof(t, []byte{0x00}, testBatch(t, tagUntypedBatch, []byte{0x04, 'm', 'i', 'n', 't'}, false))[:180],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.state
+			if state == nil {
+				state = testState
+			}
+			got, err := DecodeOutput(tt.proof, state, 2_000_000)
+			require.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProofState(t *testing.T) {
+	state, ok := ProofState(testProof(t, []byte{0x00}, []byte{tagWhitelistUpdate, 0x00}))
+	require.True(t, ok)
+	require.Equal(t, testState, state)
+
+	_, ok = ProofState([]byte{0x00, 0x02, 0x01})
+	require.False(t, ok)
+}
@@ -28,6 +28,7 @@ type Config struct {
 	SharePath string                   `yaml:"share_path"`
 	BaseURL   string                   `yaml:"base_url"`
 	Profiler  *Profiler                `yaml:"profiler"`
+	Metrics   *MetricsConfig           `yaml:"metrics"`
 	LogLevel  string                   `yaml:"log_level"`
 
 	API APIConfig `yaml:"api"`
@@ -48,19 +49,63 @@ type Profiler struct {
 	Server string `yaml:"server"`
 }
 
+// MetricsConfig - settings of Prometheus metrics. API exposes `/metrics` on its own address, indexer starts server on `bind`.
+type MetricsConfig struct {
+	Bind string `yaml:"bind"`
+}
+
 // IndexerConfig -
 type IndexerConfig struct {
 	ReceiverThreads int64            `yaml:"receiver_threads"`
 	Periodic        *periodic.Config `yaml:"periodic"`
+	Metadata        *MetadataConfig  `yaml:"metadata"`
+	Webhooks        *WebhooksConfig  `yaml:"webhooks"`
+	BulkSync        *BulkSyncConfig  `yaml:"bulk_sync"`
+	Filter          *FilterConfig    `yaml:"filter"`
+}
+
+// MetadataConfig - settings of TZIP-16 contract metadata resolving
+type MetadataConfig struct {
+	RemoteFetch bool `yaml:"remote_fetch"`
+	Timeout     int  `yaml:"timeout"`
+}
+
+// WebhooksConfig - settings of webhook delivery. Durations are in seconds.
+type WebhooksConfig struct {
+	MaxAttempts int `yaml:"max_attempts"`
+	Backoff     int `yaml:"backoff"`
+	MaxBackoff  int `yaml:"max_backoff"`
+	Timeout     int `yaml:"timeout"`
+}
+
+// BulkSyncConfig - settings of bulk synchronization which is used while indexer is far behind the head.
+// Threshold is lag in levels to switch to bulk mode, window is count of blocks received ahead of the last saved one.
+// If copy is set, blocks are saved by batches of `batch` blocks through `COPY FROM` instead of one transaction per block.
+type BulkSyncConfig struct {
+	Threshold int64 `yaml:"threshold"`
+	Window    int64 `yaml:"window"`
+	Threads   int64 `yaml:"threads"`
+	Parsers   int64 `yaml:"parsers"`
+	Copy      bool  `yaml:"copy"`
+	Batch     int64 `yaml:"batch"`
+}
+
+// FilterConfig - settings of selective indexing. Operations are indexed only if they touch contracts from `addresses`,
+// contracts originated with code hash from `script_hashes` or with one of `tags`, or contracts originated by them.
+type FilterConfig struct {
+	Addresses    []string `yaml:"addresses"`
+	ScriptHashes []string `yaml:"script_hashes"`
+	Tags         []string `yaml:"tags"`
 }
 
 // RPCConfig -
 type RPCConfig struct {
-	URI               string `yaml:"uri"`
-	Timeout           int    `yaml:"timeout"`
-	Cache             string `yaml:"cache"`
-	RequestsPerSecond int    `yaml:"requests_per_second"`
-	Log               bool   `yaml:"log"`
+	URI               string   `yaml:"uri"`
+	URIs              []string `yaml:"uris"`
+	Timeout           int      `yaml:"timeout"`
+	Cache             string   `yaml:"cache"`
+	RequestsPerSecond int      `yaml:"requests_per_second"`
+	Log               bool     `yaml:"log"`
 }
 
 // TzKTConfig -
@@ -151,16 +196,29 @@ type SeedConfig struct {
 
 // APIConfig -
 type APIConfig struct {
-	ProjectName   string           `yaml:"project_name"`
-	Bind          string           `yaml:"bind"`
-	CorsEnabled   bool             `yaml:"cors_enabled"`
-	SentryEnabled bool             `yaml:"sentry_enabled"`
-	SeedEnabled   bool             `yaml:"seed_enabled"`
-	Frontend      FrontendConfig   `yaml:"frontend"`
-	Seed          SeedConfig       `yaml:"seed"`
-	Networks      []string         `yaml:"networks"`
-	PageSize      uint64           `yaml:"page_size"`
-	Periodic      *periodic.Config `yaml:"periodic"`
+	ProjectName   string            `yaml:"project_name"`
+	Bind          string            `yaml:"bind"`
+	CorsEnabled   bool              `yaml:"cors_enabled"`
+	SentryEnabled bool              `yaml:"sentry_enabled"`
+	SeedEnabled   bool              `yaml:"seed_enabled"`
+	Frontend      FrontendConfig    `yaml:"frontend"`
+	Seed          SeedConfig        `yaml:"seed"`
+	Networks      []string          `yaml:"networks"`
+	PageSize      uint64            `yaml:"page_size"`
+	Periodic      *periodic.Config  `yaml:"periodic"`
+	GraphQL       GraphQLConfig     `yaml:"graphql"`
+	Webhooks      WebhooksAPIConfig `yaml:"webhooks"`
+}
+
+// WebhooksAPIConfig - access to webhook management endpoints. `Tokens` maps owner name to its API token. Endpoints are disabled if there is no token.
+type WebhooksAPIConfig struct {
+	Tokens map[string]string `yaml:"tokens"`
+}
+
+// GraphQLConfig - limits of GraphQL queries. Zero values mean defaults.
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth"`
+	MaxComplexity int `yaml:"max_complexity"`
 }
 
 // SentryConfig -
@@ -1,21 +1,30 @@
 package config
 
 import (
+	"io"
+
 	"github.com/baking-bad/bcdhub/internal/cache"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/analytics"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/domains"
+	"github.com/baking-bad/bcdhub/internal/models/filter"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/reorg"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/baking-bad/bcdhub/internal/services/mempool"
@@ -36,6 +45,7 @@ type Context struct {
 
 	Storage         models.GeneralRepository
 	Accounts        account.Repository
+	Analytics       analytics.Repository
 	BigMapActions   bigmapaction.Repository
 	BigMapDiffs     bigmapdiff.Repository
 	Blocks          block.Repository
@@ -49,6 +59,12 @@ type Context struct {
 	Scripts         contract.ScriptRepository
 	SmartRollups    smartrollup.Repository
 	Stats           stats.Repository
+	Tokens          token.Repository
+	Metadata        metadata.Repository
+	Webhooks        webhook.Repository
+	Search          search.Repository
+	Reorgs          reorg.Repository
+	FilterRules     filter.Repository
 
 	Cache *cache.Cache
 }
@@ -71,6 +87,11 @@ func NewContext(network types.Network, opts ...ContextOption) *Context {
 
 // Close -
 func (ctx *Context) Close() error {
+	if closer, ok := ctx.RPC.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return err
+		}
+	}
 	if ctx.StorageDB != nil {
 		if err := ctx.StorageDB.Close(); err != nil {
 			return err
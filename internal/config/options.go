@@ -5,16 +5,23 @@ import (
 
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/postgres/account"
+	"github.com/baking-bad/bcdhub/internal/postgres/analytics"
 	"github.com/baking-bad/bcdhub/internal/postgres/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/postgres/contract"
 	"github.com/baking-bad/bcdhub/internal/postgres/domains"
+	"github.com/baking-bad/bcdhub/internal/postgres/filter"
 	"github.com/baking-bad/bcdhub/internal/postgres/global_constant"
+	"github.com/baking-bad/bcdhub/internal/postgres/metadata"
 	"github.com/baking-bad/bcdhub/internal/postgres/migration"
 	"github.com/baking-bad/bcdhub/internal/postgres/operation"
 	"github.com/baking-bad/bcdhub/internal/postgres/protocol"
+	"github.com/baking-bad/bcdhub/internal/postgres/reorg"
+	"github.com/baking-bad/bcdhub/internal/postgres/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/postgres/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/postgres/stats"
 	"github.com/baking-bad/bcdhub/internal/postgres/ticket"
+	"github.com/baking-bad/bcdhub/internal/postgres/token"
+	"github.com/baking-bad/bcdhub/internal/postgres/webhook"
 	"github.com/baking-bad/bcdhub/internal/services/mempool"
 
 	"github.com/baking-bad/bcdhub/internal/postgres/bigmapaction"
@@ -34,15 +41,7 @@ func WithRPC(rpcConfig map[string]RPCConfig) ContextOption {
 			if rpcProvider.URI == "" {
 				return
 			}
-			opts := []noderpc.NodeOption{
-				noderpc.WithTimeout(time.Second * time.Duration(rpcProvider.Timeout)),
-				noderpc.WithRateLimit(rpcProvider.RequestsPerSecond),
-			}
-			if rpcProvider.Log {
-				opts = append(opts, noderpc.WithLog())
-			}
-
-			ctx.RPC = noderpc.NewNodeRPC(rpcProvider.URI, opts...)
+			ctx.RPC = newRPC(rpcProvider, false)
 		}
 	}
 }
@@ -54,17 +53,44 @@ func WithWaitRPC(rpcConfig map[string]RPCConfig) ContextOption {
 			if rpcProvider.URI == "" {
 				return
 			}
-			opts := []noderpc.NodeOption{
-				noderpc.WithTimeout(time.Second * time.Duration(rpcProvider.Timeout)),
-				noderpc.WithRateLimit(rpcProvider.RequestsPerSecond),
-			}
-			if rpcProvider.Log {
-				opts = append(opts, noderpc.WithLog())
-			}
+			ctx.RPC = newRPC(rpcProvider, true)
+		}
+	}
+}
 
-			ctx.RPC = noderpc.NewWaitNodeRPC(rpcProvider.URI, opts...)
+func newRPC(rpcProvider RPCConfig, wait bool) noderpc.INode {
+	opts := []noderpc.NodeOption{
+		noderpc.WithTimeout(time.Second * time.Duration(rpcProvider.Timeout)),
+		noderpc.WithRateLimit(rpcProvider.RequestsPerSecond),
+	}
+	if rpcProvider.Log {
+		opts = append(opts, noderpc.WithLog())
+	}
+
+	if len(rpcProvider.URIs) > 0 {
+		urls := append([]string{rpcProvider.URI}, rpcProvider.URIs...)
+		var pool *noderpc.Pool
+		if wait {
+			pool = noderpc.NewWaitPool(urls, opts...)
+		} else {
+			pool = noderpc.NewPool(urls, opts...)
+		}
+		if rpcProvider.Cache != "" {
+			return noderpc.NewCachedNode(pool, rpcProvider.Cache)
 		}
+		return pool
+	}
+
+	var node *noderpc.NodeRPC
+	if wait {
+		node = noderpc.NewWaitNodeRPC(rpcProvider.URI, opts...)
+	} else {
+		node = noderpc.NewNodeRPC(rpcProvider.URI, opts...)
+	}
+	if rpcProvider.Cache != "" {
+		return noderpc.NewCachedNode(node, rpcProvider.Cache)
 	}
+	return node
 }
 
 // WithStorage -
@@ -91,6 +117,7 @@ func WithStorage(cfg StorageConfig, appName string, maxPageSize int64) ContextOp
 		ctx.StorageDB = conn
 		ctx.Storage = conn
 		ctx.Accounts = account.NewStorage(conn)
+		ctx.Analytics = analytics.NewStorage(conn)
 		ctx.BigMapActions = bigmapaction.NewStorage(conn)
 		ctx.Blocks = block.NewStorage(conn)
 		ctx.BigMapDiffs = bigmapdiff.NewStorage(conn)
@@ -104,6 +131,12 @@ func WithStorage(cfg StorageConfig, appName string, maxPageSize int64) ContextOp
 		ctx.Scripts = contractStorage
 		ctx.SmartRollups = smartrollup.NewStorage(conn)
 		ctx.Stats = stats.NewStorage(conn)
+		ctx.Tokens = token.NewStorage(conn)
+		ctx.Metadata = metadata.NewStorage(conn)
+		ctx.Webhooks = webhook.NewStorage(conn)
+		ctx.Search = search.NewStorage(conn)
+		ctx.Reorgs = reorg.NewStorage(conn)
+		ctx.FilterRules = filter.NewStorage(conn)
 	}
 }
 
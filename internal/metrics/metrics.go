@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metric names are a part of public interface: dashboards and alerts depend on them, so they must not be changed.
+// The list is documented in docs/metrics.md.
+const namespace = "bcd"
+
+// Transaction statuses
+const (
+	StatusCommitted  = "committed"
+	StatusRolledBack = "rolled_back"
+)
+
+var (
+	blockProcessingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "block_processing_duration_seconds",
+		Help:      "Time of parsing and saving one block.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"network"})
+
+	headLevel = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "head_level",
+		Help:      "Level of the node's head.",
+	}, []string{"network"})
+
+	indexedLevel = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "level",
+		Help:      "Level of the last indexed block.",
+	}, []string{"network"})
+
+	headLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "head_lag_blocks",
+		Help:      "Count of blocks between the node's head and the last indexed block.",
+	}, []string{"network"})
+
+	rollbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "rollbacks_total",
+		Help:      "Count of rollbacks caused by chain reorganizations.",
+	}, []string{"network"})
+
+	rollbackDepth = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "rollback_depth_blocks",
+		Help:      "Count of blocks rolled back by one rollback.",
+		Buckets:   []float64{1, 2, 3, 5, 10, 20, 50, 100},
+	}, []string{"network"})
+
+	receiverQueue = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "receiver_queue_length",
+		Help:      "Count of blocks requested by the receiver which are not handled yet.",
+	}, []string{"network"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of node RPC requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"node", "method"})
+
+	rpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "errors_total",
+		Help:      "Count of failed node RPC requests.",
+	}, []string{"node", "method"})
+
+	dbTransactionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transaction_duration_seconds",
+		Help:      "Duration of database transactions from begin to commit or rollback.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"status"})
+
+	apiDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of API requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		blockProcessingDuration,
+		headLevel,
+		indexedLevel,
+		headLag,
+		rollbacks,
+		rollbackDepth,
+		receiverQueue,
+		rpcDuration,
+		rpcErrors,
+		dbTransactionDuration,
+		apiDuration,
+	)
+}
+
+// Handler - returns HTTP handler which exposes metrics in Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// levels - the last known head and indexed levels of networks which are used for head lag calculation
+var levels = struct {
+	head    map[string]int64
+	indexed map[string]int64
+	mx      sync.Mutex
+}{
+	head:    make(map[string]int64),
+	indexed: make(map[string]int64),
+}
+
+// SetHeadLevel - sets level of the node's head
+func SetHeadLevel(network string, level int64) {
+	headLevel.WithLabelValues(network).Set(float64(level))
+
+	levels.mx.Lock()
+	levels.head[network] = level
+	updateLag(network)
+	levels.mx.Unlock()
+}
+
+// SetIndexedLevel - sets level of the last indexed block
+func SetIndexedLevel(network string, level int64) {
+	indexedLevel.WithLabelValues(network).Set(float64(level))
+
+	levels.mx.Lock()
+	levels.indexed[network] = level
+	updateLag(network)
+	levels.mx.Unlock()
+}
+
+func updateLag(network string) {
+	head, ok := levels.head[network]
+	if !ok {
+		return
+	}
+	headLag.WithLabelValues(network).Set(float64(max(head-levels.indexed[network], 0)))
+}
+
+// BlockProcessed - observes processing time of the block
+func BlockProcessed(network string, duration time.Duration) {
+	blockProcessingDuration.WithLabelValues(network).Observe(duration.Seconds())
+}
+
+// Rollback - counts rollback of `depth` blocks
+func Rollback(network string, depth int64) {
+	rollbacks.WithLabelValues(network).Inc()
+	rollbackDepth.WithLabelValues(network).Observe(float64(depth))
+}
+
+// SetReceiverQueue - sets count of blocks requested by the receiver which are not handled yet
+func SetReceiverQueue(network string, length int) {
+	receiverQueue.WithLabelValues(network).Set(float64(length))
+}
+
+// RPCRequest - observes duration of the node RPC request and counts it as failed if `err` is not nil
+func RPCRequest(node, method string, duration time.Duration, err error) {
+	rpcDuration.WithLabelValues(node, method).Observe(duration.Seconds())
+	if err != nil {
+		rpcErrors.WithLabelValues(node, method).Inc()
+	}
+}
+
+// DBTransaction - observes duration of the database transaction finished with `status`
+func DBTransaction(status string, duration time.Duration) {
+	dbTransactionDuration.WithLabelValues(status).Observe(duration.Seconds())
+}
+
+// APIRequest - observes duration of the API request. `route` is a route template, e.g. `/v1/contract/:network/:address`,
+// so label cardinality doesn't depend on request parameters.
+func APIRequest(method, route string, status int, duration time.Duration) {
+	apiDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
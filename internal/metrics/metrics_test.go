@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadLag(t *testing.T) {
+	const network = "lag_test"
+
+	SetIndexedLevel(network, 90)
+
+	SetHeadLevel(network, 100)
+	require.EqualValues(t, 10, testutil.ToFloat64(headLag.WithLabelValues(network)))
+
+	SetIndexedLevel(network, 100)
+	require.EqualValues(t, 0, testutil.ToFloat64(headLag.WithLabelValues(network)))
+
+	SetIndexedLevel(network, 101)
+	require.EqualValues(t, 0, testutil.ToFloat64(headLag.WithLabelValues(network)))
+}
+
+func TestRPCRequest(t *testing.T) {
+	const node = "https://rpc_test"
+
+	RPCRequest(node, "Block", time.Millisecond, nil)
+	RPCRequest(node, "Block", time.Millisecond, errors.New("test"))
+	RPCRequest(node, "GetHeader", time.Millisecond, nil)
+
+	require.EqualValues(t, 1, testutil.ToFloat64(rpcErrors.WithLabelValues(node, "Block")))
+	require.EqualValues(t, 0, testutil.ToFloat64(rpcErrors.WithLabelValues(node, "GetHeader")))
+}
+
+func TestHandler(t *testing.T) {
+	Rollback("handler_test", 3)
+	APIRequest(http.MethodGet, "/v1/head/:network", http.StatusOK, time.Millisecond)
+
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		`bcd_indexer_rollbacks_total{network="handler_test"} 1`,
+		`bcd_indexer_rollback_depth_blocks_sum{network="handler_test"} 3`,
+		`bcd_api_request_duration_seconds_count{method="GET",route="/v1/head/:network",status="200"} 1`,
+		"go_goroutines",
+	} {
+		require.True(t, strings.Contains(string(body), want), want)
+	}
+}
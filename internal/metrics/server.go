@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Server - HTTP server which exposes metrics on `/metrics` for services without own HTTP server
+type Server struct {
+	server *http.Server
+}
+
+// NewServer -
+func NewServer(bind string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &Server{
+		server: &http.Server{
+			Addr:              bind,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start -
+func (s *Server) Start() {
+	go func() {
+		log.Info().Str("bind", s.server.Addr).Msg("metrics server started")
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Err(err).Msg("metrics server")
+		}
+	}()
+}
+
+// Close -
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
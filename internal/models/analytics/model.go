@@ -0,0 +1,80 @@
+package analytics
+
+import "time"
+
+// Period - size of the time bucket
+type Period string
+
+// periods
+const (
+	PeriodHour Period = "hour"
+	PeriodDay  Period = "day"
+)
+
+// NewPeriod -
+func NewPeriod(value string) Period {
+	switch value {
+	case string(PeriodHour):
+		return PeriodHour
+	default:
+		return PeriodDay
+	}
+}
+
+// Duration - returns length of the bucket
+func (p Period) Duration() time.Duration {
+	if p == PeriodHour {
+		return time.Hour
+	}
+	return time.Hour * 24
+}
+
+// ContractStats - aggregated operations with the contract as a destination in the time bucket.
+// Transactions and paid storage increases are aggregated. Paid storage increases are grouped under empty entrypoint.
+type ContractStats struct {
+	Bucket              time.Time `bun:"bucket"`
+	Entrypoint          string    `bun:"entrypoint"`
+	Operations          int64     `bun:"operations"`
+	Calls               int64     `bun:"calls"`
+	FailedCalls         int64     `bun:"failed_calls"`
+	TotalGas            int64     `bun:"total_gas"`
+	AvgGas              float64   `bun:"avg_gas"`
+	P95Gas              float64   `bun:"p95_gas"`
+	Burned              int64     `bun:"burned"`
+	Fee                 int64     `bun:"fee"`
+	PaidStorageSizeDiff int64     `bun:"paid_storage_size_diff"`
+	StorageSize         int64     `bun:"storage_size"`
+}
+
+// NetworkStats - network-wide activity in the time bucket
+type NetworkStats struct {
+	Bucket          time.Time `bun:"bucket"`
+	Operations      int64     `bun:"operations"`
+	Calls           int64     `bun:"calls"`
+	UniqueSenders   int64     `bun:"unique_senders"`
+	NewContracts    int64     `bun:"new_contracts"`
+	Events          int64     `bun:"events"`
+	TicketTransfers int64     `bun:"ticket_transfers"`
+	TicketUpdates   int64     `bun:"ticket_updates"`
+	GlobalConstants int64     `bun:"global_constants"`
+	SmartRollups    int64     `bun:"smart_rollups"`
+}
+
+// TopBy - metric for contracts ranking
+type TopBy string
+
+// metrics
+const (
+	TopByCalls TopBy = "calls"
+	TopByGas   TopBy = "gas"
+)
+
+// TopContract - contract's activity in the time window
+type TopContract struct {
+	AccountID   int64  `bun:"account_id"`
+	Address     string `bun:"address"`
+	Calls       int64  `bun:"calls"`
+	FailedCalls int64  `bun:"failed_calls"`
+	TotalGas    int64  `bun:"total_gas"`
+	Burned      int64  `bun:"burned"`
+}
@@ -0,0 +1,42 @@
+package analytics
+
+import (
+	"context"
+	"time"
+)
+
+// ContractRequest -
+type ContractRequest struct {
+	AccountID    int64
+	Period       Period
+	From         time.Time
+	To           time.Time
+	Entrypoint   string
+	ByEntrypoint bool
+}
+
+// NetworkRequest -
+type NetworkRequest struct {
+	Period Period
+	From   time.Time
+	To     time.Time
+}
+
+// TopRequest -
+type TopRequest struct {
+	By    TopBy
+	From  time.Time
+	To    time.Time
+	Limit int64
+}
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/analytics/mock.go -package=analytics -typed
+type Repository interface {
+	// Contract - returns time series of contract's operations stats ordered by bucket. If `ByEntrypoint` is set, series is split by entrypoints.
+	// Otherwise, 95th percentile of gas is the maximum of entrypoints' percentiles because percentiles can't be merged.
+	Contract(ctx context.Context, req ContractRequest) ([]ContractStats, error)
+	// Network - returns time series of network-wide activity ordered by bucket. Unique senders are counted per bucket.
+	Network(ctx context.Context, req NetworkRequest) ([]NetworkStats, error)
+	// TopContracts - returns contracts with the most calls or consumed gas in the time window.
+	TopContracts(ctx context.Context, req TopRequest) ([]TopContract, error)
+}
@@ -10,3 +10,9 @@ type GetContext struct {
 	CurrentLevel *int64
 	Contract     string
 }
+
+// Point - position in the history of the contract: the end of the level or, if `OperationID` is set, the moment right after the operation of the level
+type Point struct {
+	Level       int64
+	OperationID int64
+}
@@ -6,6 +6,8 @@ import "context"
 type Repository interface {
 	Get(ctx context.Context, reqCtx GetContext) ([]Bucket, error)
 	GetForOperation(ctx context.Context, id int64) ([]BigMapDiff, error)
+	// GetForOperations - returns diffs of all operations ordered by id
+	GetForOperations(ctx context.Context, ids []int64) ([]BigMapDiff, error)
 	GetByPtr(ctx context.Context, contract string, ptr int64) ([]BigMapState, error)
 	GetByPtrAndKeyHash(ctx context.Context, ptr int64, keyHash string, size int64, offset int64) ([]BigMapDiff, int64, error)
 	GetForAddress(ctx context.Context, address string) ([]BigMapState, error)
@@ -14,4 +16,9 @@ type Repository interface {
 	Previous(ctx context.Context, diffs []BigMapDiff) ([]BigMapDiff, error)
 	GetStats(ctx context.Context, ptr int64) (Stats, error)
 	Keys(ctx context.Context, reqCtx GetContext) (states []BigMapState, err error)
+
+	// StatesAtLevel - returns the last diff of every key of the contract's big maps at or before `level`. Value of the removed key is nil.
+	StatesAtLevel(ctx context.Context, contract string, level int64) ([]BigMapDiff, error)
+	// Changes - returns keys of the contract's big maps changed between points. `before` contains the last diffs of the keys at `from` (keys which did not exist are absent), `after` contains the last diffs of the keys at `to`.
+	Changes(ctx context.Context, contract string, from, to Point) (before, after []BigMapDiff, err error)
 }
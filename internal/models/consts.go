@@ -6,12 +6,18 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/filter"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/reorg"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 )
 
 // Document names
@@ -22,6 +28,8 @@ const (
 	DocBigMapState     = "big_map_states"
 	DocBlocks          = "blocks"
 	DocContracts       = "contracts"
+	DocContractMeta    = "contract_metadata"
+	DocTokenMeta       = "token_metadata"
 	DocGlobalConstants = "global_constants"
 	DocMigrations      = "migrations"
 	DocOperations      = "operations"
@@ -31,7 +39,20 @@ const (
 	DocTickets         = "tickets"
 	DocTicketBalances  = "ticket_balances"
 	DocSmartRollups    = "smart_rollup"
+	DocSrCommitments   = "smart_rollup_commitments"
+	DocSrGameMoves     = "smart_rollup_game_moves"
+	DocSrRecoveries    = "smart_rollup_bond_recoveries"
+	DocSrMessages      = "smart_rollup_messages"
+	DocSrOutbox        = "smart_rollup_outbox_transactions"
 	DocStats           = "stats"
+	DocTokenBalances   = "token_balances"
+	DocTokenTransfers  = "token_transfers"
+	DocWebhooks        = "webhooks"
+	DocWebhookQueue    = "webhook_deliveries"
+	DocWebhookDead     = "webhook_dead_letters"
+	DocSearchEntries   = "search_entries"
+	DocReorgs          = "reorgs"
+	DocFilterRules     = "filter_rules"
 )
 
 // AllDocuments - returns all document names
@@ -43,6 +64,8 @@ func AllDocuments() []string {
 		DocBigMapState,
 		DocBlocks,
 		DocContracts,
+		DocContractMeta,
+		DocTokenMeta,
 		DocGlobalConstants,
 		DocMigrations,
 		DocOperations,
@@ -52,7 +75,20 @@ func AllDocuments() []string {
 		DocTicketBalances,
 		DocTickets,
 		DocSmartRollups,
+		DocSrCommitments,
+		DocSrGameMoves,
+		DocSrRecoveries,
+		DocSrMessages,
+		DocSrOutbox,
 		DocStats,
+		DocTokenBalances,
+		DocTokenTransfers,
+		DocWebhooks,
+		DocWebhookQueue,
+		DocWebhookDead,
+		DocSearchEntries,
+		DocReorgs,
+		DocFilterRules,
 	}
 }
 
@@ -75,7 +111,22 @@ func AllModels() []Model {
 		&contract.Contract{},
 		&migration.Migration{},
 		&smartrollup.SmartRollup{},
+		&smartrollup.Commitment{},
+		&smartrollup.GameMove{},
+		&smartrollup.BondRecovery{},
+		&smartrollup.Message{},
+		&smartrollup.OutboxTransaction{},
 		&stats.Stats{},
+		&token.Balance{},
+		&token.Transfer{},
+		&metadata.ContractMetadata{},
+		&metadata.TokenMetadata{},
+		&webhook.Webhook{},
+		&webhook.Delivery{},
+		&webhook.DeadLetter{},
+		&search.Entry{},
+		&reorg.Reorg{},
+		&filter.Rule{},
 	}
 }
 
@@ -0,0 +1,31 @@
+package filter
+
+import (
+	"github.com/uptrace/bun"
+)
+
+// Rule kinds
+const (
+	RuleKindScriptHash = "script_hash"
+	RuleKindTag        = "tag"
+)
+
+// Rule - code hash or tag of the selective indexing filter whose history is indexed. Rules which are added to the filter later
+// are found by comparison with the stored ones, so history of contracts matched by them is backfilled.
+type Rule struct {
+	bun.BaseModel `bun:"filter_rules"`
+
+	ID    int64  `bun:"id,pk,notnull,autoincrement"`
+	Kind  string `bun:"kind,type:text,unique:filter_rule"`
+	Value string `bun:"value,type:text,unique:filter_rule"`
+}
+
+// GetID -
+func (r *Rule) GetID() int64 {
+	return r.ID
+}
+
+// TableName -
+func (Rule) TableName() string {
+	return "filter_rules"
+}
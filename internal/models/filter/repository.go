@@ -0,0 +1,11 @@
+package filter
+
+import "context"
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/filter/mock.go -package=filter -typed
+type Repository interface {
+	// List - returns rules whose history is indexed
+	List(ctx context.Context) ([]Rule, error)
+	// Replace - replaces stored rules by `rules`
+	Replace(ctx context.Context, rules []Rule) error
+}
@@ -8,12 +8,16 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=mock/general.go -package=mock -typed
@@ -37,6 +41,11 @@ type Transaction interface {
 	BigMapActions(ctx context.Context, bigmapdiffs ...*bigmapaction.BigMapAction) error
 	Accounts(ctx context.Context, accounts ...*account.Account) error
 	SmartRollups(ctx context.Context, rollups ...*smartrollup.SmartRollup) error
+	SmartRollupCommitments(ctx context.Context, commitments ...*smartrollup.Commitment) error
+	SmartRollupGameMoves(ctx context.Context, moves ...*smartrollup.GameMove) error
+	SmartRollupRecoveries(ctx context.Context, recoveries ...*smartrollup.BondRecovery) error
+	SmartRollupMessages(ctx context.Context, messages ...*smartrollup.Message) error
+	SmartRollupOutbox(ctx context.Context, transactions ...*smartrollup.OutboxTransaction) error
 	Operations(ctx context.Context, operations ...*operation.Operation) error
 	TickerUpdates(ctx context.Context, updates ...*ticket.TicketUpdate) error
 	Contracts(ctx context.Context, contracts ...*contract.Contract) error
@@ -47,6 +56,12 @@ type Transaction interface {
 	UpdateStats(ctx context.Context, stats stats.Stats) error
 	Tickets(ctx context.Context, tickets ...*ticket.Ticket) error
 	TicketBalances(ctx context.Context, balances ...*ticket.Balance) error
+	TokenTransfers(ctx context.Context, transfers ...*token.Transfer) error
+	TokenBalances(ctx context.Context, balances ...*token.Balance) error
+	ContractMetadata(ctx context.Context, metadata ...*metadata.ContractMetadata) error
+	TokenMetadata(ctx context.Context, metadata ...*metadata.TokenMetadata) error
+	WebhookDeliveries(ctx context.Context, deliveries ...*webhook.Delivery) error
+	SearchEntries(ctx context.Context, entries ...*search.Entry) error
 
 	ToBabylon(ctx context.Context) error
 	BabylonUpdateNonDelegator(ctx context.Context, contract *contract.Contract) error
@@ -0,0 +1,49 @@
+package metadata
+
+import (
+	stdJSON "encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ContractMetadata - TZIP-16 metadata of the contract. Every change of the metadata creates new record, so the latest resolved one by level is actual. Off-chain documents are received in background: such record is `Pending` until the document is fetched or the count of attempts is exceeded.
+type ContractMetadata struct {
+	bun.BaseModel `bun:"contract_metadata"`
+
+	ID          int64              `bun:"id,pk,notnull,autoincrement"`
+	Contract    string             `bun:"contract,notnull,type:text,unique:contract_metadata_key"`
+	Level       int64              `bun:"level,notnull,unique:contract_metadata_key"`
+	Timestamp   time.Time          `bun:"timestamp"`
+	URI         string             `bun:"uri,type:text"`
+	Name        string             `bun:"name,type:text"`
+	Description string             `bun:"description,type:text"`
+	Version     string             `bun:"version,type:text"`
+	Homepage    string             `bun:"homepage,type:text"`
+	Authors     []string           `bun:"authors,array"`
+	Interfaces  []string           `bun:"interfaces,array"`
+	License     stdJSON.RawMessage `bun:"license,type:jsonb"`
+	Views       stdJSON.RawMessage `bun:"views,type:jsonb"`
+	Raw         stdJSON.RawMessage `bun:"raw,type:jsonb"`
+	Pending     bool               `bun:"pending,notnull,default:false"`
+	Attempts    int                `bun:"attempts,notnull,default:0"`
+}
+
+// GetID -
+func (m *ContractMetadata) GetID() int64 {
+	return m.ID
+}
+
+// TableName -
+func (ContractMetadata) TableName() string {
+	return "contract_metadata"
+}
+
+// LogFields -
+func (m *ContractMetadata) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"contract": m.Contract,
+		"block":    m.Level,
+		"uri":      m.URI,
+	}
+}
@@ -0,0 +1,25 @@
+package metadata
+
+import "context"
+
+// TokenMetadataRequest -
+type TokenMetadataRequest struct {
+	Limit  int64
+	Offset int64
+}
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/metadata/mock.go -package=metadata -typed
+type Repository interface {
+	// ContractMetadata - returns actual TZIP-16 metadata of the contract
+	ContractMetadata(ctx context.Context, contract string) (ContractMetadata, error)
+	// TokenMetadata - returns actual TZIP-21 metadata of the contract's tokens ordered by token id
+	TokenMetadata(ctx context.Context, contract string, req TokenMetadataRequest) ([]TokenMetadata, error)
+	// PendingContractMetadata - returns contract metadata which off-chain document is not received yet
+	PendingContractMetadata(ctx context.Context, limit int) ([]ContractMetadata, error)
+	// PendingTokenMetadata - returns token metadata which off-chain document is not received yet
+	PendingTokenMetadata(ctx context.Context, limit int) ([]TokenMetadata, error)
+	// UpdateContractMetadata - saves result of off-chain document resolving
+	UpdateContractMetadata(ctx context.Context, m *ContractMetadata) error
+	// UpdateTokenMetadata - saves result of off-chain document resolving
+	UpdateTokenMetadata(ctx context.Context, m *TokenMetadata) error
+}
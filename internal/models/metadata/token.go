@@ -0,0 +1,53 @@
+package metadata
+
+import (
+	stdJSON "encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// TokenMetadata - TZIP-21 metadata of the FA2 token from `token_metadata` big map. Every change of the big map key creates new record, so the latest one by level is actual. On-chain fields are available at once and fields of off-chain document referenced by empty key are merged in background while record is `Pending`.
+type TokenMetadata struct {
+	bun.BaseModel `bun:"token_metadata"`
+
+	ID        int64              `bun:"id,pk,notnull,autoincrement"`
+	Contract  string             `bun:"contract,notnull,type:text,unique:token_metadata_key"`
+	TokenId   decimal.Decimal    `bun:"token_id,notnull,type:numeric(200,0),unique:token_metadata_key"`
+	Level     int64              `bun:"level,notnull,unique:token_metadata_key"`
+	Timestamp time.Time          `bun:"timestamp"`
+	Symbol    string             `bun:"symbol,type:text"`
+	Name      string             `bun:"name,type:text"`
+	Decimals  *int64             `bun:"decimals"`
+	URI       string             `bun:"uri,type:text"`
+	Extras    stdJSON.RawMessage `bun:"extras,type:jsonb"`
+	Removed   bool               `bun:"removed,notnull,default:false"`
+	Pending   bool               `bun:"pending,notnull,default:false"`
+	Attempts  int                `bun:"attempts,notnull,default:0"`
+}
+
+// GetID -
+func (m *TokenMetadata) GetID() int64 {
+	return m.ID
+}
+
+// TableName -
+func (TokenMetadata) TableName() string {
+	return "token_metadata"
+}
+
+// LogFields -
+func (m *TokenMetadata) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"contract": m.Contract,
+		"token_id": m.TokenId.String(),
+		"block":    m.Level,
+	}
+}
+
+// String -
+func (m TokenMetadata) String() string {
+	return fmt.Sprintf("%s_%s", m.Contract, m.TokenId.String())
+}
@@ -21,6 +21,9 @@ type Migration struct {
 	Kind           types.MigrationKind `bun:"kind,type:SMALLINT"`
 	ContractID     int64
 	Contract       contract.Contract `bun:"rel:belongs-to"`
+
+	// DeffatedStorage - storage of the contract after the migration
+	DeffatedStorage []byte `bun:"deffated_storage,type:bytea"`
 }
 
 // GetID -
@@ -5,4 +5,5 @@ import "context"
 //go:generate mockgen -source=$GOFILE -destination=../mock/migration/mock.go -package=migration -typed
 type Repository interface {
 	Get(ctx context.Context, contractID int64) ([]Migration, error)
+	LastStorage(ctx context.Context, accountID, level int64) (Migration, error)
 }
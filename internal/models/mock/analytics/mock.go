@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../mock/analytics/mock.go -package=analytics -typed
+//
+// Package analytics is a generated GoMock package.
+package analytics
+
+import (
+	context "context"
+	reflect "reflect"
+
+	analytics "github.com/baking-bad/bcdhub/internal/models/analytics"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Contract mocks base method.
+func (m *MockRepository) Contract(ctx context.Context, req analytics.ContractRequest) ([]analytics.ContractStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contract", ctx, req)
+	ret0, _ := ret[0].([]analytics.ContractStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Contract indicates an expected call of Contract.
+func (mr *MockRepositoryMockRecorder) Contract(ctx, req any) *RepositoryContractCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contract", reflect.TypeOf((*MockRepository)(nil).Contract), ctx, req)
+	return &RepositoryContractCall{Call: call}
+}
+
+// RepositoryContractCall wrap *gomock.Call
+type RepositoryContractCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryContractCall) Return(arg0 []analytics.ContractStats, arg1 error) *RepositoryContractCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryContractCall) Do(f func(context.Context, analytics.ContractRequest) ([]analytics.ContractStats, error)) *RepositoryContractCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryContractCall) DoAndReturn(f func(context.Context, analytics.ContractRequest) ([]analytics.ContractStats, error)) *RepositoryContractCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Network mocks base method.
+func (m *MockRepository) Network(ctx context.Context, req analytics.NetworkRequest) ([]analytics.NetworkStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Network", ctx, req)
+	ret0, _ := ret[0].([]analytics.NetworkStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Network indicates an expected call of Network.
+func (mr *MockRepositoryMockRecorder) Network(ctx, req any) *RepositoryNetworkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Network", reflect.TypeOf((*MockRepository)(nil).Network), ctx, req)
+	return &RepositoryNetworkCall{Call: call}
+}
+
+// RepositoryNetworkCall wrap *gomock.Call
+type RepositoryNetworkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryNetworkCall) Return(arg0 []analytics.NetworkStats, arg1 error) *RepositoryNetworkCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryNetworkCall) Do(f func(context.Context, analytics.NetworkRequest) ([]analytics.NetworkStats, error)) *RepositoryNetworkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryNetworkCall) DoAndReturn(f func(context.Context, analytics.NetworkRequest) ([]analytics.NetworkStats, error)) *RepositoryNetworkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// TopContracts mocks base method.
+func (m *MockRepository) TopContracts(ctx context.Context, req analytics.TopRequest) ([]analytics.TopContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopContracts", ctx, req)
+	ret0, _ := ret[0].([]analytics.TopContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopContracts indicates an expected call of TopContracts.
+func (mr *MockRepositoryMockRecorder) TopContracts(ctx, req any) *RepositoryTopContractsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopContracts", reflect.TypeOf((*MockRepository)(nil).TopContracts), ctx, req)
+	return &RepositoryTopContractsCall{Call: call}
+}
+
+// RepositoryTopContractsCall wrap *gomock.Call
+type RepositoryTopContractsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryTopContractsCall) Return(arg0 []analytics.TopContract, arg1 error) *RepositoryTopContractsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryTopContractsCall) Do(f func(context.Context, analytics.TopRequest) ([]analytics.TopContract, error)) *RepositoryTopContractsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryTopContractsCall) DoAndReturn(f func(context.Context, analytics.TopRequest) ([]analytics.TopContract, error)) *RepositoryTopContractsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
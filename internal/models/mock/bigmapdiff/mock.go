@@ -39,6 +39,46 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Changes mocks base method.
+func (m *MockRepository) Changes(ctx context.Context, contract string, from, to bigmapdiff.Point) ([]bigmapdiff.BigMapDiff, []bigmapdiff.BigMapDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changes", ctx, contract, from, to)
+	ret0, _ := ret[0].([]bigmapdiff.BigMapDiff)
+	ret1, _ := ret[1].([]bigmapdiff.BigMapDiff)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Changes indicates an expected call of Changes.
+func (mr *MockRepositoryMockRecorder) Changes(ctx, contract, from, to any) *RepositoryChangesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changes", reflect.TypeOf((*MockRepository)(nil).Changes), ctx, contract, from, to)
+	return &RepositoryChangesCall{Call: call}
+}
+
+// RepositoryChangesCall wrap *gomock.Call
+type RepositoryChangesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryChangesCall) Return(before, after []bigmapdiff.BigMapDiff, err error) *RepositoryChangesCall {
+	c.Call = c.Call.Return(before, after, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryChangesCall) Do(f func(context.Context, string, bigmapdiff.Point, bigmapdiff.Point) ([]bigmapdiff.BigMapDiff, []bigmapdiff.BigMapDiff, error)) *RepositoryChangesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryChangesCall) DoAndReturn(f func(context.Context, string, bigmapdiff.Point, bigmapdiff.Point) ([]bigmapdiff.BigMapDiff, []bigmapdiff.BigMapDiff, error)) *RepositoryChangesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Count mocks base method.
 func (m *MockRepository) Count(ctx context.Context, ptr int64) (int, error) {
 	m.ctrl.T.Helper()
@@ -313,6 +353,45 @@ func (c *RepositoryGetForOperationCall) DoAndReturn(f func(context.Context, int6
 	return c
 }
 
+// GetForOperations mocks base method.
+func (m *MockRepository) GetForOperations(ctx context.Context, ids []int64) ([]bigmapdiff.BigMapDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForOperations", ctx, ids)
+	ret0, _ := ret[0].([]bigmapdiff.BigMapDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForOperations indicates an expected call of GetForOperations.
+func (mr *MockRepositoryMockRecorder) GetForOperations(ctx, ids any) *RepositoryGetForOperationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForOperations", reflect.TypeOf((*MockRepository)(nil).GetForOperations), ctx, ids)
+	return &RepositoryGetForOperationsCall{Call: call}
+}
+
+// RepositoryGetForOperationsCall wrap *gomock.Call
+type RepositoryGetForOperationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryGetForOperationsCall) Return(arg0 []bigmapdiff.BigMapDiff, arg1 error) *RepositoryGetForOperationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryGetForOperationsCall) Do(f func(context.Context, []int64) ([]bigmapdiff.BigMapDiff, error)) *RepositoryGetForOperationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryGetForOperationsCall) DoAndReturn(f func(context.Context, []int64) ([]bigmapdiff.BigMapDiff, error)) *RepositoryGetForOperationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetStats mocks base method.
 func (m *MockRepository) GetStats(ctx context.Context, ptr int64) (bigmapdiff.Stats, error) {
 	m.ctrl.T.Helper()
@@ -429,3 +508,42 @@ func (c *RepositoryPreviousCall) DoAndReturn(f func(context.Context, []bigmapdif
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// StatesAtLevel mocks base method.
+func (m *MockRepository) StatesAtLevel(ctx context.Context, contract string, level int64) ([]bigmapdiff.BigMapDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatesAtLevel", ctx, contract, level)
+	ret0, _ := ret[0].([]bigmapdiff.BigMapDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatesAtLevel indicates an expected call of StatesAtLevel.
+func (mr *MockRepositoryMockRecorder) StatesAtLevel(ctx, contract, level any) *RepositoryStatesAtLevelCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatesAtLevel", reflect.TypeOf((*MockRepository)(nil).StatesAtLevel), ctx, contract, level)
+	return &RepositoryStatesAtLevelCall{Call: call}
+}
+
+// RepositoryStatesAtLevelCall wrap *gomock.Call
+type RepositoryStatesAtLevelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryStatesAtLevelCall) Return(arg0 []bigmapdiff.BigMapDiff, arg1 error) *RepositoryStatesAtLevelCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryStatesAtLevelCall) Do(f func(context.Context, string, int64) ([]bigmapdiff.BigMapDiff, error)) *RepositoryStatesAtLevelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryStatesAtLevelCall) DoAndReturn(f func(context.Context, string, int64) ([]bigmapdiff.BigMapDiff, error)) *RepositoryStatesAtLevelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../mock/filter/mock.go -package=filter -typed
+//
+// Package filter is a generated GoMock package.
+package filter
+
+import (
+	context "context"
+	reflect "reflect"
+
+	filter "github.com/baking-bad/bcdhub/internal/models/filter"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context) ([]filter.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]filter.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx any) *RepositoryListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx)
+	return &RepositoryListCall{Call: call}
+}
+
+// RepositoryListCall wrap *gomock.Call
+type RepositoryListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryListCall) Return(arg0 []filter.Rule, arg1 error) *RepositoryListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryListCall) Do(f func(context.Context) ([]filter.Rule, error)) *RepositoryListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryListCall) DoAndReturn(f func(context.Context) ([]filter.Rule, error)) *RepositoryListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Replace mocks base method.
+func (m *MockRepository) Replace(ctx context.Context, rules []filter.Rule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockRepositoryMockRecorder) Replace(ctx, rules any) *RepositoryReplaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockRepository)(nil).Replace), ctx, rules)
+	return &RepositoryReplaceCall{Call: call}
+}
+
+// RepositoryReplaceCall wrap *gomock.Call
+type RepositoryReplaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryReplaceCall) Return(arg0 error) *RepositoryReplaceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryReplaceCall) Do(f func(context.Context, []filter.Rule) error) *RepositoryReplaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryReplaceCall) DoAndReturn(f func(context.Context, []filter.Rule) error) *RepositoryReplaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
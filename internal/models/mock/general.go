@@ -17,12 +17,16 @@ import (
 	bigmapdiff "github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	block "github.com/baking-bad/bcdhub/internal/models/block"
 	contract "github.com/baking-bad/bcdhub/internal/models/contract"
+	metadata "github.com/baking-bad/bcdhub/internal/models/metadata"
 	migration "github.com/baking-bad/bcdhub/internal/models/migration"
 	operation "github.com/baking-bad/bcdhub/internal/models/operation"
 	protocol "github.com/baking-bad/bcdhub/internal/models/protocol"
+	search "github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	stats "github.com/baking-bad/bcdhub/internal/models/stats"
 	ticket "github.com/baking-bad/bcdhub/internal/models/ticket"
+	token "github.com/baking-bad/bcdhub/internal/models/token"
+	webhook "github.com/baking-bad/bcdhub/internal/models/webhook"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -587,6 +591,49 @@ func (c *TransactionCommitCall) DoAndReturn(f func() error) *TransactionCommitCa
 	return c
 }
 
+// ContractMetadata mocks base method.
+func (m *MockTransaction) ContractMetadata(ctx context.Context, metadata ...*metadata.ContractMetadata) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range metadata {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ContractMetadata", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ContractMetadata indicates an expected call of ContractMetadata.
+func (mr *MockTransactionMockRecorder) ContractMetadata(ctx any, metadata ...any) *TransactionContractMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, metadata...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractMetadata", reflect.TypeOf((*MockTransaction)(nil).ContractMetadata), varargs...)
+	return &TransactionContractMetadataCall{Call: call}
+}
+
+// TransactionContractMetadataCall wrap *gomock.Call
+type TransactionContractMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionContractMetadataCall) Return(arg0 error) *TransactionContractMetadataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionContractMetadataCall) Do(f func(context.Context, ...*metadata.ContractMetadata) error) *TransactionContractMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionContractMetadataCall) DoAndReturn(f func(context.Context, ...*metadata.ContractMetadata) error) *TransactionContractMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Contracts mocks base method.
 func (m *MockTransaction) Contracts(ctx context.Context, contracts ...*contract.Contract) error {
 	m.ctrl.T.Helper()
@@ -1074,6 +1121,264 @@ func (c *TransactionScriptsCall) DoAndReturn(f func(context.Context, ...*contrac
 	return c
 }
 
+// SearchEntries mocks base method.
+func (m *MockTransaction) SearchEntries(ctx context.Context, entries ...*search.Entry) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range entries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchEntries", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchEntries indicates an expected call of SearchEntries.
+func (mr *MockTransactionMockRecorder) SearchEntries(ctx any, entries ...any) *TransactionSearchEntriesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, entries...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEntries", reflect.TypeOf((*MockTransaction)(nil).SearchEntries), varargs...)
+	return &TransactionSearchEntriesCall{Call: call}
+}
+
+// TransactionSearchEntriesCall wrap *gomock.Call
+type TransactionSearchEntriesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionSearchEntriesCall) Return(arg0 error) *TransactionSearchEntriesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionSearchEntriesCall) Do(f func(context.Context, ...*search.Entry) error) *TransactionSearchEntriesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionSearchEntriesCall) DoAndReturn(f func(context.Context, ...*search.Entry) error) *TransactionSearchEntriesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SmartRollupCommitments mocks base method.
+func (m *MockTransaction) SmartRollupCommitments(ctx context.Context, commitments ...*smartrollup.Commitment) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range commitments {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SmartRollupCommitments", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SmartRollupCommitments indicates an expected call of SmartRollupCommitments.
+func (mr *MockTransactionMockRecorder) SmartRollupCommitments(ctx any, commitments ...any) *TransactionSmartRollupCommitmentsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, commitments...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SmartRollupCommitments", reflect.TypeOf((*MockTransaction)(nil).SmartRollupCommitments), varargs...)
+	return &TransactionSmartRollupCommitmentsCall{Call: call}
+}
+
+// TransactionSmartRollupCommitmentsCall wrap *gomock.Call
+type TransactionSmartRollupCommitmentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionSmartRollupCommitmentsCall) Return(arg0 error) *TransactionSmartRollupCommitmentsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionSmartRollupCommitmentsCall) Do(f func(context.Context, ...*smartrollup.Commitment) error) *TransactionSmartRollupCommitmentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionSmartRollupCommitmentsCall) DoAndReturn(f func(context.Context, ...*smartrollup.Commitment) error) *TransactionSmartRollupCommitmentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SmartRollupGameMoves mocks base method.
+func (m *MockTransaction) SmartRollupGameMoves(ctx context.Context, moves ...*smartrollup.GameMove) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range moves {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SmartRollupGameMoves", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SmartRollupGameMoves indicates an expected call of SmartRollupGameMoves.
+func (mr *MockTransactionMockRecorder) SmartRollupGameMoves(ctx any, moves ...any) *TransactionSmartRollupGameMovesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, moves...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SmartRollupGameMoves", reflect.TypeOf((*MockTransaction)(nil).SmartRollupGameMoves), varargs...)
+	return &TransactionSmartRollupGameMovesCall{Call: call}
+}
+
+// TransactionSmartRollupGameMovesCall wrap *gomock.Call
+type TransactionSmartRollupGameMovesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionSmartRollupGameMovesCall) Return(arg0 error) *TransactionSmartRollupGameMovesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionSmartRollupGameMovesCall) Do(f func(context.Context, ...*smartrollup.GameMove) error) *TransactionSmartRollupGameMovesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionSmartRollupGameMovesCall) DoAndReturn(f func(context.Context, ...*smartrollup.GameMove) error) *TransactionSmartRollupGameMovesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SmartRollupMessages mocks base method.
+func (m *MockTransaction) SmartRollupMessages(ctx context.Context, messages ...*smartrollup.Message) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range messages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SmartRollupMessages", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SmartRollupMessages indicates an expected call of SmartRollupMessages.
+func (mr *MockTransactionMockRecorder) SmartRollupMessages(ctx any, messages ...any) *TransactionSmartRollupMessagesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, messages...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SmartRollupMessages", reflect.TypeOf((*MockTransaction)(nil).SmartRollupMessages), varargs...)
+	return &TransactionSmartRollupMessagesCall{Call: call}
+}
+
+// TransactionSmartRollupMessagesCall wrap *gomock.Call
+type TransactionSmartRollupMessagesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionSmartRollupMessagesCall) Return(arg0 error) *TransactionSmartRollupMessagesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionSmartRollupMessagesCall) Do(f func(context.Context, ...*smartrollup.Message) error) *TransactionSmartRollupMessagesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionSmartRollupMessagesCall) DoAndReturn(f func(context.Context, ...*smartrollup.Message) error) *TransactionSmartRollupMessagesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SmartRollupOutbox mocks base method.
+func (m *MockTransaction) SmartRollupOutbox(ctx context.Context, transactions ...*smartrollup.OutboxTransaction) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range transactions {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SmartRollupOutbox", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SmartRollupOutbox indicates an expected call of SmartRollupOutbox.
+func (mr *MockTransactionMockRecorder) SmartRollupOutbox(ctx any, transactions ...any) *TransactionSmartRollupOutboxCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, transactions...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SmartRollupOutbox", reflect.TypeOf((*MockTransaction)(nil).SmartRollupOutbox), varargs...)
+	return &TransactionSmartRollupOutboxCall{Call: call}
+}
+
+// TransactionSmartRollupOutboxCall wrap *gomock.Call
+type TransactionSmartRollupOutboxCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionSmartRollupOutboxCall) Return(arg0 error) *TransactionSmartRollupOutboxCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionSmartRollupOutboxCall) Do(f func(context.Context, ...*smartrollup.OutboxTransaction) error) *TransactionSmartRollupOutboxCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionSmartRollupOutboxCall) DoAndReturn(f func(context.Context, ...*smartrollup.OutboxTransaction) error) *TransactionSmartRollupOutboxCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SmartRollupRecoveries mocks base method.
+func (m *MockTransaction) SmartRollupRecoveries(ctx context.Context, recoveries ...*smartrollup.BondRecovery) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range recoveries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SmartRollupRecoveries", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SmartRollupRecoveries indicates an expected call of SmartRollupRecoveries.
+func (mr *MockTransactionMockRecorder) SmartRollupRecoveries(ctx any, recoveries ...any) *TransactionSmartRollupRecoveriesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, recoveries...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SmartRollupRecoveries", reflect.TypeOf((*MockTransaction)(nil).SmartRollupRecoveries), varargs...)
+	return &TransactionSmartRollupRecoveriesCall{Call: call}
+}
+
+// TransactionSmartRollupRecoveriesCall wrap *gomock.Call
+type TransactionSmartRollupRecoveriesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionSmartRollupRecoveriesCall) Return(arg0 error) *TransactionSmartRollupRecoveriesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionSmartRollupRecoveriesCall) Do(f func(context.Context, ...*smartrollup.BondRecovery) error) *TransactionSmartRollupRecoveriesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionSmartRollupRecoveriesCall) DoAndReturn(f func(context.Context, ...*smartrollup.BondRecovery) error) *TransactionSmartRollupRecoveriesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SmartRollups mocks base method.
 func (m *MockTransaction) SmartRollups(ctx context.Context, rollups ...*smartrollup.SmartRollup) error {
 	m.ctrl.T.Helper()
@@ -1322,6 +1627,135 @@ func (c *TransactionToJakartaCall) DoAndReturn(f func(context.Context) error) *T
 	return c
 }
 
+// TokenBalances mocks base method.
+func (m *MockTransaction) TokenBalances(ctx context.Context, balances ...*token.Balance) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range balances {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TokenBalances", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TokenBalances indicates an expected call of TokenBalances.
+func (mr *MockTransactionMockRecorder) TokenBalances(ctx any, balances ...any) *TransactionTokenBalancesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, balances...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenBalances", reflect.TypeOf((*MockTransaction)(nil).TokenBalances), varargs...)
+	return &TransactionTokenBalancesCall{Call: call}
+}
+
+// TransactionTokenBalancesCall wrap *gomock.Call
+type TransactionTokenBalancesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionTokenBalancesCall) Return(arg0 error) *TransactionTokenBalancesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionTokenBalancesCall) Do(f func(context.Context, ...*token.Balance) error) *TransactionTokenBalancesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionTokenBalancesCall) DoAndReturn(f func(context.Context, ...*token.Balance) error) *TransactionTokenBalancesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// TokenMetadata mocks base method.
+func (m *MockTransaction) TokenMetadata(ctx context.Context, metadata ...*metadata.TokenMetadata) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range metadata {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TokenMetadata", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TokenMetadata indicates an expected call of TokenMetadata.
+func (mr *MockTransactionMockRecorder) TokenMetadata(ctx any, metadata ...any) *TransactionTokenMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, metadata...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenMetadata", reflect.TypeOf((*MockTransaction)(nil).TokenMetadata), varargs...)
+	return &TransactionTokenMetadataCall{Call: call}
+}
+
+// TransactionTokenMetadataCall wrap *gomock.Call
+type TransactionTokenMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionTokenMetadataCall) Return(arg0 error) *TransactionTokenMetadataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionTokenMetadataCall) Do(f func(context.Context, ...*metadata.TokenMetadata) error) *TransactionTokenMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionTokenMetadataCall) DoAndReturn(f func(context.Context, ...*metadata.TokenMetadata) error) *TransactionTokenMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// TokenTransfers mocks base method.
+func (m *MockTransaction) TokenTransfers(ctx context.Context, transfers ...*token.Transfer) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range transfers {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TokenTransfers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TokenTransfers indicates an expected call of TokenTransfers.
+func (mr *MockTransactionMockRecorder) TokenTransfers(ctx any, transfers ...any) *TransactionTokenTransfersCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, transfers...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenTransfers", reflect.TypeOf((*MockTransaction)(nil).TokenTransfers), varargs...)
+	return &TransactionTokenTransfersCall{Call: call}
+}
+
+// TransactionTokenTransfersCall wrap *gomock.Call
+type TransactionTokenTransfersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionTokenTransfersCall) Return(arg0 error) *TransactionTokenTransfersCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionTokenTransfersCall) Do(f func(context.Context, ...*token.Transfer) error) *TransactionTokenTransfersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionTokenTransfersCall) DoAndReturn(f func(context.Context, ...*token.Transfer) error) *TransactionTokenTransfersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // UpdateStats mocks base method.
 func (m *MockTransaction) UpdateStats(ctx context.Context, stats stats.Stats) error {
 	m.ctrl.T.Helper()
@@ -1359,3 +1793,46 @@ func (c *TransactionUpdateStatsCall) DoAndReturn(f func(context.Context, stats.S
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// WebhookDeliveries mocks base method.
+func (m *MockTransaction) WebhookDeliveries(ctx context.Context, deliveries ...*webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range deliveries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WebhookDeliveries", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WebhookDeliveries indicates an expected call of WebhookDeliveries.
+func (mr *MockTransactionMockRecorder) WebhookDeliveries(ctx any, deliveries ...any) *TransactionWebhookDeliveriesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, deliveries...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookDeliveries", reflect.TypeOf((*MockTransaction)(nil).WebhookDeliveries), varargs...)
+	return &TransactionWebhookDeliveriesCall{Call: call}
+}
+
+// TransactionWebhookDeliveriesCall wrap *gomock.Call
+type TransactionWebhookDeliveriesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TransactionWebhookDeliveriesCall) Return(arg0 error) *TransactionWebhookDeliveriesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TransactionWebhookDeliveriesCall) Do(f func(context.Context, ...*webhook.Delivery) error) *TransactionWebhookDeliveriesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TransactionWebhookDeliveriesCall) DoAndReturn(f func(context.Context, ...*webhook.Delivery) error) *TransactionWebhookDeliveriesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,272 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../mock/metadata/mock.go -package=metadata -typed
+//
+// Package metadata is a generated GoMock package.
+package metadata
+
+import (
+	context "context"
+	reflect "reflect"
+
+	metadata "github.com/baking-bad/bcdhub/internal/models/metadata"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ContractMetadata mocks base method.
+func (m *MockRepository) ContractMetadata(ctx context.Context, contract string) (metadata.ContractMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractMetadata", ctx, contract)
+	ret0, _ := ret[0].(metadata.ContractMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContractMetadata indicates an expected call of ContractMetadata.
+func (mr *MockRepositoryMockRecorder) ContractMetadata(ctx, contract any) *RepositoryContractMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractMetadata", reflect.TypeOf((*MockRepository)(nil).ContractMetadata), ctx, contract)
+	return &RepositoryContractMetadataCall{Call: call}
+}
+
+// RepositoryContractMetadataCall wrap *gomock.Call
+type RepositoryContractMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryContractMetadataCall) Return(arg0 metadata.ContractMetadata, arg1 error) *RepositoryContractMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryContractMetadataCall) Do(f func(context.Context, string) (metadata.ContractMetadata, error)) *RepositoryContractMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryContractMetadataCall) DoAndReturn(f func(context.Context, string) (metadata.ContractMetadata, error)) *RepositoryContractMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PendingContractMetadata mocks base method.
+func (m *MockRepository) PendingContractMetadata(ctx context.Context, limit int) ([]metadata.ContractMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingContractMetadata", ctx, limit)
+	ret0, _ := ret[0].([]metadata.ContractMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingContractMetadata indicates an expected call of PendingContractMetadata.
+func (mr *MockRepositoryMockRecorder) PendingContractMetadata(ctx, limit any) *RepositoryPendingContractMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingContractMetadata", reflect.TypeOf((*MockRepository)(nil).PendingContractMetadata), ctx, limit)
+	return &RepositoryPendingContractMetadataCall{Call: call}
+}
+
+// RepositoryPendingContractMetadataCall wrap *gomock.Call
+type RepositoryPendingContractMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryPendingContractMetadataCall) Return(arg0 []metadata.ContractMetadata, arg1 error) *RepositoryPendingContractMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryPendingContractMetadataCall) Do(f func(context.Context, int) ([]metadata.ContractMetadata, error)) *RepositoryPendingContractMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryPendingContractMetadataCall) DoAndReturn(f func(context.Context, int) ([]metadata.ContractMetadata, error)) *RepositoryPendingContractMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PendingTokenMetadata mocks base method.
+func (m *MockRepository) PendingTokenMetadata(ctx context.Context, limit int) ([]metadata.TokenMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingTokenMetadata", ctx, limit)
+	ret0, _ := ret[0].([]metadata.TokenMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingTokenMetadata indicates an expected call of PendingTokenMetadata.
+func (mr *MockRepositoryMockRecorder) PendingTokenMetadata(ctx, limit any) *RepositoryPendingTokenMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingTokenMetadata", reflect.TypeOf((*MockRepository)(nil).PendingTokenMetadata), ctx, limit)
+	return &RepositoryPendingTokenMetadataCall{Call: call}
+}
+
+// RepositoryPendingTokenMetadataCall wrap *gomock.Call
+type RepositoryPendingTokenMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryPendingTokenMetadataCall) Return(arg0 []metadata.TokenMetadata, arg1 error) *RepositoryPendingTokenMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryPendingTokenMetadataCall) Do(f func(context.Context, int) ([]metadata.TokenMetadata, error)) *RepositoryPendingTokenMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryPendingTokenMetadataCall) DoAndReturn(f func(context.Context, int) ([]metadata.TokenMetadata, error)) *RepositoryPendingTokenMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// TokenMetadata mocks base method.
+func (m *MockRepository) TokenMetadata(ctx context.Context, contract string, req metadata.TokenMetadataRequest) ([]metadata.TokenMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenMetadata", ctx, contract, req)
+	ret0, _ := ret[0].([]metadata.TokenMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenMetadata indicates an expected call of TokenMetadata.
+func (mr *MockRepositoryMockRecorder) TokenMetadata(ctx, contract, req any) *RepositoryTokenMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenMetadata", reflect.TypeOf((*MockRepository)(nil).TokenMetadata), ctx, contract, req)
+	return &RepositoryTokenMetadataCall{Call: call}
+}
+
+// RepositoryTokenMetadataCall wrap *gomock.Call
+type RepositoryTokenMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryTokenMetadataCall) Return(arg0 []metadata.TokenMetadata, arg1 error) *RepositoryTokenMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryTokenMetadataCall) Do(f func(context.Context, string, metadata.TokenMetadataRequest) ([]metadata.TokenMetadata, error)) *RepositoryTokenMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryTokenMetadataCall) DoAndReturn(f func(context.Context, string, metadata.TokenMetadataRequest) ([]metadata.TokenMetadata, error)) *RepositoryTokenMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateContractMetadata mocks base method.
+func (m_2 *MockRepository) UpdateContractMetadata(ctx context.Context, m *metadata.ContractMetadata) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateContractMetadata", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContractMetadata indicates an expected call of UpdateContractMetadata.
+func (mr *MockRepositoryMockRecorder) UpdateContractMetadata(ctx, m any) *RepositoryUpdateContractMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContractMetadata", reflect.TypeOf((*MockRepository)(nil).UpdateContractMetadata), ctx, m)
+	return &RepositoryUpdateContractMetadataCall{Call: call}
+}
+
+// RepositoryUpdateContractMetadataCall wrap *gomock.Call
+type RepositoryUpdateContractMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryUpdateContractMetadataCall) Return(arg0 error) *RepositoryUpdateContractMetadataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryUpdateContractMetadataCall) Do(f func(context.Context, *metadata.ContractMetadata) error) *RepositoryUpdateContractMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryUpdateContractMetadataCall) DoAndReturn(f func(context.Context, *metadata.ContractMetadata) error) *RepositoryUpdateContractMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateTokenMetadata mocks base method.
+func (m_2 *MockRepository) UpdateTokenMetadata(ctx context.Context, m *metadata.TokenMetadata) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateTokenMetadata", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTokenMetadata indicates an expected call of UpdateTokenMetadata.
+func (mr *MockRepositoryMockRecorder) UpdateTokenMetadata(ctx, m any) *RepositoryUpdateTokenMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTokenMetadata", reflect.TypeOf((*MockRepository)(nil).UpdateTokenMetadata), ctx, m)
+	return &RepositoryUpdateTokenMetadataCall{Call: call}
+}
+
+// RepositoryUpdateTokenMetadataCall wrap *gomock.Call
+type RepositoryUpdateTokenMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryUpdateTokenMetadataCall) Return(arg0 error) *RepositoryUpdateTokenMetadataCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryUpdateTokenMetadataCall) Do(f func(context.Context, *metadata.TokenMetadata) error) *RepositoryUpdateTokenMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryUpdateTokenMetadataCall) DoAndReturn(f func(context.Context, *metadata.TokenMetadata) error) *RepositoryUpdateTokenMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
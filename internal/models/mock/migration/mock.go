@@ -77,3 +77,42 @@ func (c *RepositoryGetCall) DoAndReturn(f func(context.Context, int64) ([]migrat
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// LastStorage mocks base method.
+func (m *MockRepository) LastStorage(ctx context.Context, accountID, level int64) (migration.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastStorage", ctx, accountID, level)
+	ret0, _ := ret[0].(migration.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastStorage indicates an expected call of LastStorage.
+func (mr *MockRepositoryMockRecorder) LastStorage(ctx, accountID, level any) *RepositoryLastStorageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastStorage", reflect.TypeOf((*MockRepository)(nil).LastStorage), ctx, accountID, level)
+	return &RepositoryLastStorageCall{Call: call}
+}
+
+// RepositoryLastStorageCall wrap *gomock.Call
+type RepositoryLastStorageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryLastStorageCall) Return(arg0 migration.Migration, arg1 error) *RepositoryLastStorageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryLastStorageCall) Do(f func(context.Context, int64, int64) (migration.Migration, error)) *RepositoryLastStorageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryLastStorageCall) DoAndReturn(f func(context.Context, int64, int64) (migration.Migration, error)) *RepositoryLastStorageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
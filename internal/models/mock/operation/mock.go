@@ -195,6 +195,123 @@ func (c *RepositoryLastCall) DoAndReturn(f func(context.Context, map[string]any,
 	return c
 }
 
+// LastStorage mocks base method.
+func (m *MockRepository) LastStorage(ctx context.Context, accountID, level int64) (operation.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastStorage", ctx, accountID, level)
+	ret0, _ := ret[0].(operation.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastStorage indicates an expected call of LastStorage.
+func (mr *MockRepositoryMockRecorder) LastStorage(ctx, accountID, level any) *RepositoryLastStorageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastStorage", reflect.TypeOf((*MockRepository)(nil).LastStorage), ctx, accountID, level)
+	return &RepositoryLastStorageCall{Call: call}
+}
+
+// RepositoryLastStorageCall wrap *gomock.Call
+type RepositoryLastStorageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryLastStorageCall) Return(arg0 operation.Operation, arg1 error) *RepositoryLastStorageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryLastStorageCall) Do(f func(context.Context, int64, int64) (operation.Operation, error)) *RepositoryLastStorageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryLastStorageCall) DoAndReturn(f func(context.Context, int64, int64) (operation.Operation, error)) *RepositoryLastStorageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListByAccount mocks base method.
+func (m *MockRepository) ListByAccount(ctx context.Context, accountID, lastID, size int64) ([]operation.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccount", ctx, accountID, lastID, size)
+	ret0, _ := ret[0].([]operation.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccount indicates an expected call of ListByAccount.
+func (mr *MockRepositoryMockRecorder) ListByAccount(ctx, accountID, lastID, size any) *RepositoryListByAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccount", reflect.TypeOf((*MockRepository)(nil).ListByAccount), ctx, accountID, lastID, size)
+	return &RepositoryListByAccountCall{Call: call}
+}
+
+// RepositoryListByAccountCall wrap *gomock.Call
+type RepositoryListByAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryListByAccountCall) Return(arg0 []operation.Operation, arg1 error) *RepositoryListByAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryListByAccountCall) Do(f func(context.Context, int64, int64, int64) ([]operation.Operation, error)) *RepositoryListByAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryListByAccountCall) DoAndReturn(f func(context.Context, int64, int64, int64) ([]operation.Operation, error)) *RepositoryListByAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListByLevel mocks base method.
+func (m *MockRepository) ListByLevel(ctx context.Context, level int64) ([]operation.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByLevel", ctx, level)
+	ret0, _ := ret[0].([]operation.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByLevel indicates an expected call of ListByLevel.
+func (mr *MockRepositoryMockRecorder) ListByLevel(ctx, level any) *RepositoryListByLevelCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLevel", reflect.TypeOf((*MockRepository)(nil).ListByLevel), ctx, level)
+	return &RepositoryListByLevelCall{Call: call}
+}
+
+// RepositoryListByLevelCall wrap *gomock.Call
+type RepositoryListByLevelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryListByLevelCall) Return(arg0 []operation.Operation, arg1 error) *RepositoryListByLevelCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryListByLevelCall) Do(f func(context.Context, int64) ([]operation.Operation, error)) *RepositoryListByLevelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryListByLevelCall) DoAndReturn(f func(context.Context, int64) ([]operation.Operation, error)) *RepositoryListByLevelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListEvents mocks base method.
 func (m *MockRepository) ListEvents(ctx context.Context, accountID, size, offset int64) ([]operation.Operation, error) {
 	m.ctrl.T.Helper()
@@ -311,3 +428,42 @@ func (c *RepositoryOriginationCall) DoAndReturn(f func(context.Context, int64) (
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Search mocks base method.
+func (m *MockRepository) Search(ctx context.Context, req operation.SearchRequest) ([]operation.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, req)
+	ret0, _ := ret[0].([]operation.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRepositoryMockRecorder) Search(ctx, req any) *RepositorySearchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), ctx, req)
+	return &RepositorySearchCall{Call: call}
+}
+
+// RepositorySearchCall wrap *gomock.Call
+type RepositorySearchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositorySearchCall) Return(arg0 []operation.Operation, arg1 error) *RepositorySearchCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositorySearchCall) Do(f func(context.Context, operation.SearchRequest) ([]operation.Operation, error)) *RepositorySearchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositorySearchCall) DoAndReturn(f func(context.Context, operation.SearchRequest) ([]operation.Operation, error)) *RepositorySearchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -11,6 +11,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/baking-bad/bcdhub/internal/models"
 	account "github.com/baking-bad/bcdhub/internal/models/account"
@@ -20,6 +21,7 @@ import (
 	operation "github.com/baking-bad/bcdhub/internal/models/operation"
 	stats "github.com/baking-bad/bcdhub/internal/models/stats"
 	ticket "github.com/baking-bad/bcdhub/internal/models/ticket"
+	token "github.com/baking-bad/bcdhub/internal/models/token"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -437,6 +439,45 @@ func (c *RollbackGetTicketUpdatesCall) DoAndReturn(f func(context.Context, int64
 	return c
 }
 
+// GetTokenTransfers mocks base method.
+func (m *MockRollback) GetTokenTransfers(ctx context.Context, level int64) ([]token.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenTransfers", ctx, level)
+	ret0, _ := ret[0].([]token.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenTransfers indicates an expected call of GetTokenTransfers.
+func (mr *MockRollbackMockRecorder) GetTokenTransfers(ctx, level any) *RollbackGetTokenTransfersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenTransfers", reflect.TypeOf((*MockRollback)(nil).GetTokenTransfers), ctx, level)
+	return &RollbackGetTokenTransfersCall{Call: call}
+}
+
+// RollbackGetTokenTransfersCall wrap *gomock.Call
+type RollbackGetTokenTransfersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RollbackGetTokenTransfersCall) Return(arg0 []token.Transfer, arg1 error) *RollbackGetTokenTransfersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RollbackGetTokenTransfersCall) Do(f func(context.Context, int64) ([]token.Transfer, error)) *RollbackGetTokenTransfersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RollbackGetTokenTransfersCall) DoAndReturn(f func(context.Context, int64) ([]token.Transfer, error)) *RollbackGetTokenTransfersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GlobalConstants mocks base method.
 func (m *MockRollback) GlobalConstants(ctx context.Context, level int64) ([]contract.GlobalConstant, error) {
 	m.ctrl.T.Helper()
@@ -553,6 +594,83 @@ func (c *RollbackProtocolsCall) DoAndReturn(f func(context.Context, int64) error
 	return c
 }
 
+// RefreshAggregates mocks base method.
+func (m *MockRollback) RefreshAggregates(ctx context.Context, from time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAggregates", ctx, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshAggregates indicates an expected call of RefreshAggregates.
+func (mr *MockRollbackMockRecorder) RefreshAggregates(ctx, from any) *RollbackRefreshAggregatesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAggregates", reflect.TypeOf((*MockRollback)(nil).RefreshAggregates), ctx, from)
+	return &RollbackRefreshAggregatesCall{Call: call}
+}
+
+// RollbackRefreshAggregatesCall wrap *gomock.Call
+type RollbackRefreshAggregatesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RollbackRefreshAggregatesCall) Return(arg0 error) *RollbackRefreshAggregatesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RollbackRefreshAggregatesCall) Do(f func(context.Context, time.Time) error) *RollbackRefreshAggregatesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RollbackRefreshAggregatesCall) DoAndReturn(f func(context.Context, time.Time) error) *RollbackRefreshAggregatesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevertWebhookDeliveries mocks base method.
+func (m *MockRollback) RevertWebhookDeliveries(ctx context.Context, level int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertWebhookDeliveries", ctx, level)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertWebhookDeliveries indicates an expected call of RevertWebhookDeliveries.
+func (mr *MockRollbackMockRecorder) RevertWebhookDeliveries(ctx, level any) *RollbackRevertWebhookDeliveriesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertWebhookDeliveries", reflect.TypeOf((*MockRollback)(nil).RevertWebhookDeliveries), ctx, level)
+	return &RollbackRevertWebhookDeliveriesCall{Call: call}
+}
+
+// RollbackRevertWebhookDeliveriesCall wrap *gomock.Call
+type RollbackRevertWebhookDeliveriesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RollbackRevertWebhookDeliveriesCall) Return(arg0 int, arg1 error) *RollbackRevertWebhookDeliveriesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RollbackRevertWebhookDeliveriesCall) Do(f func(context.Context, int64) (int, error)) *RollbackRevertWebhookDeliveriesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RollbackRevertWebhookDeliveriesCall) DoAndReturn(f func(context.Context, int64) (int, error)) *RollbackRevertWebhookDeliveriesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Rollback mocks base method.
 func (m *MockRollback) Rollback() error {
 	m.ctrl.T.Helper()
@@ -750,6 +868,49 @@ func (c *RollbackTicketBalancesCall) DoAndReturn(f func(context.Context, ...*tic
 	return c
 }
 
+// TokenBalances mocks base method.
+func (m *MockRollback) TokenBalances(ctx context.Context, balances ...*token.Balance) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range balances {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TokenBalances", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TokenBalances indicates an expected call of TokenBalances.
+func (mr *MockRollbackMockRecorder) TokenBalances(ctx any, balances ...any) *RollbackTokenBalancesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, balances...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenBalances", reflect.TypeOf((*MockRollback)(nil).TokenBalances), varargs...)
+	return &RollbackTokenBalancesCall{Call: call}
+}
+
+// RollbackTokenBalancesCall wrap *gomock.Call
+type RollbackTokenBalancesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RollbackTokenBalancesCall) Return(arg0 error) *RollbackTokenBalancesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RollbackTokenBalancesCall) Do(f func(context.Context, ...*token.Balance) error) *RollbackTokenBalancesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RollbackTokenBalancesCall) DoAndReturn(f func(context.Context, ...*token.Balance) error) *RollbackTokenBalancesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // UpdateAccountStats mocks base method.
 func (m *MockRollback) UpdateAccountStats(ctx context.Context, account account.Account) error {
 	m.ctrl.T.Helper()
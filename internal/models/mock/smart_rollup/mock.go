@@ -39,6 +39,123 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Commitment mocks base method.
+func (m *MockRepository) Commitment(ctx context.Context, rollup, hash string) (smartrollup.Commitment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commitment", ctx, rollup, hash)
+	ret0, _ := ret[0].(smartrollup.Commitment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commitment indicates an expected call of Commitment.
+func (mr *MockRepositoryMockRecorder) Commitment(ctx, rollup, hash any) *RepositoryCommitmentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commitment", reflect.TypeOf((*MockRepository)(nil).Commitment), ctx, rollup, hash)
+	return &RepositoryCommitmentCall{Call: call}
+}
+
+// RepositoryCommitmentCall wrap *gomock.Call
+type RepositoryCommitmentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryCommitmentCall) Return(arg0 smartrollup.Commitment, arg1 error) *RepositoryCommitmentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryCommitmentCall) Do(f func(context.Context, string, string) (smartrollup.Commitment, error)) *RepositoryCommitmentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryCommitmentCall) DoAndReturn(f func(context.Context, string, string) (smartrollup.Commitment, error)) *RepositoryCommitmentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Commitments mocks base method.
+func (m *MockRepository) Commitments(ctx context.Context, rollup string, limit, offset int64) ([]smartrollup.Commitment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commitments", ctx, rollup, limit, offset)
+	ret0, _ := ret[0].([]smartrollup.Commitment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commitments indicates an expected call of Commitments.
+func (mr *MockRepositoryMockRecorder) Commitments(ctx, rollup, limit, offset any) *RepositoryCommitmentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commitments", reflect.TypeOf((*MockRepository)(nil).Commitments), ctx, rollup, limit, offset)
+	return &RepositoryCommitmentsCall{Call: call}
+}
+
+// RepositoryCommitmentsCall wrap *gomock.Call
+type RepositoryCommitmentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryCommitmentsCall) Return(arg0 []smartrollup.Commitment, arg1 error) *RepositoryCommitmentsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryCommitmentsCall) Do(f func(context.Context, string, int64, int64) ([]smartrollup.Commitment, error)) *RepositoryCommitmentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryCommitmentsCall) DoAndReturn(f func(context.Context, string, int64, int64) ([]smartrollup.Commitment, error)) *RepositoryCommitmentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Games mocks base method.
+func (m *MockRepository) Games(ctx context.Context, rollup string) ([]smartrollup.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Games", ctx, rollup)
+	ret0, _ := ret[0].([]smartrollup.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Games indicates an expected call of Games.
+func (mr *MockRepositoryMockRecorder) Games(ctx, rollup any) *RepositoryGamesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Games", reflect.TypeOf((*MockRepository)(nil).Games), ctx, rollup)
+	return &RepositoryGamesCall{Call: call}
+}
+
+// RepositoryGamesCall wrap *gomock.Call
+type RepositoryGamesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryGamesCall) Return(arg0 []smartrollup.Game, arg1 error) *RepositoryGamesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryGamesCall) Do(f func(context.Context, string) ([]smartrollup.Game, error)) *RepositoryGamesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryGamesCall) DoAndReturn(f func(context.Context, string) ([]smartrollup.Game, error)) *RepositoryGamesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Get mocks base method.
 func (m *MockRepository) Get(ctx context.Context, address string) (smartrollup.SmartRollup, error) {
 	m.ctrl.T.Helper()
@@ -116,3 +233,120 @@ func (c *RepositoryListCall) DoAndReturn(f func(context.Context, int64, int64, s
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Messages mocks base method.
+func (m *MockRepository) Messages(ctx context.Context, rollup string, limit, offset int64) ([]smartrollup.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Messages", ctx, rollup, limit, offset)
+	ret0, _ := ret[0].([]smartrollup.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Messages indicates an expected call of Messages.
+func (mr *MockRepositoryMockRecorder) Messages(ctx, rollup, limit, offset any) *RepositoryMessagesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Messages", reflect.TypeOf((*MockRepository)(nil).Messages), ctx, rollup, limit, offset)
+	return &RepositoryMessagesCall{Call: call}
+}
+
+// RepositoryMessagesCall wrap *gomock.Call
+type RepositoryMessagesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryMessagesCall) Return(arg0 []smartrollup.Message, arg1 error) *RepositoryMessagesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryMessagesCall) Do(f func(context.Context, string, int64, int64) ([]smartrollup.Message, error)) *RepositoryMessagesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryMessagesCall) DoAndReturn(f func(context.Context, string, int64, int64) ([]smartrollup.Message, error)) *RepositoryMessagesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// OutboxTransactions mocks base method.
+func (m *MockRepository) OutboxTransactions(ctx context.Context, rollup string, limit, offset int64) ([]smartrollup.OutboxTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxTransactions", ctx, rollup, limit, offset)
+	ret0, _ := ret[0].([]smartrollup.OutboxTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxTransactions indicates an expected call of OutboxTransactions.
+func (mr *MockRepositoryMockRecorder) OutboxTransactions(ctx, rollup, limit, offset any) *RepositoryOutboxTransactionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxTransactions", reflect.TypeOf((*MockRepository)(nil).OutboxTransactions), ctx, rollup, limit, offset)
+	return &RepositoryOutboxTransactionsCall{Call: call}
+}
+
+// RepositoryOutboxTransactionsCall wrap *gomock.Call
+type RepositoryOutboxTransactionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryOutboxTransactionsCall) Return(arg0 []smartrollup.OutboxTransaction, arg1 error) *RepositoryOutboxTransactionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryOutboxTransactionsCall) Do(f func(context.Context, string, int64, int64) ([]smartrollup.OutboxTransaction, error)) *RepositoryOutboxTransactionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryOutboxTransactionsCall) DoAndReturn(f func(context.Context, string, int64, int64) ([]smartrollup.OutboxTransaction, error)) *RepositoryOutboxTransactionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Stakers mocks base method.
+func (m *MockRepository) Stakers(ctx context.Context, rollup string) ([]smartrollup.Staker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stakers", ctx, rollup)
+	ret0, _ := ret[0].([]smartrollup.Staker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stakers indicates an expected call of Stakers.
+func (mr *MockRepositoryMockRecorder) Stakers(ctx, rollup any) *RepositoryStakersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stakers", reflect.TypeOf((*MockRepository)(nil).Stakers), ctx, rollup)
+	return &RepositoryStakersCall{Call: call}
+}
+
+// RepositoryStakersCall wrap *gomock.Call
+type RepositoryStakersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryStakersCall) Return(arg0 []smartrollup.Staker, arg1 error) *RepositoryStakersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryStakersCall) Do(f func(context.Context, string) ([]smartrollup.Staker, error)) *RepositoryStakersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryStakersCall) DoAndReturn(f func(context.Context, string) ([]smartrollup.Staker, error)) *RepositoryStakersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -194,3 +194,42 @@ func (c *RepositoryUpdatesForOperationCall) DoAndReturn(f func(context.Context,
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// UpdatesForOperations mocks base method.
+func (m *MockRepository) UpdatesForOperations(ctx context.Context, ids []int64) ([]ticket.TicketUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatesForOperations", ctx, ids)
+	ret0, _ := ret[0].([]ticket.TicketUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatesForOperations indicates an expected call of UpdatesForOperations.
+func (mr *MockRepositoryMockRecorder) UpdatesForOperations(ctx, ids any) *RepositoryUpdatesForOperationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatesForOperations", reflect.TypeOf((*MockRepository)(nil).UpdatesForOperations), ctx, ids)
+	return &RepositoryUpdatesForOperationsCall{Call: call}
+}
+
+// RepositoryUpdatesForOperationsCall wrap *gomock.Call
+type RepositoryUpdatesForOperationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryUpdatesForOperationsCall) Return(arg0 []ticket.TicketUpdate, arg1 error) *RepositoryUpdatesForOperationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryUpdatesForOperationsCall) Do(f func(context.Context, []int64) ([]ticket.TicketUpdate, error)) *RepositoryUpdatesForOperationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryUpdatesForOperationsCall) DoAndReturn(f func(context.Context, []int64) ([]ticket.TicketUpdate, error)) *RepositoryUpdatesForOperationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
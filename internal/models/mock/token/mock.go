@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../mock/token/mock.go -package=token -typed
+//
+// Package token is a generated GoMock package.
+package token
+
+import (
+	context "context"
+	reflect "reflect"
+
+	token "github.com/baking-bad/bcdhub/internal/models/token"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Balances mocks base method.
+func (m *MockRepository) Balances(ctx context.Context, contract string, req token.BalanceRequest) ([]token.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balances", ctx, contract, req)
+	ret0, _ := ret[0].([]token.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balances indicates an expected call of Balances.
+func (mr *MockRepositoryMockRecorder) Balances(ctx, contract, req any) *RepositoryBalancesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balances", reflect.TypeOf((*MockRepository)(nil).Balances), ctx, contract, req)
+	return &RepositoryBalancesCall{Call: call}
+}
+
+// RepositoryBalancesCall wrap *gomock.Call
+type RepositoryBalancesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryBalancesCall) Return(arg0 []token.Balance, arg1 error) *RepositoryBalancesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryBalancesCall) Do(f func(context.Context, string, token.BalanceRequest) ([]token.Balance, error)) *RepositoryBalancesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryBalancesCall) DoAndReturn(f func(context.Context, string, token.BalanceRequest) ([]token.Balance, error)) *RepositoryBalancesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Transfers mocks base method.
+func (m *MockRepository) Transfers(ctx context.Context, contract string, req token.TransfersRequest) ([]token.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfers", ctx, contract, req)
+	ret0, _ := ret[0].([]token.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfers indicates an expected call of Transfers.
+func (mr *MockRepositoryMockRecorder) Transfers(ctx, contract, req any) *RepositoryTransfersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfers", reflect.TypeOf((*MockRepository)(nil).Transfers), ctx, contract, req)
+	return &RepositoryTransfersCall{Call: call}
+}
+
+// RepositoryTransfersCall wrap *gomock.Call
+type RepositoryTransfersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryTransfersCall) Return(arg0 []token.Transfer, arg1 error) *RepositoryTransfersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryTransfersCall) Do(f func(context.Context, string, token.TransfersRequest) ([]token.Transfer, error)) *RepositoryTransfersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryTransfersCall) DoAndReturn(f func(context.Context, string, token.TransfersRequest) ([]token.Transfer, error)) *RepositoryTransfersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// TransfersForOperation mocks base method.
+func (m *MockRepository) TransfersForOperation(ctx context.Context, operationId int64) ([]token.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransfersForOperation", ctx, operationId)
+	ret0, _ := ret[0].([]token.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransfersForOperation indicates an expected call of TransfersForOperation.
+func (mr *MockRepositoryMockRecorder) TransfersForOperation(ctx, operationId any) *RepositoryTransfersForOperationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransfersForOperation", reflect.TypeOf((*MockRepository)(nil).TransfersForOperation), ctx, operationId)
+	return &RepositoryTransfersForOperationCall{Call: call}
+}
+
+// RepositoryTransfersForOperationCall wrap *gomock.Call
+type RepositoryTransfersForOperationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryTransfersForOperationCall) Return(arg0 []token.Transfer, arg1 error) *RepositoryTransfersForOperationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryTransfersForOperationCall) Do(f func(context.Context, int64) ([]token.Transfer, error)) *RepositoryTransfersForOperationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryTransfersForOperationCall) DoAndReturn(f func(context.Context, int64) ([]token.Transfer, error)) *RepositoryTransfersForOperationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
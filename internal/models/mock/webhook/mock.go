@@ -0,0 +1,426 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../mock/webhook/mock.go -package=webhook -typed
+//
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	webhook "github.com/baking-bad/bcdhub/internal/models/webhook"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, hook *webhook.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, hook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, hook any) *RepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, hook)
+	return &RepositoryCreateCall{Call: call}
+}
+
+// RepositoryCreateCall wrap *gomock.Call
+type RepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryCreateCall) Return(arg0 error) *RepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryCreateCall) Do(f func(context.Context, *webhook.Webhook) error) *RepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryCreateCall) DoAndReturn(f func(context.Context, *webhook.Webhook) error) *RepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeadLetter mocks base method.
+func (m *MockRepository) DeadLetter(ctx context.Context, delivery *webhook.Delivery, failedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetter", ctx, delivery, failedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeadLetter indicates an expected call of DeadLetter.
+func (mr *MockRepositoryMockRecorder) DeadLetter(ctx, delivery, failedAt any) *RepositoryDeadLetterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetter", reflect.TypeOf((*MockRepository)(nil).DeadLetter), ctx, delivery, failedAt)
+	return &RepositoryDeadLetterCall{Call: call}
+}
+
+// RepositoryDeadLetterCall wrap *gomock.Call
+type RepositoryDeadLetterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryDeadLetterCall) Return(arg0 error) *RepositoryDeadLetterCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryDeadLetterCall) Do(f func(context.Context, *webhook.Delivery, time.Time) error) *RepositoryDeadLetterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryDeadLetterCall) DoAndReturn(f func(context.Context, *webhook.Delivery, time.Time) error) *RepositoryDeadLetterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeadLetters mocks base method.
+func (m *MockRepository) DeadLetters(ctx context.Context, webhookID, limit, offset int64) ([]webhook.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters", ctx, webhookID, limit, offset)
+	ret0, _ := ret[0].([]webhook.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockRepositoryMockRecorder) DeadLetters(ctx, webhookID, limit, offset any) *RepositoryDeadLettersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockRepository)(nil).DeadLetters), ctx, webhookID, limit, offset)
+	return &RepositoryDeadLettersCall{Call: call}
+}
+
+// RepositoryDeadLettersCall wrap *gomock.Call
+type RepositoryDeadLettersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryDeadLettersCall) Return(arg0 []webhook.DeadLetter, arg1 error) *RepositoryDeadLettersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryDeadLettersCall) Do(f func(context.Context, int64, int64, int64) ([]webhook.DeadLetter, error)) *RepositoryDeadLettersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryDeadLettersCall) DoAndReturn(f func(context.Context, int64, int64, int64) ([]webhook.DeadLetter, error)) *RepositoryDeadLettersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *RepositoryDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+	return &RepositoryDeleteCall{Call: call}
+}
+
+// RepositoryDeleteCall wrap *gomock.Call
+type RepositoryDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryDeleteCall) Return(arg0 error) *RepositoryDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryDeleteCall) Do(f func(context.Context, int64) error) *RepositoryDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryDeleteCall) DoAndReturn(f func(context.Context, int64) error) *RepositoryDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delivered mocks base method.
+func (m *MockRepository) Delivered(ctx context.Context, delivery *webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delivered", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delivered indicates an expected call of Delivered.
+func (mr *MockRepositoryMockRecorder) Delivered(ctx, delivery any) *RepositoryDeliveredCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delivered", reflect.TypeOf((*MockRepository)(nil).Delivered), ctx, delivery)
+	return &RepositoryDeliveredCall{Call: call}
+}
+
+// RepositoryDeliveredCall wrap *gomock.Call
+type RepositoryDeliveredCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryDeliveredCall) Return(arg0 error) *RepositoryDeliveredCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryDeliveredCall) Do(f func(context.Context, *webhook.Delivery) error) *RepositoryDeliveredCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryDeliveredCall) DoAndReturn(f func(context.Context, *webhook.Delivery) error) *RepositoryDeliveredCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(ctx context.Context, id int64) (webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(ctx, id any) *RepositoryGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, id)
+	return &RepositoryGetCall{Call: call}
+}
+
+// RepositoryGetCall wrap *gomock.Call
+type RepositoryGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryGetCall) Return(arg0 webhook.Webhook, arg1 error) *RepositoryGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryGetCall) Do(f func(context.Context, int64) (webhook.Webhook, error)) *RepositoryGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryGetCall) DoAndReturn(f func(context.Context, int64) (webhook.Webhook, error)) *RepositoryGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context) ([]webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx any) *RepositoryListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx)
+	return &RepositoryListCall{Call: call}
+}
+
+// RepositoryListCall wrap *gomock.Call
+type RepositoryListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryListCall) Return(arg0 []webhook.Webhook, arg1 error) *RepositoryListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryListCall) Do(f func(context.Context) ([]webhook.Webhook, error)) *RepositoryListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryListCall) DoAndReturn(f func(context.Context) ([]webhook.Webhook, error)) *RepositoryListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListByOwner mocks base method.
+func (m *MockRepository) ListByOwner(ctx context.Context, owner string) ([]webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOwner", ctx, owner)
+	ret0, _ := ret[0].([]webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOwner indicates an expected call of ListByOwner.
+func (mr *MockRepositoryMockRecorder) ListByOwner(ctx, owner any) *RepositoryListByOwnerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOwner", reflect.TypeOf((*MockRepository)(nil).ListByOwner), ctx, owner)
+	return &RepositoryListByOwnerCall{Call: call}
+}
+
+// RepositoryListByOwnerCall wrap *gomock.Call
+type RepositoryListByOwnerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryListByOwnerCall) Return(arg0 []webhook.Webhook, arg1 error) *RepositoryListByOwnerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryListByOwnerCall) Do(f func(context.Context, string) ([]webhook.Webhook, error)) *RepositoryListByOwnerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryListByOwnerCall) DoAndReturn(f func(context.Context, string) ([]webhook.Webhook, error)) *RepositoryListByOwnerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Pending mocks base method.
+func (m *MockRepository) Pending(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, now, limit)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockRepositoryMockRecorder) Pending(ctx, now, limit any) *RepositoryPendingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockRepository)(nil).Pending), ctx, now, limit)
+	return &RepositoryPendingCall{Call: call}
+}
+
+// RepositoryPendingCall wrap *gomock.Call
+type RepositoryPendingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryPendingCall) Return(arg0 []webhook.Delivery, arg1 error) *RepositoryPendingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryPendingCall) Do(f func(context.Context, time.Time, int) ([]webhook.Delivery, error)) *RepositoryPendingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryPendingCall) DoAndReturn(f func(context.Context, time.Time, int) ([]webhook.Delivery, error)) *RepositoryPendingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Retry mocks base method.
+func (m *MockRepository) Retry(ctx context.Context, delivery *webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockRepositoryMockRecorder) Retry(ctx, delivery any) *RepositoryRetryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockRepository)(nil).Retry), ctx, delivery)
+	return &RepositoryRetryCall{Call: call}
+}
+
+// RepositoryRetryCall wrap *gomock.Call
+type RepositoryRetryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *RepositoryRetryCall) Return(arg0 error) *RepositoryRetryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *RepositoryRetryCall) Do(f func(context.Context, *webhook.Delivery) error) *RepositoryRetryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *RepositoryRetryCall) DoAndReturn(f func(context.Context, *webhook.Delivery) error) *RepositoryRetryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/uptrace/bun"
 )
@@ -65,9 +67,16 @@ type Operation struct {
 
 	AST *ast.Script `bun:"-"`
 
-	BigMapDiffs   []*bigmapdiff.BigMapDiff     `bun:"rel:has-many"`
-	BigMapActions []*bigmapaction.BigMapAction `bun:"rel:has-many"`
-	TicketUpdates []*ticket.TicketUpdate       `bun:"rel:has-many"`
+	BigMapDiffs    []*bigmapdiff.BigMapDiff     `bun:"rel:has-many"`
+	BigMapActions  []*bigmapaction.BigMapAction `bun:"rel:has-many"`
+	TicketUpdates  []*ticket.TicketUpdate       `bun:"rel:has-many"`
+	TokenTransfers []*token.Transfer            `bun:"rel:has-many"`
+
+	SmartRollupCommitments []*smartrollup.Commitment        `bun:"rel:has-many"`
+	SmartRollupGameMoves   []*smartrollup.GameMove          `bun:"rel:has-many"`
+	SmartRollupRecoveries  []*smartrollup.BondRecovery      `bun:"rel:has-many"`
+	SmartRollupMessages    []*smartrollup.Message           `bun:"rel:has-many"`
+	SmartRollupOutbox      []*smartrollup.OutboxTransaction `bun:"rel:has-many"`
 
 	AllocatedDestinationContract bool
 	Internal                     bool
@@ -2,8 +2,32 @@ package operation
 
 import (
 	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/types"
 )
 
+// SearchRequest - filters of operations search. Empty fields are ignored.
+type SearchRequest struct {
+	Kinds       []types.OperationKind
+	Statuses    []types.OperationStatus
+	Entrypoint  string
+	Source      string
+	Destination string
+	Tag         string
+	MinLevel    int64
+	MaxLevel    int64
+	From        time.Time
+	To          time.Time
+	MinAmount   int64
+	ErrorID     string
+
+	// LastTimestamp and LastID are the key of the last operation of the previous page
+	LastTimestamp time.Time
+	LastID        int64
+	Size          int64
+}
+
 //go:generate mockgen -source=$GOFILE -destination=../mock/operation/mock.go -package=operation -typed
 type Repository interface {
 	Last(ctx context.Context, filter map[string]interface{}, lastID int64) (Operation, error)
@@ -13,4 +37,12 @@ type Repository interface {
 	Origination(ctx context.Context, accountID int64) (Operation, error)
 	GetByID(ctx context.Context, id int64) (Operation, error)
 	ListEvents(ctx context.Context, accountID int64, size, offset int64) ([]Operation, error)
+	ListByLevel(ctx context.Context, level int64) ([]Operation, error)
+
+	// ListByAccount - returns operations where account is source or destination ordered by id descending. If `lastID` is positive only operations with lower id are returned.
+	ListByAccount(ctx context.Context, accountID int64, lastID, size int64) ([]Operation, error)
+	Search(ctx context.Context, req SearchRequest) ([]Operation, error)
+
+	// LastStorage - returns the last applied operation to the account at or before `level` having not empty deffated storage
+	LastStorage(ctx context.Context, accountID, level int64) (Operation, error)
 }
@@ -0,0 +1,30 @@
+package reorg
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Reorg - chain reorganization detected by indexer. Blocks after the fork point `Level` were rolled back.
+type Reorg struct {
+	bun.BaseModel `bun:"reorgs"`
+
+	ID         int64     `bun:"id,pk,notnull,autoincrement"`
+	DetectedAt time.Time `bun:"detected_at,notnull"`
+	Level      int64     `bun:"level"`
+	Depth      int64     `bun:"depth"`
+	OldHash    string    `bun:"old_hash,type:text"`
+	NewHash    string    `bun:"new_hash,type:text"`
+	OldHead    int64     `bun:"old_head"`
+}
+
+// GetID -
+func (r *Reorg) GetID() int64 {
+	return r.ID
+}
+
+// TableName -
+func (Reorg) TableName() string {
+	return "reorgs"
+}
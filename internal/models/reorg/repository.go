@@ -0,0 +1,11 @@
+package reorg
+
+import "context"
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/reorg/mock.go -package=reorg -typed
+type Repository interface {
+	// Save - records reorganization event
+	Save(ctx context.Context, event *Reorg) error
+	// List - returns reorganization events ordered by id descending
+	List(ctx context.Context, limit, offset int64) ([]Reorg, error)
+}
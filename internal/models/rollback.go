@@ -11,6 +11,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 )
 
 type LastAction struct {
@@ -39,6 +40,11 @@ type Rollback interface {
 	TicketBalances(ctx context.Context, balances ...*ticket.Balance) error
 	DeleteTickets(ctx context.Context, level int64) (ids []int64, err error)
 	DeleteTicketBalances(ctx context.Context, ticketIds []int64) (err error)
+	GetTokenTransfers(ctx context.Context, level int64) ([]token.Transfer, error)
+	TokenBalances(ctx context.Context, balances ...*token.Balance) error
+	RevertWebhookDeliveries(ctx context.Context, level int64) (int, error)
+	// RefreshAggregates - recomputes time-series aggregates starting from `from`. It's called after commit.
+	RefreshAggregates(ctx context.Context, from time.Time) error
 
 	Commit() error
 	Rollback() error
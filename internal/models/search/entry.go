@@ -0,0 +1,110 @@
+package search
+
+import (
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Kind - kind of the indexed value
+type Kind string
+
+// search entry kinds
+const (
+	KindAccount          Kind = "account"
+	KindEntrypoint       Kind = "entrypoint"
+	KindAnnotation       Kind = "annotation"
+	KindGlobalConstant   Kind = "global_constant"
+	KindEvent            Kind = "event"
+	KindContractMetadata Kind = "contract_metadata"
+	KindTokenMetadata    Kind = "token_metadata"
+)
+
+// NewKind - returns kind by its name. Returns empty kind if name is unknown.
+func NewKind(value string) Kind {
+	switch kind := Kind(value); kind {
+	case KindAccount, KindEntrypoint, KindAnnotation, KindGlobalConstant, KindEvent, KindContractMetadata, KindTokenMetadata:
+		return kind
+	default:
+		return ""
+	}
+}
+
+// Entry - searchable value. `Address` is the account which the value belongs to: the contract for entrypoints, annotations, event tags and metadata names, the hash for global constants and the account itself for addresses.
+// The entry is created at the level where the value appeared first, so rollback of the level removes it.
+type Entry struct {
+	bun.BaseModel `bun:"search_entries"`
+
+	ID        int64     `bun:"id,pk,notnull,autoincrement"`
+	Kind      Kind      `bun:"kind,notnull,type:text,unique:search_entries_key"`
+	Value     string    `bun:"value,notnull,type:text,unique:search_entries_key"`
+	Address   string    `bun:"address,notnull,type:text,unique:search_entries_key"`
+	Level     int64     `bun:"level"`
+	Timestamp time.Time `bun:"timestamp"`
+}
+
+// GetID -
+func (e *Entry) GetID() int64 {
+	return e.ID
+}
+
+// TableName -
+func (Entry) TableName() string {
+	return "search_entries"
+}
+
+// String -
+func (e Entry) String() string {
+	return string(e.Kind) + ":" + e.Address + ":" + e.Value
+}
+
+// Rank - returns relevance of the value to the query: 0 - exact match, 1 - prefix match, 2 - substring match, -1 - no match. Comparison is case-insensitive.
+func Rank(value, query string) int {
+	value = strings.ToLower(value)
+	query = strings.ToLower(query)
+	switch {
+	case value == query:
+		return 0
+	case strings.HasPrefix(value, query):
+		return 1
+	case strings.Contains(value, query):
+		return 2
+	default:
+		return -1
+	}
+}
+
+// highlight tags
+const (
+	HighlightOpen  = "<mark>"
+	HighlightClose = "</mark>"
+)
+
+// Highlight - wraps the first case-insensitive occurrence of the query in the value with `<mark>` tag. Returns the value as is if it does not contain the query.
+func Highlight(value, query string) string {
+	if query == "" {
+		return value
+	}
+	lowerValue := strings.ToLower(value)
+	lowerQuery := strings.ToLower(query)
+	if len(lowerValue) != len(value) || len(lowerQuery) != len(query) {
+		// case folding changed byte length, so positions are not comparable
+		lowerValue, lowerQuery = value, query
+	}
+
+	idx := strings.Index(lowerValue, lowerQuery)
+	if idx < 0 {
+		return value
+	}
+
+	end := idx + len(query)
+	var b strings.Builder
+	b.Grow(len(value) + len(HighlightOpen) + len(HighlightClose))
+	b.WriteString(value[:idx])
+	b.WriteString(HighlightOpen)
+	b.WriteString(value[idx:end])
+	b.WriteString(HighlightClose)
+	b.WriteString(value[end:])
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		value string
+		query string
+		want  int
+	}{
+		{value: "transfer", query: "transfer", want: 0},
+		{value: "Transfer", query: "transfer", want: 0},
+		{value: "transfer_ownership", query: "transfer", want: 1},
+		{value: "do_transfer", query: "TRANS", want: 2},
+		{value: "mint", query: "transfer", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			require.Equal(t, tt.want, Rank(tt.value, tt.query))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		query string
+		want  string
+	}{
+		{
+			name:  "prefix",
+			value: "transfer_ownership",
+			query: "transfer",
+			want:  "<mark>transfer</mark>_ownership",
+		}, {
+			name:  "case-insensitive",
+			value: "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9",
+			query: "hkg5",
+			want:  "KT1<mark>Hkg5</mark>qeNhfwpKW4fXvq7HGZB9z2EnmCCA9",
+		}, {
+			name:  "first occurrence",
+			value: "%token_token",
+			query: "token",
+			want:  "%<mark>token</mark>_token",
+		}, {
+			name:  "no match",
+			value: "mint",
+			query: "burn",
+			want:  "mint",
+		}, {
+			name:  "empty query",
+			value: "mint",
+			want:  "mint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Highlight(tt.value, tt.query))
+		})
+	}
+}
+
+func TestNewKind(t *testing.T) {
+	require.Equal(t, KindEntrypoint, NewKind("entrypoint"))
+	require.Equal(t, KindTokenMetadata, NewKind("token_metadata"))
+	require.Equal(t, Kind(""), NewKind("unknown"))
+}
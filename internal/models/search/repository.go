@@ -0,0 +1,17 @@
+package search
+
+import "context"
+
+// Request -
+type Request struct {
+	Query  string
+	Kinds  []Kind
+	Limit  int64
+	Offset int64
+}
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/search/mock.go -package=search -typed
+type Repository interface {
+	// Search - returns entries containing the query (case-insensitive). Exact matches go first, then prefix matches, then other ones. Entries with the same rank are ordered by value length.
+	Search(ctx context.Context, req Request) ([]Entry, error)
+}
@@ -0,0 +1,52 @@
+package smartrollup
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Commitment actions
+const (
+	CommitmentPublish = "publish"
+	CommitmentCement  = "cement"
+)
+
+// Commitment - smart rollup commitment published by the staker (`smart_rollup_publish`) or cemented (`smart_rollup_cement`).
+// Cement doesn't have a staker and commitment's content.
+type Commitment struct {
+	bun.BaseModel `bun:"smart_rollup_commitments"`
+
+	ID               int64     `bun:"id,pk,notnull,autoincrement"`
+	Timestamp        time.Time `bun:"timestamp,pk,notnull"`
+	Level            int64     `bun:"level"`
+	OperationId      int64     `bun:"operation_id"`
+	Action           string    `bun:"action,type:text"`
+	Rollup           string    `bun:"rollup,type:text"`
+	Staker           string    `bun:"staker,type:text"`
+	Hash             string    `bun:"hash,type:text"`
+	Predecessor      string    `bun:"predecessor,type:text"`
+	CompressedState  string    `bun:"compressed_state,type:text"`
+	InboxLevel       int64     `bun:"inbox_level"`
+	NumberOfTicks    int64     `bun:"number_of_ticks"`
+	PublishedAtLevel int64     `bun:"published_at_level"`
+}
+
+// GetID -
+func (c *Commitment) GetID() int64 {
+	return c.ID
+}
+
+// TableName -
+func (Commitment) TableName() string {
+	return "smart_rollup_commitments"
+}
+
+// LogFields -
+func (c *Commitment) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"rollup": c.Rollup,
+		"hash":   c.Hash,
+		"block":  c.Level,
+	}
+}
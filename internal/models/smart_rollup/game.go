@@ -0,0 +1,135 @@
+package smartrollup
+
+import (
+	"sort"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Game move kinds
+const (
+	MoveStart   = "start"
+	MoveMove    = "move"
+	MoveTimeout = "timeout"
+)
+
+// Game statuses
+const (
+	GameOngoing = "ongoing"
+	GameLoser   = "loser"
+	GameDraw    = "draw"
+)
+
+// GameMove - move of the refutation game between two stakers: `smart_rollup_refute` or `smart_rollup_timeout`.
+// For refutation `Player` is the operation's source, for timeout players are `alice` and `bob` of the game.
+type GameMove struct {
+	bun.BaseModel `bun:"smart_rollup_game_moves"`
+
+	ID                 int64     `bun:"id,pk,notnull,autoincrement"`
+	Timestamp          time.Time `bun:"timestamp,pk,notnull"`
+	Level              int64     `bun:"level"`
+	OperationId        int64     `bun:"operation_id"`
+	Rollup             string    `bun:"rollup,type:text"`
+	Kind               string    `bun:"kind,type:text"`
+	Player             string    `bun:"player,type:text"`
+	Opponent           string    `bun:"opponent,type:text"`
+	PlayerCommitment   string    `bun:"player_commitment,type:text"`
+	OpponentCommitment string    `bun:"opponent_commitment,type:text"`
+	Choice             string    `bun:"choice,type:text"`
+	Step               []byte    `bun:"step,type:bytea"`
+	Status             string    `bun:"status,type:text"`
+	Loser              string    `bun:"loser,type:text"`
+	Reason             string    `bun:"reason,type:text"`
+}
+
+// GetID -
+func (m *GameMove) GetID() int64 {
+	return m.ID
+}
+
+// TableName -
+func (GameMove) TableName() string {
+	return "smart_rollup_game_moves"
+}
+
+// LogFields -
+func (m *GameMove) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"rollup":   m.Rollup,
+		"player":   m.Player,
+		"opponent": m.Opponent,
+		"block":    m.Level,
+	}
+}
+
+// IsFinished - true if the move finished the game
+func (m GameMove) IsFinished() bool {
+	return m.Status == GameLoser || m.Status == GameDraw
+}
+
+// Game - refutation game between two stakers built from its moves
+type Game struct {
+	Rollup              string
+	Initiator           string
+	Opponent            string
+	InitiatorCommitment string
+	OpponentCommitment  string
+	StartLevel          int64
+	LastMoveLevel       int64
+	MovesCount          int64
+	Status              string
+	Loser               string
+	Reason              string
+}
+
+func (g Game) isPlayedBy(player, opponent string) bool {
+	return (g.Initiator == player && g.Opponent == opponent) || (g.Initiator == opponent && g.Opponent == player)
+}
+
+// NewGames - builds games from moves. Stakers can play only one game against each other at the same time,
+// so the move belongs to the last not finished game of its players. Games are returned from the latest one.
+func NewGames(moves []GameMove) []Game {
+	sorted := make([]GameMove, len(moves))
+	copy(sorted, moves)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Level < sorted[j].Level
+	})
+
+	games := make([]Game, 0)
+	for _, move := range sorted {
+		idx := -1
+		for i := len(games) - 1; i >= 0; i-- {
+			if games[i].Status == GameOngoing && games[i].isPlayedBy(move.Player, move.Opponent) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			games = append(games, Game{
+				Rollup:              move.Rollup,
+				Initiator:           move.Player,
+				Opponent:            move.Opponent,
+				InitiatorCommitment: move.PlayerCommitment,
+				OpponentCommitment:  move.OpponentCommitment,
+				StartLevel:          move.Level,
+				Status:              GameOngoing,
+			})
+			idx = len(games) - 1
+		}
+
+		game := &games[idx]
+		game.MovesCount++
+		game.LastMoveLevel = move.Level
+		if move.IsFinished() {
+			game.Status = move.Status
+			game.Loser = move.Loser
+			game.Reason = move.Reason
+		}
+	}
+
+	for i, j := 0, len(games)-1; i < j; i, j = i+1, j-1 {
+		games[i], games[j] = games[j], games[i]
+	}
+	return games
+}
@@ -0,0 +1,102 @@
+package smartrollup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGames(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []GameMove
+		want  []Game
+	}{
+		{
+			name:  "empty",
+			moves: nil,
+			want:  []Game{},
+		}, {
+			name: "finished and ongoing games of the same players",
+			moves: []GameMove{
+				{Level: 10, Rollup: "sr1", Kind: MoveStart, Player: "alice", Opponent: "bob", PlayerCommitment: "src1", OpponentCommitment: "src2", Status: GameOngoing},
+				{Level: 11, Rollup: "sr1", Kind: MoveMove, Player: "bob", Opponent: "alice", Status: GameOngoing},
+				{Level: 12, Rollup: "sr1", Kind: MoveMove, Player: "alice", Opponent: "bob", Status: GameLoser, Loser: "bob", Reason: "conflict_resolved"},
+				{Level: 20, Rollup: "sr1", Kind: MoveStart, Player: "bob", Opponent: "alice", PlayerCommitment: "src3", OpponentCommitment: "src4", Status: GameOngoing},
+			},
+			want: []Game{
+				{
+					Rollup:              "sr1",
+					Initiator:           "bob",
+					Opponent:            "alice",
+					InitiatorCommitment: "src3",
+					OpponentCommitment:  "src4",
+					StartLevel:          20,
+					LastMoveLevel:       20,
+					MovesCount:          1,
+					Status:              GameOngoing,
+				}, {
+					Rollup:              "sr1",
+					Initiator:           "alice",
+					Opponent:            "bob",
+					InitiatorCommitment: "src1",
+					OpponentCommitment:  "src2",
+					StartLevel:          10,
+					LastMoveLevel:       12,
+					MovesCount:          3,
+					Status:              GameLoser,
+					Loser:               "bob",
+					Reason:              "conflict_resolved",
+				},
+			},
+		}, {
+			name: "timeout finishes the game",
+			moves: []GameMove{
+				{Level: 11, Rollup: "sr1", Kind: MoveTimeout, Player: "carol", Opponent: "dave", Status: GameDraw},
+				{Level: 10, Rollup: "sr1", Kind: MoveStart, Player: "dave", Opponent: "carol", Status: GameOngoing},
+			},
+			want: []Game{
+				{
+					Rollup:        "sr1",
+					Initiator:     "dave",
+					Opponent:      "carol",
+					StartLevel:    10,
+					LastMoveLevel: 11,
+					MovesCount:    2,
+					Status:        GameDraw,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, NewGames(tt.moves))
+		})
+	}
+}
+
+func TestNewStakers(t *testing.T) {
+	publications := []Publications{
+		{Staker: "alice", Count: 3, FirstLevel: 10, LastLevel: 30, LastCommitment: "src3"},
+		{Staker: "bob", Count: 1, FirstLevel: 10, LastLevel: 10, LastCommitment: "src1"},
+		{Staker: "carol", Count: 2, FirstLevel: 10, LastLevel: 40, LastCommitment: "src4"},
+		{Staker: "dave", Count: 1, FirstLevel: 5, LastLevel: 5, LastCommitment: "src0"},
+	}
+	lost := []GameMove{
+		{Level: 15, Status: GameLoser, Loser: "bob"},
+		{Level: 20, Status: GameLoser, Loser: "alice"},
+		{Level: 12, Status: GameLoser, Loser: "dave"},
+	}
+	recoveries := []BondRecovery{
+		{Level: 35, Staker: "carol"},
+		{Level: 25, Staker: "bob"},
+	}
+
+	want := []Staker{
+		{Address: "carol", Status: StakerActive, PublicationsCount: 2, FirstLevel: 10, LastLevel: 40, LastCommitment: "src4", StatusLevel: 40},
+		{Address: "alice", Status: StakerActive, PublicationsCount: 3, FirstLevel: 10, LastLevel: 30, LastCommitment: "src3", StatusLevel: 30},
+		{Address: "bob", Status: StakerRecovered, PublicationsCount: 1, FirstLevel: 10, LastLevel: 10, LastCommitment: "src1", StatusLevel: 25},
+		{Address: "dave", Status: StakerRefuted, PublicationsCount: 1, FirstLevel: 5, LastLevel: 5, LastCommitment: "src0", StatusLevel: 12},
+	}
+	require.Equal(t, want, NewStakers(publications, lost, recoveries))
+}
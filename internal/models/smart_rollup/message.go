@@ -0,0 +1,41 @@
+package smartrollup
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Message - external inbox message added by `smart_rollup_add_messages`. `Rollup` and `Payload` are set
+// if the message is framed for the known rollup.
+type Message struct {
+	bun.BaseModel `bun:"smart_rollup_messages"`
+
+	ID          int64     `bun:"id,pk,notnull,autoincrement"`
+	Timestamp   time.Time `bun:"timestamp,pk,notnull"`
+	Level       int64     `bun:"level"`
+	OperationId int64     `bun:"operation_id"`
+	Index       int64     `bun:"index"`
+	Rollup      string    `bun:"rollup,type:text"`
+	Raw         []byte    `bun:"raw,type:bytea"`
+	Payload     []byte    `bun:"payload,type:bytea"`
+}
+
+// GetID -
+func (m *Message) GetID() int64 {
+	return m.ID
+}
+
+// TableName -
+func (Message) TableName() string {
+	return "smart_rollup_messages"
+}
+
+// LogFields -
+func (m *Message) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"rollup": m.Rollup,
+		"index":  m.Index,
+		"block":  m.Level,
+	}
+}
@@ -0,0 +1,48 @@
+package smartrollup
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// OutboxTransaction - transaction of the outbox message executed by `smart_rollup_execute_outbox_message`.
+// `Nonce` is the nonce of the internal operation made by the transaction and `InternalOperationId` is its identifier.
+type OutboxTransaction struct {
+	bun.BaseModel `bun:"smart_rollup_outbox_transactions"`
+
+	ID                  int64     `bun:"id,pk,notnull,autoincrement"`
+	Timestamp           time.Time `bun:"timestamp,pk,notnull"`
+	Level               int64     `bun:"level"`
+	OperationId         int64     `bun:"operation_id"`
+	InternalOperationId int64     `bun:"internal_operation_id"`
+	Nonce               *int64    `bun:"nonce,nullzero"`
+	Rollup              string    `bun:"rollup,type:text"`
+	OutboxLevel         int64     `bun:"outbox_level"`
+	MessageIndex        int64     `bun:"message_index"`
+	Index               int64     `bun:"index"`
+	Kind                string    `bun:"kind,type:text"`
+	Destination         string    `bun:"destination,type:text"`
+	Entrypoint          string    `bun:"entrypoint,type:text"`
+	Parameters          []byte    `bun:"parameters,type:bytea"`
+	ParametersType      []byte    `bun:"parameters_type,type:bytea"`
+}
+
+// GetID -
+func (t *OutboxTransaction) GetID() int64 {
+	return t.ID
+}
+
+// TableName -
+func (OutboxTransaction) TableName() string {
+	return "smart_rollup_outbox_transactions"
+}
+
+// LogFields -
+func (t *OutboxTransaction) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"rollup":      t.Rollup,
+		"destination": t.Destination,
+		"block":       t.Level,
+	}
+}
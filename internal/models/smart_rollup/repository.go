@@ -6,4 +6,10 @@ import "context"
 type Repository interface {
 	Get(ctx context.Context, address string) (SmartRollup, error)
 	List(ctx context.Context, limit, offset int64, sort string) ([]SmartRollup, error)
+	Commitments(ctx context.Context, rollup string, limit, offset int64) ([]Commitment, error)
+	Commitment(ctx context.Context, rollup, hash string) (Commitment, error)
+	Stakers(ctx context.Context, rollup string) ([]Staker, error)
+	Games(ctx context.Context, rollup string) ([]Game, error)
+	Messages(ctx context.Context, rollup string, limit, offset int64) ([]Message, error)
+	OutboxTransactions(ctx context.Context, rollup string, limit, offset int64) ([]OutboxTransaction, error)
 }
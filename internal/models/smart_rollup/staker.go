@@ -0,0 +1,114 @@
+package smartrollup
+
+import (
+	"sort"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Staker statuses
+const (
+	StakerActive    = "active"
+	StakerRefuted   = "refuted"
+	StakerRecovered = "recovered"
+)
+
+// BondRecovery - staker's bond recovered by `smart_rollup_recover_bond`
+type BondRecovery struct {
+	bun.BaseModel `bun:"smart_rollup_bond_recoveries"`
+
+	ID          int64     `bun:"id,pk,notnull,autoincrement"`
+	Timestamp   time.Time `bun:"timestamp,pk,notnull"`
+	Level       int64     `bun:"level"`
+	OperationId int64     `bun:"operation_id"`
+	Rollup      string    `bun:"rollup,type:text"`
+	Staker      string    `bun:"staker,type:text"`
+}
+
+// GetID -
+func (r *BondRecovery) GetID() int64 {
+	return r.ID
+}
+
+// TableName -
+func (BondRecovery) TableName() string {
+	return "smart_rollup_bond_recoveries"
+}
+
+// LogFields -
+func (r *BondRecovery) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"rollup": r.Rollup,
+		"staker": r.Staker,
+		"block":  r.Level,
+	}
+}
+
+// Publications - aggregated commitments published by the staker
+type Publications struct {
+	Staker         string
+	Count          int64
+	FirstLevel     int64
+	LastLevel      int64
+	LastCommitment string
+}
+
+// Staker - staker of the smart rollup
+type Staker struct {
+	Address           string
+	Status            string
+	PublicationsCount int64
+	FirstLevel        int64
+	LastLevel         int64
+	LastCommitment    string
+	StatusLevel       int64
+}
+
+// NewStakers - builds stakers of the rollup. Staker is refuted if it lost the game and bond is recovered
+// if staker withdrew it. The latest event defines the status, so staker which published commitment
+// after bond recovery is active again. Stakers are sorted by the last publication level descending.
+func NewStakers(publications []Publications, lostGames []GameMove, recoveries []BondRecovery) []Staker {
+	stakers := make(map[string]*Staker, len(publications))
+	for _, p := range publications {
+		stakers[p.Staker] = &Staker{
+			Address:           p.Staker,
+			Status:            StakerActive,
+			PublicationsCount: p.Count,
+			FirstLevel:        p.FirstLevel,
+			LastLevel:         p.LastLevel,
+			LastCommitment:    p.LastCommitment,
+			StatusLevel:       p.LastLevel,
+		}
+	}
+
+	setStatus := func(address, status string, level int64) {
+		staker, ok := stakers[address]
+		if !ok || staker.StatusLevel > level {
+			return
+		}
+		staker.Status = status
+		staker.StatusLevel = level
+	}
+
+	for _, move := range lostGames {
+		if move.Status == GameLoser {
+			setStatus(move.Loser, StakerRefuted, move.Level)
+		}
+	}
+	for _, r := range recoveries {
+		setStatus(r.Staker, StakerRecovered, r.Level)
+	}
+
+	result := make([]Staker, 0, len(stakers))
+	for _, staker := range stakers {
+		result = append(result, *staker)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LastLevel == result[j].LastLevel {
+			return result[i].Address < result[j].Address
+		}
+		return result[i].LastLevel > result[j].LastLevel
+	})
+	return result
+}
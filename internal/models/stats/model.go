@@ -20,6 +20,8 @@ type Stats struct {
 	SrExecutesCount             int   `bun:"sr_executes_count"`
 	RegisterGlobalConstantCount int   `bun:"register_global_constants_count"`
 	TransferTicketsCount        int   `bun:"transfer_tickets_count"`
+	IncreasePaidStorageCount    int   `bun:"increase_paid_storage_count"`
+	StakingCount                int   `bun:"staking_count"`
 }
 
 // GetID -
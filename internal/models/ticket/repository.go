@@ -21,5 +21,7 @@ type Repository interface {
 	List(ctx context.Context, ticketer string, limit, offset int64) ([]Ticket, error)
 	Updates(ctx context.Context, req UpdatesRequest) ([]TicketUpdate, error)
 	UpdatesForOperation(ctx context.Context, operationId int64) ([]TicketUpdate, error)
+	// UpdatesForOperations - returns ticket updates of all operations ordered by id
+	UpdatesForOperations(ctx context.Context, ids []int64) ([]TicketUpdate, error)
 	BalancesForAccount(ctx context.Context, accountId int64, req BalanceRequest) ([]Balance, error)
 }
@@ -0,0 +1,42 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// Balance - token balance of the account in FA1.2 or FA2 contract
+type Balance struct {
+	bun.BaseModel `bun:"token_balances"`
+
+	Contract string          `bun:"contract,pk,notnull,type:text"`
+	Address  string          `bun:"address,pk,notnull,type:text"`
+	TokenId  decimal.Decimal `bun:"token_id,pk,notnull,type:numeric(200,0)"`
+	Amount   decimal.Decimal `bun:"amount,type:numeric(200,0)"`
+}
+
+// GetID -
+func (Balance) GetID() int64 {
+	return 0
+}
+
+// TableName -
+func (Balance) TableName() string {
+	return "token_balances"
+}
+
+// LogFields -
+func (b Balance) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"contract": b.Contract,
+		"address":  b.Address,
+		"token_id": b.TokenId.String(),
+		"amount":   b.Amount.String(),
+	}
+}
+
+func (b Balance) String() string {
+	return fmt.Sprintf("%s_%s_%s", b.Contract, b.Address, b.TokenId.String())
+}
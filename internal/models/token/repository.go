@@ -0,0 +1,25 @@
+package token
+
+import "context"
+
+type BalanceRequest struct {
+	Holder              string
+	TokenId             *uint64
+	Limit               int64
+	Offset              int64
+	WithoutZeroBalances bool
+}
+
+type TransfersRequest struct {
+	Account string
+	TokenId *uint64
+	Limit   int64
+	Offset  int64
+}
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/token/mock.go -package=token -typed
+type Repository interface {
+	Balances(ctx context.Context, contract string, req BalanceRequest) ([]Balance, error)
+	Transfers(ctx context.Context, contract string, req TransfersRequest) ([]Transfer, error)
+	TransfersForOperation(ctx context.Context, operationId int64) ([]Transfer, error)
+}
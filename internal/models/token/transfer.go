@@ -0,0 +1,91 @@
+package token
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// Transfer - token movement between two accounts. Empty `From` means mint, empty `To` means burn.
+type Transfer struct {
+	bun.BaseModel `bun:"token_transfers"`
+
+	ID          int64           `bun:"id,pk,notnull,autoincrement"`
+	Timestamp   time.Time       `bun:"timestamp,pk,notnull"`
+	Level       int64           `bun:"level"`
+	OperationId int64           `bun:"operation_id"`
+	Contract    string          `bun:"contract,type:text"`
+	From        string          `bun:"from,type:text"`
+	To          string          `bun:"to,type:text"`
+	TokenId     decimal.Decimal `bun:"token_id,type:numeric(200,0)"`
+	Amount      decimal.Decimal `bun:"amount,type:numeric(200,0)"`
+}
+
+// GetID -
+func (t *Transfer) GetID() int64 {
+	return t.ID
+}
+
+// TableName -
+func (Transfer) TableName() string {
+	return "token_transfers"
+}
+
+// LogFields -
+func (t *Transfer) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"id":       t.ID,
+		"block":    t.Level,
+		"contract": t.Contract,
+		"token_id": t.TokenId.String(),
+	}
+}
+
+// Balances - returns balance changes made by the transfer
+func (t *Transfer) Balances() []Balance {
+	balances := make([]Balance, 0, 2)
+	if t.From != "" {
+		balances = append(balances, Balance{
+			Contract: t.Contract,
+			Address:  t.From,
+			TokenId:  t.TokenId.Copy(),
+			Amount:   t.Amount.Neg(),
+		})
+	}
+	if t.To != "" {
+		balances = append(balances, Balance{
+			Contract: t.Contract,
+			Address:  t.To,
+			TokenId:  t.TokenId.Copy(),
+			Amount:   t.Amount.Copy(),
+		})
+	}
+	return balances
+}
+
+// AggregateBalances - sums balance changes made by transfers per contract, token and account. Result is sorted by the balance key.
+// Indexer adds the changes to stored balances and rollback subtracts them.
+func AggregateBalances(transfers []*Transfer) []*Balance {
+	balances := make(map[string]*Balance)
+	keys := make([]string, 0)
+	for i := range transfers {
+		for _, balance := range transfers[i].Balances() {
+			key := balance.String()
+			if b, ok := balances[key]; ok {
+				b.Amount = b.Amount.Add(balance.Amount)
+			} else {
+				balances[key] = &balance
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	arr := make([]*Balance, len(keys))
+	for i := range keys {
+		arr[i] = balances[keys[i]]
+	}
+	return arr
+}
@@ -0,0 +1,91 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransfer_Balances(t *testing.T) {
+	tests := []struct {
+		name     string
+		transfer Transfer
+		want     []Balance
+	}{
+		{
+			name: "transfer",
+			transfer: Transfer{
+				Contract: "KT1",
+				From:     "tz1",
+				To:       "tz2",
+				TokenId:  decimal.NewFromInt(1),
+				Amount:   decimal.NewFromInt(100),
+			},
+			want: []Balance{
+				{Contract: "KT1", Address: "tz1", TokenId: decimal.NewFromInt(1), Amount: decimal.NewFromInt(-100)},
+				{Contract: "KT1", Address: "tz2", TokenId: decimal.NewFromInt(1), Amount: decimal.NewFromInt(100)},
+			},
+		}, {
+			name: "mint",
+			transfer: Transfer{
+				Contract: "KT1",
+				To:       "tz2",
+				TokenId:  decimal.Zero,
+				Amount:   decimal.NewFromInt(10),
+			},
+			want: []Balance{
+				{Contract: "KT1", Address: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+			},
+		}, {
+			name: "burn",
+			transfer: Transfer{
+				Contract: "KT1",
+				From:     "tz1",
+				TokenId:  decimal.Zero,
+				Amount:   decimal.NewFromInt(10),
+			},
+			want: []Balance{
+				{Contract: "KT1", Address: "tz1", TokenId: decimal.Zero, Amount: decimal.NewFromInt(-10)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.transfer.Balances()
+			require.Len(t, got, len(tt.want))
+			for i := range got {
+				require.Equal(t, tt.want[i].Contract, got[i].Contract)
+				require.Equal(t, tt.want[i].Address, got[i].Address)
+				require.True(t, tt.want[i].TokenId.Equal(got[i].TokenId))
+				require.True(t, tt.want[i].Amount.Equal(got[i].Amount))
+			}
+		})
+	}
+}
+
+func TestAggregateBalances(t *testing.T) {
+	transfers := []*Transfer{
+		{Contract: "KT1", From: "tz1", To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+		{Contract: "KT1", From: "tz2", To: "tz3", TokenId: decimal.Zero, Amount: decimal.NewFromInt(4)},
+		{Contract: "KT1", To: "tz1", TokenId: decimal.NewFromInt(1), Amount: decimal.NewFromInt(7)},
+	}
+
+	got := AggregateBalances(transfers)
+	require.Len(t, got, 4)
+
+	want := map[string]int64{
+		"KT1_tz1_0": -10,
+		"KT1_tz2_0": 6,
+		"KT1_tz3_0": 4,
+		"KT1_tz1_1": 7,
+	}
+	for i := range got {
+		amount, ok := want[got[i].String()]
+		require.True(t, ok, got[i].String())
+		require.True(t, decimal.NewFromInt(amount).Equal(got[i].Amount), got[i].String())
+		if i > 0 {
+			require.Less(t, got[i-1].String(), got[i].String())
+		}
+	}
+}
@@ -28,6 +28,28 @@ func NewOperationKind(value string) OperationKind {
 		return OperationKindSrOrigination
 	case consts.SrExecuteOutboxMessage:
 		return OperationKindSrExecuteOutboxMessage
+	case consts.SrAddMessages:
+		return OperationKindSrAddMessages
+	case consts.SrCement:
+		return OperationKindSrCement
+	case consts.SrPublish:
+		return OperationKindSrPublish
+	case consts.SrRefute:
+		return OperationKindSrRefute
+	case consts.SrTimeout:
+		return OperationKindSrTimeout
+	case consts.SrRecoverBond:
+		return OperationKindSrRecoverBond
+	case consts.IncreasePaidStorage:
+		return OperationKindIncreasePaidStorage
+	case consts.Stake:
+		return OperationKindStake
+	case consts.Unstake:
+		return OperationKindUnstake
+	case consts.FinalizeUnstake:
+		return OperationKindFinalizeUnstake
+	case consts.SetDelegateParameters:
+		return OperationKindSetDelegateParameters
 	default:
 		return 0
 	}
@@ -56,6 +78,28 @@ func (kind OperationKind) String() string {
 		return consts.SrOriginate
 	case OperationKindSrExecuteOutboxMessage:
 		return consts.SrExecuteOutboxMessage
+	case OperationKindSrAddMessages:
+		return consts.SrAddMessages
+	case OperationKindSrCement:
+		return consts.SrCement
+	case OperationKindSrPublish:
+		return consts.SrPublish
+	case OperationKindSrRefute:
+		return consts.SrRefute
+	case OperationKindSrTimeout:
+		return consts.SrTimeout
+	case OperationKindSrRecoverBond:
+		return consts.SrRecoverBond
+	case OperationKindIncreasePaidStorage:
+		return consts.IncreasePaidStorage
+	case OperationKindStake:
+		return consts.Stake
+	case OperationKindUnstake:
+		return consts.Unstake
+	case OperationKindFinalizeUnstake:
+		return consts.FinalizeUnstake
+	case OperationKindSetDelegateParameters:
+		return consts.SetDelegateParameters
 	default:
 		return ""
 	}
@@ -72,4 +116,25 @@ const (
 	OperationKindTransferTicket
 	OperationKindSrOrigination
 	OperationKindSrExecuteOutboxMessage
+	OperationKindSrAddMessages
+	OperationKindSrCement
+	OperationKindSrPublish
+	OperationKindSrRefute
+	OperationKindSrTimeout
+	OperationKindSrRecoverBond
+	OperationKindIncreasePaidStorage
+	OperationKindStake
+	OperationKindUnstake
+	OperationKindFinalizeUnstake
+	OperationKindSetDelegateParameters
 )
+
+// IsStaking - returns true if the kind is one of staking pseudo-operations
+func (kind OperationKind) IsStaking() bool {
+	switch kind {
+	case OperationKindStake, OperationKindUnstake, OperationKindFinalizeUnstake, OperationKindSetDelegateParameters:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	stdJSON "encoding/json"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/uptrace/bun"
+)
+
+// EventType -
+type EventType string
+
+// event types
+const (
+	EventOperation EventType = "operation"
+	EventReverted  EventType = "reverted"
+)
+
+// Delivery - queued event of the webhook. `Operation` event is created when matched operation is indexed. `Reverted` event is created on rollback for every delivered `operation` event of the rolled back level.
+type Delivery struct {
+	bun.BaseModel `bun:"webhook_deliveries"`
+
+	ID            int64              `bun:"id,pk,notnull,autoincrement"`
+	WebhookID     int64              `bun:"webhook_id,notnull"`
+	Webhook       Webhook            `bun:"rel:belongs-to"`
+	Event         EventType          `bun:"event,notnull,type:text"`
+	OperationID   int64              `bun:"operation_id"`
+	Level         int64              `bun:"level"`
+	Payload       stdJSON.RawMessage `bun:"payload,type:jsonb"`
+	Attempts      int                `bun:"attempts,notnull,default:0"`
+	NextAttemptAt time.Time          `bun:"next_attempt_at,notnull"`
+	LastError     string             `bun:"last_error,type:text"`
+	Delivered     bool               `bun:"delivered,notnull,default:false"`
+	CreatedAt     time.Time          `bun:"created_at,notnull"`
+}
+
+// GetID -
+func (d *Delivery) GetID() int64 {
+	return d.ID
+}
+
+// TableName -
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// LogFields -
+func (d *Delivery) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"id":       d.ID,
+		"webhook":  d.WebhookID,
+		"event":    d.Event,
+		"block":    d.Level,
+		"attempts": d.Attempts,
+	}
+}
+
+// DeadLetter - delivery which was not accepted by receiver after maximum count of attempts
+type DeadLetter struct {
+	bun.BaseModel `bun:"webhook_dead_letters"`
+
+	ID          int64              `bun:"id,pk,notnull,autoincrement"`
+	DeliveryID  int64              `bun:"delivery_id,notnull"`
+	WebhookID   int64              `bun:"webhook_id,notnull"`
+	Event       EventType          `bun:"event,notnull,type:text"`
+	OperationID int64              `bun:"operation_id"`
+	Level       int64              `bun:"level"`
+	Payload     stdJSON.RawMessage `bun:"payload,type:jsonb"`
+	Attempts    int                `bun:"attempts"`
+	LastError   string             `bun:"last_error,type:text"`
+	CreatedAt   time.Time          `bun:"created_at,notnull"`
+}
+
+// GetID -
+func (d *DeadLetter) GetID() int64 {
+	return d.ID
+}
+
+// TableName -
+func (DeadLetter) TableName() string {
+	return "webhook_dead_letters"
+}
+
+// NewDeadLetter -
+func NewDeadLetter(delivery Delivery, failedAt time.Time) *DeadLetter {
+	return &DeadLetter{
+		DeliveryID:  delivery.ID,
+		WebhookID:   delivery.WebhookID,
+		Event:       delivery.Event,
+		OperationID: delivery.OperationID,
+		Level:       delivery.Level,
+		Payload:     delivery.Payload,
+		Attempts:    delivery.Attempts,
+		LastError:   delivery.LastError,
+		CreatedAt:   failedAt,
+	}
+}
+
+// Payload - operation data sent to the webhook
+type Payload struct {
+	ID           int64     `json:"id"`
+	Hash         string    `json:"hash,omitempty"`
+	Level        int64     `json:"level"`
+	Timestamp    time.Time `json:"timestamp"`
+	Kind         string    `json:"kind"`
+	Status       string    `json:"status"`
+	Source       string    `json:"source,omitempty"`
+	Destination  string    `json:"destination,omitempty"`
+	Entrypoint   string    `json:"entrypoint,omitempty"`
+	Tag          string    `json:"tag,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Amount       int64     `json:"amount,omitempty"`
+	Counter      int64     `json:"counter,omitempty"`
+	ContentIndex int64     `json:"content_index"`
+	Nonce        *int64    `json:"nonce,omitempty"`
+	Internal     bool      `json:"internal"`
+}
+
+// NewDelivery - creates `operation` event of the webhook. Operation must be saved before, because its identity is sent to receiver.
+func NewDelivery(hook Webhook, op *operation.Operation, now time.Time) (*Delivery, error) {
+	payload := Payload{
+		ID:           op.ID,
+		Level:        op.Level,
+		Timestamp:    op.Timestamp.UTC(),
+		Kind:         op.Kind.String(),
+		Status:       op.Status.String(),
+		Source:       op.Source.Address,
+		Destination:  op.Destination.Address,
+		Entrypoint:   op.Entrypoint.String(),
+		Tag:          op.Tag.String(),
+		Tags:         op.Tags.ToArray(),
+		Amount:       op.Amount,
+		Counter:      op.Counter,
+		ContentIndex: op.ContentIndex,
+		Nonce:        op.Nonce,
+		Internal:     op.Internal,
+	}
+	if len(op.Hash) > 0 {
+		hash, err := encoding.EncodeBase58(op.Hash, []byte(encoding.PrefixOperationHash))
+		if err != nil {
+			return nil, err
+		}
+		payload.Hash = hash
+	}
+
+	data, err := stdJSON.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delivery{
+		WebhookID:     hook.ID,
+		Event:         EventOperation,
+		OperationID:   op.ID,
+		Level:         op.Level,
+		Payload:       data,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../mock/webhook/mock.go -package=webhook -typed
+type Repository interface {
+	// Create - registers new webhook
+	Create(ctx context.Context, hook *Webhook) error
+	// Get - returns webhook by id
+	Get(ctx context.Context, id int64) (Webhook, error)
+	// List - returns all registered webhooks ordered by id
+	List(ctx context.Context) ([]Webhook, error)
+	// ListByOwner - returns webhooks registered by the owner ordered by id
+	ListByOwner(ctx context.Context, owner string) ([]Webhook, error)
+	// Delete - removes webhook with its queued deliveries and dead letters
+	Delete(ctx context.Context, id int64) error
+
+	// Pending - returns undelivered events with next attempt time before `now` ordered by id. Webhook of the event is joined.
+	Pending(ctx context.Context, now time.Time, limit int) ([]Delivery, error)
+	// Delivered - marks event as delivered
+	Delivered(ctx context.Context, delivery *Delivery) error
+	// Retry - saves count of attempts, last error and next attempt time of the event
+	Retry(ctx context.Context, delivery *Delivery) error
+	// DeadLetter - moves event to dead-letter table
+	DeadLetter(ctx context.Context, delivery *Delivery, failedAt time.Time) error
+	// DeadLetters - returns dead letters of the webhook ordered by id descending
+	DeadLetters(ctx context.Context, webhookID int64, limit, offset int64) ([]DeadLetter, error)
+}
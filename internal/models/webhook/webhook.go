@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/uptrace/bun"
+)
+
+// Webhook - subscription of external service to indexed operations. Empty filter field matches any value.
+type Webhook struct {
+	bun.BaseModel `bun:"webhooks"`
+
+	ID         int64                 `bun:"id,pk,notnull,autoincrement"`
+	Owner      string                `bun:"owner,notnull,type:text"`
+	URL        string                `bun:"url,notnull,type:text"`
+	Secret     string                `bun:"secret,notnull,type:text"`
+	Address    string                `bun:"address,type:text"`
+	Entrypoint string                `bun:"entrypoint,type:text"`
+	Tag        string                `bun:"tag,type:text"`
+	Status     types.OperationStatus `bun:"status,type:SMALLINT"`
+	CreatedAt  time.Time             `bun:"created_at,notnull"`
+}
+
+// GetID -
+func (w *Webhook) GetID() int64 {
+	return w.ID
+}
+
+// TableName -
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// LogFields -
+func (w *Webhook) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"id":  w.ID,
+		"url": w.URL,
+	}
+}
+
+// Match - checks the operation is matched by webhook filter. Address is compared with source and destination of the operation. Tag is compared with event tag and contract tags of the operation.
+func (w Webhook) Match(op *operation.Operation) bool {
+	if op == nil {
+		return false
+	}
+	if w.Address != "" && op.Source.Address != w.Address && op.Destination.Address != w.Address {
+		return false
+	}
+	if w.Entrypoint != "" && !op.Entrypoint.EqualString(w.Entrypoint) {
+		return false
+	}
+	if w.Status != 0 && op.Status != w.Status {
+		return false
+	}
+	if w.Tag != "" && !op.Tag.EqualString(w.Tag) && !op.Tags.Has(types.NewTags([]string{w.Tag})) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/testsuite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Match(t *testing.T) {
+	op := &operation.Operation{
+		Source:      account.Account{Address: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},
+		Destination: account.Account{Address: "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"},
+		Entrypoint:  types.NewNullString(testsuite.Ptr("transfer")),
+		Status:      types.OperationStatusApplied,
+		Tags:        types.FA2Tag,
+	}
+
+	tests := []struct {
+		name string
+		hook Webhook
+		want bool
+	}{
+		{
+			name: "empty filter",
+			want: true,
+		}, {
+			name: "destination",
+			hook: Webhook{Address: "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9", Entrypoint: "transfer"},
+			want: true,
+		}, {
+			name: "source",
+			hook: Webhook{Address: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},
+			want: true,
+		}, {
+			name: "other address",
+			hook: Webhook{Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+		}, {
+			name: "other entrypoint",
+			hook: Webhook{Entrypoint: "update_operators"},
+		}, {
+			name: "status",
+			hook: Webhook{Status: types.OperationStatusFailed},
+		}, {
+			name: "contract tag",
+			hook: Webhook{Tag: types.FA2StringTag, Status: types.OperationStatusApplied},
+			want: true,
+		}, {
+			name: "other tag",
+			hook: Webhook{Tag: types.FA12StringTag},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.hook.Match(op))
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package noderpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stdJSON "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const blockHashFile = "block_hash"
+
+// CachedNode - node RPC which keeps immutable responses (blocks, operation groups, scripts, big map types and constants at certain level) in the local directory.
+// Responses are stored by the hash of the block and the request path. Requests to the head are never cached.
+// Cache of the levels above the rollback level have to be dropped by `Rollback` when the chain is reorganized.
+type CachedNode struct {
+	rawNode
+
+	dir string
+}
+
+// rawNode - node which can return raw response of the request. It's implemented by `NodeRPC` and `Pool`.
+type rawNode interface {
+	INode
+
+	getRawAt(ctx context.Context, method string, level int64, uri string) ([]byte, error)
+}
+
+// NewCachedNode -
+func NewCachedNode(node rawNode, dir string) *CachedNode {
+	return &CachedNode{
+		rawNode: node,
+		dir:     dir,
+	}
+}
+
+// Health - returns health state of the underlying nodes if they are tracked
+func (cn *CachedNode) Health() []NodeHealth {
+	if reporter, ok := cn.rawNode.(HealthReporter); ok {
+		return reporter.Health()
+	}
+	return nil
+}
+
+// Close - closes the underlying node if it's closable
+func (cn *CachedNode) Close() error {
+	if closer, ok := cn.rawNode.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Rollback - removes cached responses of the levels above `level`
+func (cn *CachedNode) Rollback(level int64) error {
+	entries, err := os.ReadDir(cn.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		entryLevel, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || entryLevel <= level {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(cn.dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cn *CachedNode) levelDir(level int64) string {
+	return filepath.Join(cn.dir, strconv.FormatInt(level, 10))
+}
+
+func (cn *CachedNode) blockHash(ctx context.Context, level int64) (string, error) {
+	name := filepath.Join(cn.levelDir(level), blockHashFile)
+	data, err := os.ReadFile(name)
+	switch {
+	case err == nil:
+		return string(data), nil
+	case !errors.Is(err, os.ErrNotExist):
+		return "", err
+	}
+
+	hash, err := cn.rawNode.BlockHash(ctx, level)
+	if err != nil {
+		return "", err
+	}
+	return hash, writeCacheFile(name, []byte(hash))
+}
+
+func (cn *CachedNode) cachedGetRaw(ctx context.Context, method string, level int64, uri string) ([]byte, error) {
+	if level <= 0 {
+		return cn.rawNode.getRawAt(ctx, method, level, uri)
+	}
+
+	hash, err := cn.blockHash(ctx, level)
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256([]byte(hash + "/" + uri))
+	name := filepath.Join(cn.levelDir(level), hex.EncodeToString(key[:]))
+
+	data, err := os.ReadFile(name)
+	switch {
+	case err == nil:
+		return data, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	data, err = cn.rawNode.getRawAt(ctx, method, level, uri)
+	if err != nil {
+		return nil, err
+	}
+	return data, writeCacheFile(name, data)
+}
+
+func (cn *CachedNode) cachedGet(ctx context.Context, method string, level int64, uri string, response any) error {
+	data, err := cn.cachedGetRaw(ctx, method, level, uri)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, response)
+}
+
+// writeCacheFile - writes file atomically, so partially written responses are never read from the cache
+func writeCacheFile(name string, data []byte) error {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// Block -
+func (cn *CachedNode) Block(ctx context.Context, level int64) (block Block, err error) {
+	err = cn.cachedGet(ctx, "Block", level, fmt.Sprintf("chains/main/blocks/%s", getBlockString(level)), &block)
+	return
+}
+
+// GetBlockMetadata -
+func (cn *CachedNode) GetBlockMetadata(ctx context.Context, level int64) (metadata Metadata, err error) {
+	err = cn.cachedGet(ctx, "GetBlockMetadata", level, fmt.Sprintf("chains/main/blocks/%s/metadata", getBlockString(level)), &metadata)
+	return
+}
+
+// GetOPG -
+func (cn *CachedNode) GetOPG(ctx context.Context, block int64) (group []OperationGroup, err error) {
+	err = cn.cachedGet(ctx, "GetOPG", block, fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
+	return
+}
+
+// GetLightOPG -
+func (cn *CachedNode) GetLightOPG(ctx context.Context, block int64) (group []LightOperationGroup, err error) {
+	err = cn.cachedGet(ctx, "GetLightOPG", block, fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
+	return
+}
+
+// GetScriptJSON -
+func (cn *CachedNode) GetScriptJSON(ctx context.Context, address string, level int64) (script Script, err error) {
+	err = cn.cachedGet(ctx, "GetScriptJSON", level, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address), &script)
+	return
+}
+
+// GetRawScript -
+func (cn *CachedNode) GetRawScript(ctx context.Context, address string, level int64) ([]byte, error) {
+	return cn.cachedGetRaw(ctx, "GetRawScript", level, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address))
+}
+
+// GetScriptStorageRaw -
+func (cn *CachedNode) GetScriptStorageRaw(ctx context.Context, address string, level int64) ([]byte, error) {
+	var response struct {
+		Storage stdJSON.RawMessage `json:"storage"`
+	}
+	err := cn.cachedGet(ctx, "GetScriptStorageRaw", level, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address), &response)
+	return response.Storage, err
+}
+
+// GetContractBalance -
+func (cn *CachedNode) GetContractBalance(ctx context.Context, address string, level int64) (int64, error) {
+	var balanceStr string
+	if err := cn.cachedGet(ctx, "GetContractBalance", level, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/balance", getBlockString(level), address), &balanceStr); err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(balanceStr, 10, 64)
+}
+
+// GetContractData -
+func (cn *CachedNode) GetContractData(ctx context.Context, address string, level int64) (response ContractData, err error) {
+	err = cn.cachedGet(ctx, "GetContractData", level, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s", getBlockString(level), address), &response)
+	return
+}
+
+// GetNetworkConstants -
+func (cn *CachedNode) GetNetworkConstants(ctx context.Context, level int64) (constants Constants, err error) {
+	err = cn.cachedGet(ctx, "GetNetworkConstants", level, fmt.Sprintf("chains/main/blocks/%s/context/constants", getBlockString(level)), &constants)
+	return
+}
+
+// GetBigMapType -
+func (cn *CachedNode) GetBigMapType(ctx context.Context, ptr, level int64) (bm BigMap, err error) {
+	err = cn.cachedGet(ctx, "GetBigMapType", level, fmt.Sprintf("chains/main/blocks/%s/context/raw/json/big_maps/index/%d", getBlockString(level), ptr), &bm)
+	return
+}
@@ -0,0 +1,61 @@
+package noderpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedNode(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/chains/main/blocks/10/hash", "/chains/main/blocks/head/hash":
+			_, _ = w.Write([]byte(`"BLockHash"`))
+		case "/chains/main/blocks/10/context/constants", "/chains/main/blocks/head/context/constants":
+			_, _ = w.Write([]byte(`{"cost_per_byte":"250","minimal_block_delay":"15"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	node := NewCachedNode(NewNodeRPC(server.URL), t.TempDir())
+
+	constants, err := node.GetNetworkConstants(ctx, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 250, constants.CostPerByte)
+	require.EqualValues(t, 15, constants.BlockDelay())
+	require.EqualValues(t, 2, requests.Load())
+
+	cached, err := node.GetNetworkConstants(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, constants, cached)
+	require.EqualValues(t, 2, requests.Load(), "cached response should be used")
+
+	_, err = node.GetNetworkConstants(ctx, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, requests.Load(), "head should not be cached")
+
+	require.NoError(t, node.Rollback(10))
+	_, err = node.GetNetworkConstants(ctx, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, requests.Load(), "levels below rollback level should be kept")
+
+	require.NoError(t, node.Rollback(9))
+	_, err = node.GetNetworkConstants(ctx, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, requests.Load(), "levels above rollback level should be invalidated")
+
+	_, err = node.GetBigMapType(ctx, 1, 10)
+	require.Error(t, err)
+	_, err = node.GetBigMapType(ctx, 1, 10)
+	require.Error(t, err)
+	require.EqualValues(t, 7, requests.Load(), "errors should not be cached")
+}
@@ -75,4 +75,10 @@ func (e InvalidNodeResponse) Is(target error) bool {
 var (
 	ErrInvalidStatusCode = errors.New("invalid status code")
 	ErrNodeRPCError      = errors.New("Node RPC error")
+	ErrNotFound          = errors.New("not found")
 )
+
+// IsNotFound - returns true if the node responded with 404 status code
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
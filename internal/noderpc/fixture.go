@@ -0,0 +1,246 @@
+package noderpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const replayBaseURL = "http://replay.local"
+
+// ErrNotRecorded - request is absent in the fixture
+var ErrNotRecorded = errors.New("request is not recorded")
+
+// Fixture - archive of the node responses. It's filled by recording node and used by replay node to serve responses offline.
+// Responses are stored by method, path and body of the request relative to the node base URL, so fixture is independent of the node address.
+type Fixture struct {
+	mx        sync.RWMutex
+	responses map[string]fixtureResponse
+}
+
+type fixtureResponse struct {
+	Key    string `json:"key"`
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+// NewFixture -
+func NewFixture() *Fixture {
+	return &Fixture{
+		responses: make(map[string]fixtureResponse),
+	}
+}
+
+// LoadFixture - reads gzipped fixture archive
+func LoadFixture(name string) (*Fixture, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+	defer gz.Close()
+
+	var responses []fixtureResponse
+	if err := json.NewDecoder(gz).Decode(&responses); err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	fixture := NewFixture()
+	for i := range responses {
+		fixture.responses[responses[i].Key] = responses[i]
+	}
+	return fixture, nil
+}
+
+// Save - writes gzipped fixture archive. Responses are sorted by request to keep archive stable between recordings.
+func (f *Fixture) Save(name string) error {
+	f.mx.RLock()
+	responses := make([]fixtureResponse, 0, len(f.responses))
+	for _, response := range f.responses {
+		responses = append(responses, response)
+	}
+	f.mx.RUnlock()
+
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Key < responses[j].Key
+	})
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	if err := json.NewEncoder(gz).Encode(responses); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Len - count of recorded responses
+func (f *Fixture) Len() int {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	return len(f.responses)
+}
+
+func (f *Fixture) add(response fixtureResponse) {
+	f.mx.Lock()
+	f.responses[response.Key] = response
+	f.mx.Unlock()
+}
+
+func (f *Fixture) get(key string) (fixtureResponse, bool) {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	response, ok := f.responses[key]
+	return response, ok
+}
+
+// fixtureKey - returns key of the request: method, path relative to the node base path and hash of the body if it's set
+func fixtureKey(req *http.Request, basePath string) (string, error) {
+	path := strings.TrimPrefix(req.URL.EscapedPath(), strings.TrimSuffix(basePath, "/"))
+	if req.URL.RawQuery != "" {
+		path += "?" + req.URL.RawQuery
+	}
+	key := req.Method + " " + path
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return key, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.Sum256(body)
+	return key + " " + hex.EncodeToString(hash[:]), nil
+}
+
+type recordingTransport struct {
+	next     http.RoundTripper
+	fixture  *Fixture
+	basePath string
+}
+
+// RoundTrip -
+func (t recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := fixtureKey(req, t.basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// server errors are not recorded to replay only stable responses of the node
+	if resp.StatusCode < http.StatusInternalServerError {
+		t.fixture.add(fixtureResponse{
+			Key:    key,
+			Status: resp.StatusCode,
+			Body:   string(body),
+		})
+	}
+	return resp, nil
+}
+
+type replayTransport struct {
+	fixture *Fixture
+}
+
+// RoundTrip -
+func (t replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := fixtureKey(req, "")
+	if err != nil {
+		return nil, err
+	}
+	response, ok := t.fixture.get(key)
+	if !ok {
+		return nil, errors.Wrap(ErrNotRecorded, key)
+	}
+	return &http.Response{
+		Status:        http.StatusText(response.Status),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// NewRecordingNode - creates node which sends requests to `baseURL` and records every response to `fixture`
+func NewRecordingNode(baseURL string, fixture *Fixture, opts ...NodeOption) (*NodeRPC, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	transport := recordingTransport{
+		next:     newTransport(),
+		fixture:  fixture,
+		basePath: u.EscapedPath(),
+	}
+	return NewNodeRPC(baseURL, append(opts, WithTransport(transport))...), nil
+}
+
+// NewReplayNode - creates node which serves responses from `fixture` without network. Request which is absent in the fixture fails with `ErrNotRecorded`.
+func NewReplayNode(fixture *Fixture, opts ...NodeOption) *NodeRPC {
+	return NewNodeRPC(replayBaseURL, append(opts, WithTransport(replayTransport{fixture}))...)
+}
+
+// NewFixtureNode - creates replay node from fixture archive `name`. If the archive doesn't exist, responses of the node at `baseURL` are recorded
+// and the returned `save` function writes them to the archive. It's the way to record fixture of end-to-end test once and run it offline later.
+func NewFixtureNode(name, baseURL string, opts ...NodeOption) (node *NodeRPC, save func() error, err error) {
+	fixture, err := LoadFixture(name)
+	switch {
+	case err == nil:
+		return NewReplayNode(fixture, opts...), func() error { return nil }, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, nil, err
+	case baseURL == "":
+		return nil, nil, errors.Wrapf(err, "fixture %s is not recorded and node URL is not set", name)
+	}
+
+	fixture = NewFixture()
+	node, err = NewRecordingNode(baseURL, fixture, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return node, func() error { return fixture.Save(name) }, nil
+}
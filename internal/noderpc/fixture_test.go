@@ -0,0 +1,75 @@
+package noderpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixture_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mainnet/chains/main/blocks/10/header":
+			_, _ = w.Write([]byte(`{"level":10,"hash":"BLockHash","predecessor":"BLockPredecessor","chain_id":"NetXdQprcVkpaWU"}`))
+		case "/mainnet/chains/main/blocks/10/context/constants":
+			_, _ = w.Write([]byte(`{"cost_per_byte":"250","minimal_block_delay":"15"}`))
+		case "/mainnet/chains/main/blocks/head/helpers/scripts/run_script_view":
+			_, _ = w.Write([]byte(`{"data":{"int":"1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	name := filepath.Join(t.TempDir(), "fixture.json.gz")
+
+	recorder, save, err := NewFixtureNode(name, server.URL+"/mainnet")
+	require.NoError(t, err)
+
+	header, err := recorder.GetHeader(ctx, 10)
+	require.NoError(t, err)
+	constants, err := recorder.GetNetworkConstants(ctx, 10)
+	require.NoError(t, err)
+	view, err := recorder.RunScriptView(ctx, RunScriptViewRequest{Contract: "KT1", View: "view"})
+	require.NoError(t, err)
+	_, err = recorder.GetBigMapType(ctx, 1, 10)
+	require.Error(t, err)
+	require.NoError(t, save())
+
+	server.Close()
+
+	replay, _, err := NewFixtureNode(name, "")
+	require.NoError(t, err)
+
+	replayHeader, err := replay.GetHeader(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, header, replayHeader)
+
+	replayConstants, err := replay.GetNetworkConstants(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, constants, replayConstants)
+
+	replayView, err := replay.RunScriptView(ctx, RunScriptViewRequest{Contract: "KT1", View: "view"})
+	require.NoError(t, err)
+	require.Equal(t, view, replayView)
+
+	_, err = replay.GetBigMapType(ctx, 1, 10)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotRecorded)
+
+	_, err = replay.RunScriptView(ctx, RunScriptViewRequest{Contract: "KT1", View: "other"})
+	require.ErrorIs(t, err, ErrNotRecorded)
+
+	_, err = replay.GetHeader(ctx, 11)
+	require.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestNewFixtureNode_WithoutFixtureAndURL(t *testing.T) {
+	_, _, err := NewFixtureNode(filepath.Join(t.TempDir(), "absent.json.gz"), "")
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package noderpc
 
 import (
+	"net/http"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -31,3 +32,10 @@ func WithLog() NodeOption {
 		node.needLog = true
 	}
 }
+
+// WithTransport - sets transport of HTTP client instead of default one
+func WithTransport(transport http.RoundTripper) NodeOption {
+	return func(node *NodeRPC) {
+		node.transport = transport
+	}
+}
@@ -2,312 +2,543 @@ package noderpc
 
 import (
 	"context"
-	"crypto/rand"
-	"math/big"
-	"reflect"
+	"net"
+	"net/url"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/dipdup-io/workerpool"
 	"github.com/pkg/errors"
 )
 
-// Pool - node pool
-type Pool []*poolItem
+// ErrNoAvailableNodes - all nodes of the pool are unavailable
+var ErrNoAvailableNodes = errors.New("No available nodes")
+
+// BreakerState - state of the node circuit breaker
+type BreakerState string
+
+// Breaker states. Requests are sent to the `closed` nodes. The `open` node doesn't receive requests until timeout is expired.
+// After that the node is `half_open`: single probe request is sent to the node and it's closed on success or opened again on failure.
+const (
+	BreakerStateClosed   BreakerState = "closed"
+	BreakerStateOpen     BreakerState = "open"
+	BreakerStateHalfOpen BreakerState = "half_open"
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultOpenTimeout      = time.Second * 30
+	defaultCheckInterval    = time.Second * 15
+	defaultCheckTimeout     = time.Second * 5
+
+	// weight of the last request in error rate and latency moving averages
+	healthSmoothing = 0.2
+)
+
+// NodeHealth - health state of the node in the pool
+type NodeHealth struct {
+	URL       string       `json:"url"`
+	State     BreakerState `json:"state"`
+	Level     int64        `json:"level"`
+	Lag       int64        `json:"lag"`
+	Requests  int64        `json:"requests"`
+	Failures  int64        `json:"failures"`
+	ErrorRate float64      `json:"error_rate"`
+	LatencyMs int64        `json:"latency_ms"`
+	OpenUntil *time.Time   `json:"open_until,omitempty"`
+}
+
+// HealthReporter - node which tracks health of the underlying nodes
+type HealthReporter interface {
+	Health() []NodeHealth
+}
+
+// Pool - node pool. Requests are sent to the healthy node which is closest to the head. If the node is unavailable or it hasn't reached the requested level yet, request is retried on the next one.
+// Head levels of the nodes are checked in background, so `Close` has to be called to stop it.
+type Pool struct {
+	nodes []*poolItem
+
+	failureThreshold int
+	openTimeout      time.Duration
+	checkInterval    time.Duration
+	checkTimeout     time.Duration
+
+	checkMx sync.Mutex
+	cancel  context.CancelFunc
+	g       workerpool.Group
+}
 
 type poolItem struct {
-	node      *NodeRPC
-	blockTime time.Time
+	node *NodeRPC
+	url  string
+
+	mx            sync.Mutex
+	state         BreakerState
+	openUntil     time.Time
+	probing       bool
+	failuresInRow int
+	requests      int64
+	failures      int64
+	errorRate     float64
+	latency       time.Duration
+	level         int64
 }
 
-func newPoolItem(url string, opts ...NodeOption) *poolItem {
+func newPoolItem(node *NodeRPC, uri string) *poolItem {
 	return &poolItem{
-		node:      NewNodeRPC(url, opts...),
-		blockTime: time.Now(),
+		node:  node,
+		url:   uri,
+		state: BreakerStateClosed,
 	}
 }
 
-func newWaitPoolItem(url string, opts ...NodeOption) *poolItem {
-	return &poolItem{
-		node:      NewWaitNodeRPC(url, opts...),
-		blockTime: time.Now(),
+// acquire - checks the node can receive request. The open node with expired timeout becomes half-open and receives single probe request.
+func (p *poolItem) acquire(now time.Time) bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	switch {
+	case p.state == BreakerStateClosed:
+		return true
+	case p.probing || now.Before(p.openUntil):
+		return false
+	default:
+		p.state = BreakerStateHalfOpen
+		p.probing = true
+		return true
+	}
+}
+
+// release - saves result of the request to the node
+func (p *poolItem) release(failed bool, latency time.Duration, threshold int, openTimeout time.Duration) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.requests++
+	probe := p.probing
+	p.probing = false
+
+	if failed {
+		p.failures++
+		p.failuresInRow++
+		p.errorRate += healthSmoothing * (1 - p.errorRate)
+
+		if probe || p.failuresInRow >= threshold {
+			p.state = BreakerStateOpen
+			p.openUntil = time.Now().Add(openTimeout)
+		}
+		return
 	}
+
+	p.failuresInRow = 0
+	p.errorRate -= healthSmoothing * p.errorRate
+	if p.latency == 0 {
+		p.latency = latency
+	} else {
+		p.latency += time.Duration(healthSmoothing * float64(latency-p.latency))
+	}
+	if probe {
+		p.state = BreakerStateClosed
+	}
+}
+
+func (p *poolItem) setLevel(level int64) {
+	p.mx.Lock()
+	p.level = level
+	p.mx.Unlock()
 }
 
-func (p *poolItem) block() {
-	p.blockTime = time.Now().Add(time.Minute * 5)
+// behind - checks the node's known head is below `level`
+func (p *poolItem) behind(level int64) bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	return p.level > 0 && level > p.level
 }
 
-// func (p *poolItem) isBlocked() bool {
-// 	return time.Now().After(p.blockTime)
-// }
+func (p *poolItem) health(head int64, now time.Time) NodeHealth {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	h := NodeHealth{
+		URL:       p.url,
+		State:     p.state,
+		Level:     p.level,
+		Requests:  p.requests,
+		Failures:  p.failures,
+		ErrorRate: p.errorRate,
+		LatencyMs: p.latency.Milliseconds(),
+	}
+	if p.level > 0 && head > p.level {
+		h.Lag = head - p.level
+	}
+	if p.state == BreakerStateOpen {
+		if now.Before(p.openUntil) {
+			openUntil := p.openUntil
+			h.OpenUntil = &openUntil
+		} else {
+			h.State = BreakerStateHalfOpen
+		}
+	}
+	return h
+}
 
 // NewPool - creates `Pool` struct by `urls`
-func NewPool(urls []string, opts ...NodeOption) Pool {
-	pool := make(Pool, len(urls))
+func NewPool(urls []string, opts ...NodeOption) *Pool {
+	pool := newPool(len(urls))
 	for i := range urls {
-		pool[i] = newPoolItem(urls[i], opts...)
+		pool.nodes[i] = newPoolItem(NewNodeRPC(urls[i], opts...), urls[i])
 	}
+	pool.start()
 	return pool
 }
 
 // NewWaitPool -
-func NewWaitPool(urls []string, opts ...NodeOption) Pool {
-	pool := make(Pool, len(urls))
+func NewWaitPool(urls []string, opts ...NodeOption) *Pool {
+	pool := newPool(len(urls))
 	for i := range urls {
-		pool[i] = newWaitPoolItem(urls[i], opts...)
+		pool.nodes[i] = newPoolItem(NewWaitNodeRPC(urls[i], opts...), urls[i])
 	}
+	pool.start()
 	return pool
 }
 
-func (p Pool) getNode() (*poolItem, error) {
-	nodes := make([]*poolItem, 0)
-	for i := range p {
-		nodes = append(nodes, p[i])
+func newPool(size int) *Pool {
+	return &Pool{
+		nodes:            make([]*poolItem, size),
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+		checkInterval:    defaultCheckInterval,
+		checkTimeout:     defaultCheckTimeout,
+		g:                workerpool.NewGroup(),
 	}
+}
 
-	switch len(nodes) {
-	case 0:
-		return nil, errors.Errorf("No available nodes")
-	case 1:
-		return nodes[0], nil
-	default:
-		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(nodes))))
-		if err != nil {
-			return nil, err
-		}
+func (p *Pool) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.g.GoCtx(ctx, p.run)
+}
 
-		return nodes[idx.Int64()], nil
+// Close - stops background check of the nodes levels
+func (p *Pool) Close() error {
+	if p.cancel != nil {
+		p.cancel()
 	}
+	p.g.Wait()
+	return nil
 }
 
-func (p Pool) call(method string, args ...interface{}) (reflect.Value, error) {
-	node, err := p.getNode()
-	if err != nil {
-		return reflect.Value{}, err
-	}
-	nodeVal := reflect.ValueOf(&node.node)
-	if nodeVal.Kind() == reflect.Ptr {
-		nodeVal = nodeVal.Elem()
+func (p *Pool) run(ctx context.Context) {
+	p.checkLevels(ctx)
+
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkLevels(ctx)
+		}
 	}
+}
 
-	mthd := nodeVal.MethodByName(method)
-	numIn := mthd.Type().NumIn()
-	if numIn != len(args) {
-		return reflect.Value{}, errors.Errorf("Invalid args count: wait %d got %d", numIn, len(args))
+// Health - returns health state of the pool nodes
+func (p *Pool) Health() []NodeHealth {
+	head := p.head()
+	now := time.Now()
+
+	result := make([]NodeHealth, len(p.nodes))
+	for i := range p.nodes {
+		result[i] = p.nodes[i].health(head, now)
+		result[i].URL = hideCredentials(result[i].URL)
 	}
+	return result
+}
 
-	in := make([]reflect.Value, numIn)
-	for i := range args {
-		in[i] = reflect.ValueOf(args[i])
+func (p *Pool) head() int64 {
+	var head int64
+	for i := range p.nodes {
+		p.nodes[i].mx.Lock()
+		if p.nodes[i].level > head {
+			head = p.nodes[i].level
+		}
+		p.nodes[i].mx.Unlock()
 	}
+	return head
+}
 
-	response := mthd.Call(in)
+// checkLevels - requests head level of every available node to find lagging nodes. It's also the probe of half-open nodes.
+func (p *Pool) checkLevels(ctx context.Context) {
+	p.checkMx.Lock()
+	defer p.checkMx.Unlock()
 
-	switch len(response) {
-	case 1:
-		if !response[0].IsNil() {
-			return reflect.Value{}, response[0].Interface().(error)
+	now := time.Now()
+	requestCtx, cancel := context.WithTimeout(ctx, p.checkTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, item := range p.nodes {
+		if !item.acquire(now) {
+			continue
 		}
-		return reflect.Value{}, nil
-	case 2:
-		if !response[1].IsNil() {
-			if IsNodeUnavailiableError(response[1].Interface().(error)) {
-				node.block()
-				return p.call(method, args...)
+		wg.Add(1)
+		go func(item *poolItem) {
+			defer wg.Done()
+
+			start := time.Now()
+			level, err := item.node.GetLevel(requestCtx)
+			item.release(isNodeFailure(ctx, err), time.Since(start), p.failureThreshold, p.openTimeout)
+			if err == nil {
+				item.setLevel(level)
 			}
-			return response[0], response[1].Interface().(error)
+		}(item)
+	}
+	wg.Wait()
+}
+
+// getNode - returns the best available node which was not tried yet: closed nodes are preferred to half-open, then nodes closest to the head, then nodes with less errors and latency.
+func (p *Pool) getNode(tried map[*poolItem]struct{}) (*poolItem, error) {
+	head := p.head()
+	now := time.Now()
+
+	candidates := make([]*poolItem, 0, len(p.nodes))
+	states := make(map[*poolItem]NodeHealth, len(p.nodes))
+	for _, item := range p.nodes {
+		if _, ok := tried[item]; ok {
+			continue
+		}
+		h := item.health(head, now)
+		if h.OpenUntil != nil {
+			continue
+		}
+		candidates = append(candidates, item)
+		states[item] = h
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		hi, hj := states[candidates[i]], states[candidates[j]]
+		switch {
+		case hi.State != hj.State:
+			return hi.State == BreakerStateClosed
+		case hi.Lag != hj.Lag:
+			return hi.Lag < hj.Lag
+		case hi.ErrorRate != hj.ErrorRate:
+			return hi.ErrorRate < hj.ErrorRate
+		default:
+			return hi.LatencyMs < hj.LatencyMs
+		}
+	})
+
+	for _, item := range candidates {
+		if item.acquire(now) {
+			return item, nil
 		}
-		return response[0], nil
-	default:
-		node.block()
-		return reflect.Value{}, errors.Errorf("Invalid response length: %d", len(response))
 	}
+	return nil, ErrNoAvailableNodes
 }
 
-// Block -
-func (p Pool) Block(ctx context.Context, level int64) (Block, error) {
-	data, err := p.call("Block", ctx, level)
-	if err != nil {
-		return Block{}, err
+// isNodeFailure - checks the error is caused by the node: node is unreachable, responses with server error or timeout.
+// Errors of the request itself (not found, invalid request and etc.) and cancellation by caller are not node failures.
+func isNodeFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
 	}
-	return data.Interface().(Block), nil
+	var unavailable NodeUnavailiableError
+	if errors.As(err, &unavailable) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
 }
 
-// BlockHash -
-func (p Pool) BlockHash(ctx context.Context, level int64) (string, error) {
-	data, err := p.call("BlockHash", ctx, level)
+func hideCredentials(uri string) string {
+	u, err := url.Parse(uri)
 	if err != nil {
-		return "", err
+		return ""
 	}
-	return data.Interface().(string), nil
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
 }
 
-// GetHead -
-func (p Pool) GetHead(ctx context.Context) (Header, error) {
-	data, err := p.call("GetHead", ctx)
-	if err != nil {
-		return Header{}, err
+// call - sends request to the pool nodes until it's succeeded. Request of the `level` (0 is head) not found on the node which hasn't reached the level yet is retried on the next node too.
+func call[T any](ctx context.Context, p *Pool, level int64, request func(node *NodeRPC) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	tried := make(map[*poolItem]struct{})
+	for {
+		item, nodeErr := p.getNode(tried)
+		if nodeErr != nil {
+			if err != nil {
+				return result, err
+			}
+			return result, nodeErr
+		}
+		tried[item] = struct{}{}
+
+		start := time.Now()
+		result, err = request(item.node)
+		failed := isNodeFailure(ctx, err)
+		item.release(failed, time.Since(start), p.failureThreshold, p.openTimeout)
+		if !failed && !(IsNotFound(err) && item.behind(level)) {
+			return result, err
+		}
 	}
-	return data.Interface().(Header), nil
+}
+
+// Block -
+func (p *Pool) Block(ctx context.Context, level int64) (Block, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (Block, error) {
+		return node.Block(ctx, level)
+	})
+}
+
+// BlockHash -
+func (p *Pool) BlockHash(ctx context.Context, level int64) (string, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (string, error) {
+		return node.BlockHash(ctx, level)
+	})
+}
+
+// GetHead -
+func (p *Pool) GetHead(ctx context.Context) (Header, error) {
+	return p.GetHeader(ctx, 0)
 }
 
 // GetHeader -
-func (p Pool) GetHeader(ctx context.Context, block int64) (Header, error) {
-	data, err := p.call("GetHeader", ctx, block)
-	if err != nil {
-		return Header{}, err
-	}
-	return data.Interface().(Header), nil
+func (p *Pool) GetHeader(ctx context.Context, block int64) (Header, error) {
+	return call(ctx, p, block, func(node *NodeRPC) (Header, error) {
+		return node.GetHeader(ctx, block)
+	})
 }
 
 // GetLevel -
-func (p Pool) GetLevel(ctx context.Context) (int64, error) {
-	data, err := p.call("GetLevel", ctx)
-	if err != nil {
-		return 0, err
-	}
-	return data.Int(), nil
+func (p *Pool) GetLevel(ctx context.Context) (int64, error) {
+	return call(ctx, p, 0, func(node *NodeRPC) (int64, error) {
+		return node.GetLevel(ctx)
+	})
 }
 
 // GetScriptJSON -
-func (p Pool) GetScriptJSON(ctx context.Context, address string, level int64) (Script, error) {
-	data, err := p.call("GetScriptJSON", ctx, address, level)
-	if err != nil {
-		return Script{}, err
-	}
-	return data.Interface().(Script), nil
+func (p *Pool) GetScriptJSON(ctx context.Context, address string, level int64) (Script, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (Script, error) {
+		return node.GetScriptJSON(ctx, address, level)
+	})
 }
 
 // GetScriptStorageRaw -
-func (p Pool) GetScriptStorageRaw(ctx context.Context, address string, level int64) ([]byte, error) {
-	data, err := p.call("GetScriptStorageRaw", ctx, address, level)
-	if err != nil {
-		return nil, err
-	}
-	return data.Interface().([]byte), nil
+func (p *Pool) GetScriptStorageRaw(ctx context.Context, address string, level int64) ([]byte, error) {
+	return call(ctx, p, level, func(node *NodeRPC) ([]byte, error) {
+		return node.GetScriptStorageRaw(ctx, address, level)
+	})
 }
 
 // GetContractBalance -
-func (p Pool) GetContractBalance(ctx context.Context, address string, level int64) (int64, error) {
-	data, err := p.call("GetContractBalance", ctx, address, level)
-	if err != nil {
-		return 0, err
-	}
-	return data.Int(), nil
+func (p *Pool) GetContractBalance(ctx context.Context, address string, level int64) (int64, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (int64, error) {
+		return node.GetContractBalance(ctx, address, level)
+	})
 }
 
 // GetContractData -
-func (p Pool) GetContractData(ctx context.Context, address string, level int64) (ContractData, error) {
-	data, err := p.call("GetContractData", ctx, address, level)
-	if err != nil {
-		return ContractData{}, err
-	}
-	return data.Interface().(ContractData), nil
+func (p *Pool) GetContractData(ctx context.Context, address string, level int64) (ContractData, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (ContractData, error) {
+		return node.GetContractData(ctx, address, level)
+	})
 }
 
 // GetOPG -
-func (p Pool) GetOPG(ctx context.Context, block int64) ([]OperationGroup, error) {
-	data, err := p.call("GetOPG", ctx, block)
-	if err != nil {
-		return nil, err
-	}
-	return data.Interface().([]OperationGroup), nil
+func (p *Pool) GetOPG(ctx context.Context, block int64) ([]OperationGroup, error) {
+	return call(ctx, p, block, func(node *NodeRPC) ([]OperationGroup, error) {
+		return node.GetOPG(ctx, block)
+	})
 }
 
 // GetLightOPG -
-func (p Pool) GetLightOPG(ctx context.Context, block int64) ([]LightOperationGroup, error) {
-	data, err := p.call("GetLightOPG", ctx, block)
-	if err != nil {
-		return nil, err
-	}
-	return data.Interface().([]LightOperationGroup), nil
+func (p *Pool) GetLightOPG(ctx context.Context, block int64) ([]LightOperationGroup, error) {
+	return call(ctx, p, block, func(node *NodeRPC) ([]LightOperationGroup, error) {
+		return node.GetLightOPG(ctx, block)
+	})
 }
 
 // GetContractsByBlock -
-func (p Pool) GetContractsByBlock(ctx context.Context, block int64) ([]string, error) {
-	data, err := p.call("GetContractsByBlock", ctx, block)
-	if err != nil {
-		return nil, err
-	}
-	return data.Interface().([]string), nil
+func (p *Pool) GetContractsByBlock(ctx context.Context, block int64) ([]string, error) {
+	return call(ctx, p, block, func(node *NodeRPC) ([]string, error) {
+		return node.GetContractsByBlock(ctx, block)
+	})
 }
 
 // GetNetworkConstants -
-func (p Pool) GetNetworkConstants(ctx context.Context, level int64) (res Constants, err error) {
-	data, err := p.call("GetNetworkConstants", ctx, level)
-	if err != nil {
-		return res, err
-	}
-	return data.Interface().(Constants), nil
+func (p *Pool) GetNetworkConstants(ctx context.Context, level int64) (Constants, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (Constants, error) {
+		return node.GetNetworkConstants(ctx, level)
+	})
 }
 
 // RunCode -
-func (p Pool) RunCode(ctx context.Context, script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (RunCodeResponse, error) {
-	data, err := p.call("RunCode", ctx, script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
-	if err != nil {
-		return RunCodeResponse{}, err
-	}
-	return data.Interface().(RunCodeResponse), nil
+func (p *Pool) RunCode(ctx context.Context, script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (RunCodeResponse, error) {
+	return call(ctx, p, 0, func(node *NodeRPC) (RunCodeResponse, error) {
+		return node.RunCode(ctx, script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
+	})
 }
 
 // RunOperation -
-func (p Pool) RunOperation(ctx context.Context, chainID, branch, source, destination string, fee, gasLimit, storageLimit, counter, amount int64, parameters []byte) (OperationGroup, error) {
-	data, err := p.call("RunOperation", ctx, chainID, branch, source, destination, fee, gasLimit, storageLimit, counter, amount, parameters)
-	if err != nil {
-		return OperationGroup{}, err
-	}
-	return data.Interface().(OperationGroup), nil
+func (p *Pool) RunOperation(ctx context.Context, chainID, branch, source, destination string, fee, gasLimit, storageLimit, counter, amount int64, parameters []byte) (OperationGroup, error) {
+	return call(ctx, p, 0, func(node *NodeRPC) (OperationGroup, error) {
+		return node.RunOperation(ctx, chainID, branch, source, destination, fee, gasLimit, storageLimit, counter, amount, parameters)
+	})
 }
 
 // RunOperationLight -
-func (p Pool) RunOperationLight(ctx context.Context, chainID, branch, source, destination string, fee, gasLimit, storageLimit, counter, amount int64, parameters []byte) (LightOperationGroup, error) {
-	data, err := p.call("RunOperationLight", ctx, chainID, branch, source, destination, fee, gasLimit, storageLimit, counter, amount, parameters)
-	if err != nil {
-		return LightOperationGroup{}, err
-	}
-	return data.Interface().(LightOperationGroup), nil
+func (p *Pool) RunOperationLight(ctx context.Context, chainID, branch, source, destination string, fee, gasLimit, storageLimit, counter, amount int64, parameters []byte) (LightOperationGroup, error) {
+	return call(ctx, p, 0, func(node *NodeRPC) (LightOperationGroup, error) {
+		return node.RunOperationLight(ctx, chainID, branch, source, destination, fee, gasLimit, storageLimit, counter, amount, parameters)
+	})
 }
 
 // GetCounter -
-func (p Pool) GetCounter(ctx context.Context, address string) (int64, error) {
-	data, err := p.call("GetCounter", ctx, address)
-	if err != nil {
-		return 0, err
-	}
-	return data.Int(), nil
+func (p *Pool) GetCounter(ctx context.Context, address string) (int64, error) {
+	return call(ctx, p, 0, func(node *NodeRPC) (int64, error) {
+		return node.GetCounter(ctx, address)
+	})
 }
 
 // GetBigMapType -
-func (p Pool) GetBigMapType(ctx context.Context, ptr, level int64) (BigMap, error) {
-	data, err := p.call("GetBigMapType", ctx, ptr, level)
-	if err != nil {
-		return BigMap{}, err
-	}
-	return data.Interface().(BigMap), nil
+func (p *Pool) GetBigMapType(ctx context.Context, ptr, level int64) (BigMap, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (BigMap, error) {
+		return node.GetBigMapType(ctx, ptr, level)
+	})
 }
 
 // GetBlockMetadata -
-func (p Pool) GetBlockMetadata(ctx context.Context, level int64) (Metadata, error) {
-	data, err := p.call("GetBlockMetadata", ctx, level)
-	if err != nil {
-		return Metadata{}, err
-	}
-	return data.Interface().(Metadata), nil
+func (p *Pool) GetBlockMetadata(ctx context.Context, level int64) (Metadata, error) {
+	return call(ctx, p, level, func(node *NodeRPC) (Metadata, error) {
+		return node.GetBlockMetadata(ctx, level)
+	})
 }
 
 // GetRawScript -
-func (p Pool) GetRawScript(ctx context.Context, address string, level int64) ([]byte, error) {
-	data, err := p.call("GetRawScript", ctx, address, level)
-	if err != nil {
-		return nil, err
-	}
-	return data.Interface().([]byte), nil
+func (p *Pool) GetRawScript(ctx context.Context, address string, level int64) ([]byte, error) {
+	return call(ctx, p, level, func(node *NodeRPC) ([]byte, error) {
+		return node.GetRawScript(ctx, address, level)
+	})
 }
 
 // RunScriptView -
-func (p Pool) RunScriptView(ctx context.Context, request RunScriptViewRequest) ([]byte, error) {
-	data, err := p.call("RunScriptView", ctx, request)
-	if err != nil {
-		return nil, err
-	}
-	return data.Interface().([]byte), nil
+func (p *Pool) RunScriptView(ctx context.Context, request RunScriptViewRequest) ([]byte, error) {
+	return call(ctx, p, 0, func(node *NodeRPC) ([]byte, error) {
+		return node.RunScriptView(ctx, request)
+	})
+}
+
+func (p *Pool) getRawAt(ctx context.Context, method string, level int64, uri string) ([]byte, error) {
+	return call(ctx, p, level, func(node *NodeRPC) ([]byte, error) {
+		return node.getRaw(ctx, method, uri)
+	})
 }
@@ -115,6 +115,12 @@ type Operation struct {
 	Kernel             string             `json:"kernel,omitempty"`
 	CementedCommitment string             `json:"cemented_commitment,omitempty"`
 	OutputProof        string             `json:"output_proof,omitempty"`
+	Opponent           string             `json:"opponent,omitempty"`
+	Staker             string             `json:"staker,omitempty"`
+	Message            []string           `json:"message,omitempty"`
+	Commitment         stdJSON.RawMessage `json:"commitment,omitempty"`
+	Refutation         *Refutation        `json:"refutation,omitempty"`
+	Stakers            *Stakers           `json:"stakers,omitempty"`
 	Parameters         stdJSON.RawMessage `json:"parameters,omitempty"`
 	Metadata           *OperationMetadata `json:"metadata,omitempty"`
 	Result             *OperationResult   `json:"result,omitempty"`
@@ -155,6 +161,12 @@ type LightOperation struct {
 	Kind        string             `json:"kind"`
 	Source      string             `json:"source"`
 	Destination *string            `json:"destination,omitempty"`
+	Parameters  *LightParameters   `json:"parameters,omitempty"`
+}
+
+// LightParameters -
+type LightParameters struct {
+	Entrypoint string `json:"entrypoint"`
 }
 
 // UnmarshalJSON -
@@ -211,6 +223,64 @@ type OperationResult struct {
 	Address                      string             `json:"address,omitempty"`
 	GenesisCommitmentHash        string             `json:"genesis_commitment_hash,omitempty"`
 	Size                         string             `json:"size,omitempty"`
+	InboxLevel                   int64              `json:"inbox_level,omitempty"`
+	CommitmentHash               string             `json:"commitment_hash,omitempty"`
+	StakedHash                   string             `json:"staked_hash,omitempty"`
+	PublishedAtLevel             int64              `json:"published_at_level,omitempty"`
+	GameStatus                   *GameStatus        `json:"game_status,omitempty"`
+}
+
+// Commitment - smart rollup commitment published by `smart_rollup_publish`
+type Commitment struct {
+	CompressedState string `json:"compressed_state"`
+	InboxLevel      int64  `json:"inbox_level"`
+	Predecessor     string `json:"predecessor"`
+	NumberOfTicks   int64  `json:"number_of_ticks,string"`
+}
+
+// Refutation - move of smart rollup refutation game. Game is started by `start` move with conflicting commitments,
+// `move` contains dissection or proof for the chosen tick.
+type Refutation struct {
+	Kind                   string             `json:"refutation_kind"`
+	PlayerCommitmentHash   string             `json:"player_commitment_hash,omitempty"`
+	OpponentCommitmentHash string             `json:"opponent_commitment_hash,omitempty"`
+	Choice                 string             `json:"choice,omitempty"`
+	Step                   stdJSON.RawMessage `json:"step,omitempty"`
+}
+
+// Stakers - players of smart rollup refutation game which is finished by `smart_rollup_timeout`
+type Stakers struct {
+	Alice string `json:"alice"`
+	Bob   string `json:"bob"`
+}
+
+// GameStatus - status of smart rollup refutation game. Node returns `ongoing` string or result object with loser and reason.
+type GameStatus struct {
+	Status string
+	Loser  string
+	Reason string
+}
+
+// UnmarshalJSON -
+func (gs *GameStatus) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &gs.Status)
+	}
+
+	var status struct {
+		Result struct {
+			Kind   string `json:"kind"`
+			Reason string `json:"reason"`
+			Player string `json:"player"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return err
+	}
+	gs.Status = status.Result.Kind
+	gs.Loser = status.Result.Player
+	gs.Reason = status.Result.Reason
+	return nil
 }
 
 // LazyStorageDiff -
@@ -99,3 +99,46 @@ func TestLazyStorageDiff_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestGameStatus_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    GameStatus
+		wantErr bool
+	}{
+		{
+			name: "ongoing",
+			data: []byte(`"ongoing"`),
+			want: GameStatus{Status: "ongoing"},
+		}, {
+			name: "loser",
+			data: []byte(`{"result":{"kind":"loser","reason":"conflict_resolved","player":"tz1TiuhDeTXb7gXDF1Yt1Ee5GVAt8g7MrM9F"}}`),
+			want: GameStatus{
+				Status: "loser",
+				Reason: "conflict_resolved",
+				Loser:  "tz1TiuhDeTXb7gXDF1Yt1Ee5GVAt8g7MrM9F",
+			},
+		}, {
+			name: "draw",
+			data: []byte(`{"result":{"kind":"draw"}}`),
+			want: GameStatus{Status: "draw"},
+		}, {
+			name:    "invalid",
+			data:    []byte(`[]`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got GameStatus
+			if err := got.UnmarshalJSON(tt.data); (err != nil) != tt.wantErr {
+				t.Errorf("GameStatus.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
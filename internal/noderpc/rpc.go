@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/metrics"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -37,7 +38,11 @@ type NodeRPC struct {
 	baseURL string
 	client  *http.Client
 
+	// label - base URL without credentials which identifies the node in metrics
+	label string
+
 	timeout   time.Duration
+	transport http.RoundTripper
 	userAgent string
 	rateLimit *rate.Limiter
 	needLog   bool
@@ -47,6 +52,7 @@ type NodeRPC struct {
 func NewNodeRPC(baseURL string, opts ...NodeOption) *NodeRPC {
 	node := &NodeRPC{
 		baseURL:   baseURL,
+		label:     hideCredentials(baseURL),
 		timeout:   time.Second * 10,
 		userAgent: userAgent,
 	}
@@ -59,18 +65,25 @@ func NewNodeRPC(baseURL string, opts ...NodeOption) *NodeRPC {
 		opt(node)
 	}
 
-	t := http.DefaultTransport.(*http.Transport).Clone()
-	t.MaxIdleConns = 20
-	t.MaxConnsPerHost = 20
-	t.MaxIdleConnsPerHost = 20
+	if node.transport == nil {
+		node.transport = newTransport()
+	}
 	node.client = &http.Client{
 		Timeout:   node.timeout,
-		Transport: t,
+		Transport: node.transport,
 	}
 
 	return node
 }
 
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = 20
+	t.MaxConnsPerHost = 20
+	t.MaxIdleConnsPerHost = 20
+	return t
+}
+
 // NewWaitNodeRPC -
 func NewWaitNodeRPC(baseURL string, opts ...NodeOption) *NodeRPC {
 	node := NewNodeRPC(baseURL, opts...)
@@ -91,7 +104,7 @@ func (rpc *NodeRPC) checkStatusCode(r io.Reader, statusCode int, checkStatusCode
 	case statusCode == http.StatusOK:
 		return nil
 	case statusCode == http.StatusNotFound:
-		return errors.Errorf("%s: not found", uri)
+		return errors.Wrap(ErrNotFound, uri)
 	case statusCode > http.StatusInternalServerError:
 		return NewNodeUnavailiableError(rpc.baseURL, statusCode)
 	case checkStatusCode:
@@ -116,6 +129,14 @@ func (rpc *NodeRPC) parseResponse(r io.Reader, statusCode int, checkStatusCode b
 	return json.NewDecoder(r).Decode(response)
 }
 
+// observe - updates metrics of the request. Requests which are canceled by caller are not counted as failed.
+func (rpc *NodeRPC) observe(method string, start time.Time, err error) {
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	metrics.RPCRequest(rpc.label, method, time.Since(start), err)
+}
+
 func (rpc *NodeRPC) makeRequest(req *http.Request) (*http.Response, error) {
 	req.Header.Set("User-Agent", rpc.userAgent)
 	return rpc.client.Do(req)
@@ -150,7 +171,7 @@ func (rpc *NodeRPC) makePostRequest(ctx context.Context, uri string, data interf
 	return rpc.makeRequest(req)
 }
 
-func (rpc *NodeRPC) get(ctx context.Context, uri string, response interface{}) error {
+func (rpc *NodeRPC) get(ctx context.Context, method, uri string, response interface{}) (err error) {
 	if rpc.rateLimit != nil {
 		if err := rpc.rateLimit.Wait(ctx); err != nil {
 			return err
@@ -159,6 +180,7 @@ func (rpc *NodeRPC) get(ctx context.Context, uri string, response interface{}) e
 
 	start := time.Now()
 	defer func() {
+		rpc.observe(method, start, err)
 		if rpc.needLog {
 			log.Info().Str("method", "get").Int64("ms", time.Since(start).Milliseconds()).Msg(uri)
 		}
@@ -178,7 +200,12 @@ func (rpc *NodeRPC) get(ctx context.Context, uri string, response interface{}) e
 	return rpc.parseResponse(buffer, resp.StatusCode, true, resp.Request.URL.String(), response)
 }
 
-func (rpc *NodeRPC) getRaw(ctx context.Context, uri string) ([]byte, error) {
+// getRawAt - single node doesn't depend on the requested level
+func (rpc *NodeRPC) getRawAt(ctx context.Context, method string, _ int64, uri string) ([]byte, error) {
+	return rpc.getRaw(ctx, method, uri)
+}
+
+func (rpc *NodeRPC) getRaw(ctx context.Context, method, uri string) (data []byte, err error) {
 	if rpc.rateLimit != nil {
 		if err := rpc.rateLimit.Wait(ctx); err != nil {
 			return nil, err
@@ -187,6 +214,7 @@ func (rpc *NodeRPC) getRaw(ctx context.Context, uri string) ([]byte, error) {
 
 	start := time.Now()
 	defer func() {
+		rpc.observe(method, start, err)
 		if rpc.needLog {
 			log.Info().Str("method", "get").Int64("ms", time.Since(start).Milliseconds()).Msg(uri)
 		}
@@ -204,7 +232,7 @@ func (rpc *NodeRPC) getRaw(ctx context.Context, uri string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-func (rpc *NodeRPC) post(ctx context.Context, uri string, data interface{}, checkStatusCode bool, response interface{}) error {
+func (rpc *NodeRPC) post(ctx context.Context, method, uri string, data interface{}, checkStatusCode bool, response interface{}) (err error) {
 	if rpc.rateLimit != nil {
 		if err := rpc.rateLimit.Wait(ctx); err != nil {
 			return err
@@ -213,6 +241,7 @@ func (rpc *NodeRPC) post(ctx context.Context, uri string, data interface{}, chec
 
 	start := time.Now()
 	defer func() {
+		rpc.observe(method, start, err)
 		if rpc.needLog {
 			log.Info().Str("method", "post").Int64("ms", time.Since(start).Milliseconds()).Msg(uri)
 		}
@@ -234,13 +263,13 @@ func (rpc *NodeRPC) post(ctx context.Context, uri string, data interface{}, chec
 
 // Block - returns block
 func (rpc *NodeRPC) Block(ctx context.Context, level int64) (block Block, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s", getBlockString(level)), &block)
+	err = rpc.get(ctx, "Block", fmt.Sprintf("chains/main/blocks/%s", getBlockString(level)), &block)
 	return
 }
 
 // BlockHash - returns block's hash, its unique identifier.
 func (rpc *NodeRPC) BlockHash(ctx context.Context, level int64) (hash string, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/hash", getBlockString(level)), &hash)
+	err = rpc.get(ctx, "BlockHash", fmt.Sprintf("chains/main/blocks/%s/hash", getBlockString(level)), &hash)
 	return
 }
 
@@ -254,7 +283,7 @@ func (rpc *NodeRPC) GetLevel(ctx context.Context) (int64, error) {
 	var head struct {
 		Level int64 `json:"level"`
 	}
-	if err := rpc.get(ctx, "chains/main/blocks/head/helpers/current_level", &head); err != nil {
+	if err := rpc.get(ctx, "GetLevel", "chains/main/blocks/head/helpers/current_level", &head); err != nil {
 		return 0, err
 	}
 	return head.Level, nil
@@ -262,19 +291,19 @@ func (rpc *NodeRPC) GetLevel(ctx context.Context) (int64, error) {
 
 // GetHeader - get head for certain level
 func (rpc *NodeRPC) GetHeader(ctx context.Context, level int64) (header Header, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/header", getBlockString(level)), &header)
+	err = rpc.get(ctx, "GetHeader", fmt.Sprintf("chains/main/blocks/%s/header", getBlockString(level)), &header)
 	return
 }
 
 // GetScriptJSON -
 func (rpc *NodeRPC) GetScriptJSON(ctx context.Context, address string, level int64) (script Script, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address), &script)
+	err = rpc.get(ctx, "GetScriptJSON", fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address), &script)
 	return
 }
 
 // GetRawScript -
 func (rpc *NodeRPC) GetRawScript(ctx context.Context, address string, level int64) ([]byte, error) {
-	return rpc.getRaw(ctx, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address))
+	return rpc.getRaw(ctx, "GetRawScript", fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address))
 }
 
 // GetScriptStorageRaw -
@@ -282,14 +311,14 @@ func (rpc *NodeRPC) GetScriptStorageRaw(ctx context.Context, address string, lev
 	var response struct {
 		Storage stdJSON.RawMessage `json:"storage"`
 	}
-	err := rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address), &response)
+	err := rpc.get(ctx, "GetScriptStorageRaw", fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/script", getBlockString(level), address), &response)
 	return response.Storage, err
 }
 
 // GetContractBalance -
 func (rpc *NodeRPC) GetContractBalance(ctx context.Context, address string, level int64) (int64, error) {
 	var balanceStr string
-	if err := rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/balance", getBlockString(level), address), &balanceStr); err != nil {
+	if err := rpc.get(ctx, "GetContractBalance", fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s/balance", getBlockString(level), address), &balanceStr); err != nil {
 		return 0, err
 	}
 	return strconv.ParseInt(balanceStr, 10, 64)
@@ -298,19 +327,19 @@ func (rpc *NodeRPC) GetContractBalance(ctx context.Context, address string, leve
 // GetContractData -
 func (rpc *NodeRPC) GetContractData(ctx context.Context, address string, level int64) (ContractData, error) {
 	var response ContractData
-	err := rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s", getBlockString(level), address), &response)
+	err := rpc.get(ctx, "GetContractData", fmt.Sprintf("chains/main/blocks/%s/context/contracts/%s", getBlockString(level), address), &response)
 	return response, err
 }
 
 // GetOPG -
 func (rpc *NodeRPC) GetOPG(ctx context.Context, block int64) (group []OperationGroup, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
+	err = rpc.get(ctx, "GetOPG", fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
 	return
 }
 
 // GetLightOPG -
 func (rpc *NodeRPC) GetLightOPG(ctx context.Context, block int64) (group []LightOperationGroup, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
+	err = rpc.get(ctx, "GetLightOPG", fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
 	return
 }
 
@@ -320,7 +349,7 @@ func (rpc *NodeRPC) GetContractsByBlock(ctx context.Context, block int64) ([]str
 		return nil, errors.Errorf("For less loading node RPC `block` value is only 1")
 	}
 	contracts := make([]string, 0)
-	if err := rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%d/context/contracts", block), &contracts); err != nil {
+	if err := rpc.get(ctx, "GetContractsByBlock", fmt.Sprintf("chains/main/blocks/%d/context/contracts", block), &contracts); err != nil {
 		return nil, err
 	}
 	return contracts, nil
@@ -328,7 +357,7 @@ func (rpc *NodeRPC) GetContractsByBlock(ctx context.Context, block int64) ([]str
 
 // GetNetworkConstants -
 func (rpc *NodeRPC) GetNetworkConstants(ctx context.Context, level int64) (constants Constants, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/context/constants", getBlockString(level)), &constants)
+	err = rpc.get(ctx, "GetNetworkConstants", fmt.Sprintf("chains/main/blocks/%s/context/constants", getBlockString(level)), &constants)
 	return
 }
 
@@ -358,7 +387,7 @@ func (rpc *NodeRPC) RunCode(ctx context.Context, script, storage, input []byte,
 		request.Entrypoint = entrypoint
 	}
 
-	err = rpc.post(ctx, "chains/main/blocks/head/helpers/scripts/run_code", request, true, &response)
+	err = rpc.post(ctx, "RunCode", "chains/main/blocks/head/helpers/scripts/run_code", request, true, &response)
 	return
 }
 
@@ -385,7 +414,7 @@ func (rpc *NodeRPC) RunOperation(ctx context.Context, chainID, branch, source, d
 		},
 	}
 
-	err = rpc.post(ctx, "chains/main/blocks/head/helpers/scripts/run_operation", request, true, &group)
+	err = rpc.post(ctx, "RunOperation", "chains/main/blocks/head/helpers/scripts/run_operation", request, true, &group)
 	return
 }
 
@@ -412,21 +441,21 @@ func (rpc *NodeRPC) RunOperationLight(ctx context.Context, chainID, branch, sour
 		},
 	}
 
-	err = rpc.post(ctx, "chains/main/blocks/head/helpers/scripts/run_operation", request, true, &group)
+	err = rpc.post(ctx, "RunOperationLight", "chains/main/blocks/head/helpers/scripts/run_operation", request, true, &group)
 	return
 }
 
 // RunScriptView -
 func (rpc *NodeRPC) RunScriptView(ctx context.Context, request RunScriptViewRequest) ([]byte, error) {
 	var response RunScriptViewResponse
-	err := rpc.post(ctx, "chains/main/blocks/head/helpers/scripts/run_script_view", request, true, &response)
+	err := rpc.post(ctx, "RunScriptView", "chains/main/blocks/head/helpers/scripts/run_script_view", request, true, &response)
 	return response.Data, err
 }
 
 // GetCounter -
 func (rpc *NodeRPC) GetCounter(ctx context.Context, address string) (int64, error) {
 	var counter string
-	if err := rpc.get(ctx, fmt.Sprintf("chains/main/blocks/head/context/contracts/%s/counter", address), &counter); err != nil {
+	if err := rpc.get(ctx, "GetCounter", fmt.Sprintf("chains/main/blocks/head/context/contracts/%s/counter", address), &counter); err != nil {
 		return 0, err
 	}
 	return strconv.ParseInt(counter, 10, 64)
@@ -434,12 +463,12 @@ func (rpc *NodeRPC) GetCounter(ctx context.Context, address string) (int64, erro
 
 // GetBigMapType -
 func (rpc *NodeRPC) GetBigMapType(ctx context.Context, ptr, level int64) (bm BigMap, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/context/raw/json/big_maps/index/%d", getBlockString(level), ptr), &bm)
+	err = rpc.get(ctx, "GetBigMapType", fmt.Sprintf("chains/main/blocks/%s/context/raw/json/big_maps/index/%d", getBlockString(level), ptr), &bm)
 	return
 }
 
 // GetBlockMetadata -
 func (rpc *NodeRPC) GetBlockMetadata(ctx context.Context, level int64) (metadata Metadata, err error) {
-	err = rpc.get(ctx, fmt.Sprintf("chains/main/blocks/%s/metadata", getBlockString(level)), &metadata)
+	err = rpc.get(ctx, "GetBlockMetadata", fmt.Sprintf("chains/main/blocks/%s/metadata", getBlockString(level)), &metadata)
 	return
 }
@@ -0,0 +1,290 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	metadataAnnot = "metadata"
+	tezosStorage  = "tezos-storage:"
+)
+
+// ContractParser - resolves TZIP-16 metadata of contract when its `%metadata` big map is changed
+type ContractParser struct {
+	bigMapDiffs bigmapdiff.Repository
+	storage     models.GeneralRepository
+	remote      bool
+}
+
+// NewContractParser - creates contract metadata parser. On-chain (`tezos-storage:`) metadata is resolved at once. If `remote` is true metadata referenced by off-chain URI is saved as pending and its document is received by Resolver later, otherwise it's skipped.
+func NewContractParser(bigMapDiffs bigmapdiff.Repository, storage models.GeneralRepository, remote bool) ContractParser {
+	return ContractParser{
+		bigMapDiffs: bigMapDiffs,
+		storage:     storage,
+		remote:      remote,
+	}
+}
+
+// Parse -
+func (p ContractParser) Parse(ctx context.Context, op *operation.Operation, store parsers.Store) error {
+	if op == nil || len(op.BigMapDiffs) == 0 {
+		return nil
+	}
+
+	ptr, ok, err := p.metadataPtr(op)
+	if err != nil || !ok {
+		return err
+	}
+
+	rawURI, err := p.value(ctx, op, store, ptr, "")
+	if err != nil {
+		return err
+	}
+	if len(rawURI) == 0 {
+		return nil
+	}
+	uri := string(rawURI)
+
+	if !strings.HasPrefix(uri, tezosStorage) {
+		if p.remote && IsRemote(uri) {
+			store.AddContractMetadata(&metadata.ContractMetadata{
+				Contract:  op.Destination.Address,
+				Level:     op.Level,
+				Timestamp: op.Timestamp,
+				URI:       uri,
+				Pending:   true,
+			})
+		}
+		return nil
+	}
+
+	data, err := p.resolve(ctx, op, store, ptr, uri)
+	if err != nil {
+		log.Warn().Err(err).Str("contract", op.Destination.Address).Str("uri", uri).Msg("can't resolve contract metadata")
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	tzip, err := ParseTZIP16(data)
+	if err != nil {
+		log.Warn().Err(err).Str("contract", op.Destination.Address).Str("uri", uri).Msg("invalid contract metadata")
+		return nil
+	}
+
+	model, err := tzip.ToModel(op.Destination.Address, uri, op.Level, op.Timestamp, data)
+	if err != nil {
+		return err
+	}
+	store.AddContractMetadata(model)
+	return nil
+}
+
+// metadataPtr - returns pointer of `%metadata` big map if it was changed by the operation
+func (p ContractParser) metadataPtr(op *operation.Operation) (int64, bool, error) {
+	bigMap, err := findBigMap(op, metadataAnnot)
+	if err != nil {
+		return 0, false, err
+	}
+	if bigMap != nil && bigMap.Ptr != nil {
+		return *bigMap.Ptr, hasDiffs(op, *bigMap.Ptr), nil
+	}
+
+	// storage of origination doesn't contain pointers, so the metadata big map is detected by its empty key
+	emptyKeyHash, err := keyHash("")
+	if err != nil {
+		return 0, false, err
+	}
+	for i := range op.BigMapDiffs {
+		if op.BigMapDiffs[i].KeyHash != emptyKeyHash {
+			continue
+		}
+		value, err := bytesValue(op.BigMapDiffs[i].ValueBytes())
+		if err != nil {
+			continue
+		}
+		if isMetadataURI(string(value)) {
+			return op.BigMapDiffs[i].Ptr, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// resolve - returns document referenced by `tezos-storage:` URI
+func (p ContractParser) resolve(ctx context.Context, op *operation.Operation, store parsers.Store, ptr int64, uri string) ([]byte, error) {
+	location := strings.TrimPrefix(uri, tezosStorage)
+	if !strings.HasPrefix(location, "//") {
+		key, err := url.PathUnescape(location)
+		if err != nil {
+			return nil, errors.Wrap(ErrUnsupportedURI, uri)
+		}
+		return p.value(ctx, op, store, ptr, key)
+	}
+
+	// tezos-storage://<address>[.<network>]/<key>
+	host, path, ok := strings.Cut(strings.TrimPrefix(location, "//"), "/")
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedURI, uri)
+	}
+	address, _, _ := strings.Cut(host, ".")
+	key, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupportedURI, uri)
+	}
+
+	if address == op.Destination.Address {
+		return p.value(ctx, op, store, ptr, key)
+	}
+
+	states, err := p.bigMapDiffs.GetForAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	remotePtr, ok, err := storedMetadataPtr(states)
+	if err != nil || !ok {
+		return nil, err
+	}
+	hash, err := keyHash(key)
+	if err != nil {
+		return nil, err
+	}
+	for i := range states {
+		if states[i].Ptr == remotePtr && states[i].KeyHash == hash && !states[i].Removed {
+			return bytesValue(states[i].Value)
+		}
+	}
+	return nil, nil
+}
+
+// storedMetadataPtr - returns pointer of `%metadata` big map among big map states of other contract. It's the big map which empty key references metadata document.
+func storedMetadataPtr(states []bigmapdiff.BigMapState) (int64, bool, error) {
+	emptyKeyHash, err := keyHash("")
+	if err != nil {
+		return 0, false, err
+	}
+	for i := range states {
+		if states[i].KeyHash != emptyKeyHash || states[i].Removed {
+			continue
+		}
+		value, err := bytesValue(states[i].Value)
+		if err != nil {
+			continue
+		}
+		if isMetadataURI(string(value)) {
+			return states[i].Ptr, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func isMetadataURI(value string) bool {
+	return strings.HasPrefix(value, tezosStorage) || strings.Contains(value, "://")
+}
+
+// value - returns decoded bytes value of the string key in big map. It is looked up in the operation at first, in the operations of current block then and in the database at last.
+func (p ContractParser) value(ctx context.Context, op *operation.Operation, store parsers.Store, ptr int64, key string) ([]byte, error) {
+	hash, err := keyHash(key)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(op.BigMapDiffs) - 1; i >= 0; i-- {
+		if op.BigMapDiffs[i].Ptr == ptr && op.BigMapDiffs[i].KeyHash == hash {
+			return bytesValue(op.BigMapDiffs[i].ValueBytes())
+		}
+	}
+
+	operations := store.ListOperations()
+	for i := len(operations) - 1; i >= 0; i-- {
+		for j := len(operations[i].BigMapDiffs) - 1; j >= 0; j-- {
+			diff := operations[i].BigMapDiffs[j]
+			if diff.Ptr == ptr && diff.KeyHash == hash {
+				return bytesValue(diff.ValueBytes())
+			}
+		}
+	}
+
+	state, err := p.bigMapDiffs.Current(ctx, hash, ptr)
+	if err != nil {
+		if p.storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if state.Removed {
+		return nil, nil
+	}
+	return bytesValue(state.Value)
+}
+
+// findBigMap - returns big map with the annotation from the storage of the operation. Pointer of the big map is set if storage contains it.
+func findBigMap(op *operation.Operation, name string) (*ast.BigMap, error) {
+	if op.AST == nil || len(op.DeffatedStorage) == 0 {
+		return nil, nil
+	}
+	storage, err := op.AST.StorageType()
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SettleFromBytes(op.DeffatedStorage); err != nil {
+		return nil, err
+	}
+	node := storage.FindByName(name, false)
+	if node == nil {
+		return nil, nil
+	}
+	bigMap, ok := node.(*ast.BigMap)
+	if !ok {
+		return nil, nil
+	}
+	return bigMap, nil
+}
+
+func hasDiffs(op *operation.Operation, ptr int64) bool {
+	for i := range op.BigMapDiffs {
+		if op.BigMapDiffs[i].Ptr == ptr {
+			return true
+		}
+	}
+	return false
+}
+
+func keyHash(key string) (string, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return ast.BigMapKeyHashFromString(fmt.Sprintf(`{"string":%s}`, data))
+}
+
+func bytesValue(value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	var node base.Node
+	if err := json.Unmarshal(value, &node); err != nil {
+		return nil, err
+	}
+	if node.BytesValue == nil {
+		return nil, errors.Errorf("value is not bytes: %s", value)
+	}
+	return hex.DecodeString(*node.BytesValue)
+}
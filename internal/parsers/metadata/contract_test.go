@@ -0,0 +1,227 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/stretchr/testify/require"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_bmd "github.com/baking-bad/bcdhub/internal/models/mock/bigmapdiff"
+	"go.uber.org/mock/gomock"
+)
+
+const testContract = "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"
+
+type stubFetcher map[string][]byte
+
+func (f stubFetcher) Fetch(_ context.Context, uri string) ([]byte, error) {
+	data, ok := f[uri]
+	if !ok {
+		return nil, fmt.Errorf("unknown uri: %s", uri)
+	}
+	return data, nil
+}
+
+func newDiff(t *testing.T, ptr int64, key string, value []byte) *bigmapdiff.BigMapDiff {
+	hash, err := keyHash(key)
+	require.NoError(t, err)
+	return &bigmapdiff.BigMapDiff{
+		Ptr:      ptr,
+		Key:      []byte(fmt.Sprintf(`{"string":"%s"}`, key)),
+		KeyHash:  hash,
+		Value:    []byte(fmt.Sprintf(`{"bytes":"%s"}`, hex.EncodeToString(value))),
+		Contract: testContract,
+	}
+}
+
+func TestContractParser_Parse(t *testing.T) {
+	level := int64(100)
+	ts := time.Unix(1700000000, 0).UTC()
+	document := []byte(`{"name":"Test","description":"test contract","version":"1.0","interfaces":["TZIP-012","TZIP-016"],"views":[{"name":"get_balance","implementations":[{"michelsonStorageView":{"code":[]}}]}]}`)
+
+	tests := []struct {
+		name   string
+		diffs  []*bigmapdiff.BigMapDiff
+		remote bool
+		want   []*wantMetadata
+	}{
+		{
+			name: "tezos-storage",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "", []byte("tezos-storage:content")),
+				newDiff(t, 10, "content", document),
+			},
+			want: []*wantMetadata{{name: "Test", uri: "tezos-storage:content"}},
+		}, {
+			name: "escaped tezos-storage key",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "", []byte("tezos-storage:here%20it%20is")),
+				newDiff(t, 10, "here it is", document),
+			},
+			want: []*wantMetadata{{name: "Test", uri: "tezos-storage:here%20it%20is"}},
+		}, {
+			name: "https without remote metadata",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "", []byte("https://example.com/metadata.json")),
+			},
+			want: []*wantMetadata{},
+		}, {
+			name: "https is pending",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "", []byte("https://example.com/metadata.json")),
+			},
+			remote: true,
+			want:   []*wantMetadata{{uri: "https://example.com/metadata.json", pending: true}},
+		}, {
+			name: "http is skipped",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "", []byte("http://example.com/metadata.json")),
+			},
+			remote: true,
+			want:   []*wantMetadata{},
+		}, {
+			name: "invalid metadata is skipped",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "", []byte("tezos-storage:content")),
+				newDiff(t, 10, "content", []byte(`{"name":"Test","views":[{"name":"v"}]}`)),
+			},
+			want: []*wantMetadata{},
+		}, {
+			name: "not a metadata big map",
+			diffs: []*bigmapdiff.BigMapDiff{
+				newDiff(t, 10, "key", []byte("value")),
+			},
+			want: []*wantMetadata{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bmdRepo := mock_bmd.NewMockRepository(ctrl)
+			generalRepo := mock_general.NewMockGeneralRepository(ctrl)
+
+			op := &operation.Operation{
+				Level:       level,
+				Timestamp:   ts,
+				Destination: account.Account{Address: testContract},
+				BigMapDiffs: tt.diffs,
+			}
+
+			store := parsers.NewTestStore()
+			err := NewContractParser(bmdRepo, generalRepo, tt.remote).Parse(context.Background(), op, store)
+			require.NoError(t, err)
+			require.Len(t, store.ContractMeta, len(tt.want))
+
+			for i := range tt.want {
+				got := store.ContractMeta[i]
+				require.Equal(t, testContract, got.Contract)
+				require.Equal(t, level, got.Level)
+				require.Equal(t, ts, got.Timestamp)
+				require.Equal(t, tt.want[i].name, got.Name)
+				require.Equal(t, tt.want[i].uri, got.URI)
+				require.Equal(t, tt.want[i].pending, got.Pending)
+				if tt.want[i].pending {
+					require.Empty(t, got.Raw)
+					continue
+				}
+				require.Equal(t, []string{"TZIP-012", "TZIP-016"}, got.Interfaces)
+				require.JSONEq(t, string(document), string(got.Raw))
+			}
+		})
+	}
+}
+
+func TestContractParser_ParseFromDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bmdRepo := mock_bmd.NewMockRepository(ctrl)
+	generalRepo := mock_general.NewMockGeneralRepository(ctrl)
+
+	document := []byte(`{"name":"Stored"}`)
+	stored := newDiff(t, 10, "content", document)
+
+	bmdRepo.EXPECT().
+		Current(gomock.Any(), stored.KeyHash, int64(10)).
+		Return(bigmapdiff.BigMapState{
+			Ptr:     10,
+			KeyHash: stored.KeyHash,
+			Value:   stored.Value,
+		}, nil).
+		Times(1)
+
+	op := &operation.Operation{
+		Level:       101,
+		Destination: account.Account{Address: testContract},
+		BigMapDiffs: []*bigmapdiff.BigMapDiff{
+			newDiff(t, 10, "", []byte("tezos-storage:content")),
+		},
+	}
+
+	store := parsers.NewTestStore()
+	err := NewContractParser(bmdRepo, generalRepo, false).Parse(context.Background(), op, store)
+	require.NoError(t, err)
+	require.Len(t, store.ContractMeta, 1)
+	require.Equal(t, "Stored", store.ContractMeta[0].Name)
+}
+
+func TestContractParser_ParseOtherContract(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bmdRepo := mock_bmd.NewMockRepository(ctrl)
+	generalRepo := mock_general.NewMockGeneralRepository(ctrl)
+
+	const other = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	document := []byte(`{"name":"Other"}`)
+
+	state := func(diff *bigmapdiff.BigMapDiff) bigmapdiff.BigMapState {
+		return bigmapdiff.BigMapState{
+			Ptr:      diff.Ptr,
+			KeyHash:  diff.KeyHash,
+			Key:      diff.Key,
+			Value:    diff.Value,
+			Contract: other,
+		}
+	}
+
+	bmdRepo.EXPECT().
+		GetForAddress(gomock.Any(), other).
+		Return([]bigmapdiff.BigMapState{
+			// the same key in a big map which isn't `%metadata`
+			state(newDiff(t, 20, "content", []byte(`{"name":"Wrong"}`))),
+			state(newDiff(t, 21, "", []byte("tezos-storage:content"))),
+			state(newDiff(t, 21, "content", document)),
+		}, nil).
+		Times(1)
+
+	op := &operation.Operation{
+		Level:       101,
+		Destination: account.Account{Address: testContract},
+		BigMapDiffs: []*bigmapdiff.BigMapDiff{
+			newDiff(t, 10, "", []byte("tezos-storage://"+other+"/content")),
+		},
+	}
+
+	store := parsers.NewTestStore()
+	err := NewContractParser(bmdRepo, generalRepo, false).Parse(context.Background(), op, store)
+	require.NoError(t, err)
+	require.Len(t, store.ContractMeta, 1)
+	require.Equal(t, "Other", store.ContractMeta[0].Name)
+}
+
+type wantMetadata struct {
+	name    string
+	uri     string
+	pending bool
+}
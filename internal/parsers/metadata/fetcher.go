@@ -0,0 +1,130 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/webhook"
+	"github.com/pkg/errors"
+)
+
+const (
+	// max size of off-chain metadata document
+	maxMetadataSize = 1 << 20
+	// max count of redirects while fetching the document
+	maxMetadataRedirects = 3
+)
+
+// Errors
+var (
+	ErrUnsupportedURI = errors.New("unsupported metadata URI")
+	ErrInvalidHash    = errors.New("metadata hash mismatch")
+)
+
+// Fetcher - receives off-chain metadata by URI. Implementations are pluggable: HTTP one is used in production and any stub can be used in tests or sandboxes.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string) ([]byte, error)
+}
+
+// IsRemote - returns true if the URI references off-chain document which can be received by HTTPFetcher
+func IsRemote(uri string) bool {
+	return strings.HasPrefix(uri, "https://") || strings.HasPrefix(uri, "sha256://")
+}
+
+// HTTPFetcher - fetches `https://` and `sha256://` metadata URIs. Plain `http://` is not supported.
+// URIs are controlled by contracts, so connections to loopback, private and link-local addresses are refused as for webhooks.
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher -
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = webhook.NewDialer(timeout).DialContext
+	t.MaxIdleConns = 10
+	t.MaxConnsPerHost = 10
+	t.MaxIdleConnsPerHost = 10
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Transport:     t,
+			Timeout:       timeout,
+			CheckRedirect: checkRedirect,
+		},
+	}
+}
+
+// checkRedirect - limits count of redirects and validates every redirect target
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxMetadataRedirects {
+		return errors.Errorf("stopped after %d redirects", maxMetadataRedirects)
+	}
+	return webhook.ValidateURL(req.Context(), req.URL.String())
+}
+
+// Fetch -
+func (f *HTTPFetcher) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(uri, "https://"):
+		return f.get(ctx, uri)
+	case strings.HasPrefix(uri, "sha256://"):
+		return fetchWithHash(ctx, f, uri)
+	default:
+		return nil, errors.Wrap(ErrUnsupportedURI, uri)
+	}
+}
+
+func (f *HTTPFetcher) get(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("invalid status code: %d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, maxMetadataSize))
+}
+
+// fetchWithHash - resolves `sha256://0x<hash>/<percent-encoded URI>` and checks the hash of received document
+func fetchWithHash(ctx context.Context, fetcher Fetcher, uri string) ([]byte, error) {
+	hashWithURI := strings.TrimPrefix(uri, "sha256://")
+	hashString, encodedURI, ok := strings.Cut(hashWithURI, "/")
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedURI, uri)
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(hashString, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupportedURI, uri)
+	}
+	innerURI, err := url.PathUnescape(encodedURI)
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupportedURI, uri)
+	}
+
+	data, err := fetcher.Fetch(ctx, innerURI)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(data)
+	if !bytes.Equal(hash[:], expected) {
+		return nil, errors.Wrap(ErrInvalidHash, uri)
+	}
+	return data, nil
+}
@@ -0,0 +1,121 @@
+package metadata
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	document := []byte(`{"name":"Test"}`)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(document)
+	}))
+	defer server.Close()
+
+	hash := sha256.Sum256(document)
+	uri := server.URL + "/metadata.json"
+
+	tests := []struct {
+		name    string
+		uri     string
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "https",
+			uri:  uri,
+			want: document,
+		}, {
+			name:    "http",
+			uri:     "http" + strings.TrimPrefix(uri, "https"),
+			wantErr: ErrUnsupportedURI,
+		}, {
+			name:    "sha256 over http",
+			uri:     "sha256://0x" + hex.EncodeToString(hash[:]) + "/" + url.PathEscape("http"+strings.TrimPrefix(uri, "https")),
+			wantErr: ErrUnsupportedURI,
+		}, {
+			name: "sha256",
+			uri:  "sha256://0x" + hex.EncodeToString(hash[:]) + "/" + url.PathEscape(uri),
+			want: document,
+		}, {
+			name:    "sha256 mismatch",
+			uri:     "sha256://0x" + hex.EncodeToString(make([]byte, 32)) + "/" + url.PathEscape(uri),
+			wantErr: ErrInvalidHash,
+		}, {
+			name:    "ipfs",
+			uri:     "ipfs://QmWDcp3BpBjvu8uJYxVqb7JLfr1pcyXsL97Cfkt3y1758o",
+			wantErr: ErrUnsupportedURI,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := NewHTTPFetcher(time.Second)
+			fetcher.client = server.Client()
+
+			got, err := fetcher.Fetch(context.Background(), tt.uri)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPFetcher_ForbiddenTarget(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// test server listens on loopback address
+	_, err := NewHTTPFetcher(time.Second).Fetch(context.Background(), server.URL+"/metadata.json")
+	require.ErrorIs(t, err, webhook.ErrForbiddenTarget)
+}
+
+func Test_checkRedirect(t *testing.T) {
+	newRequest := func(uri string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, uri, nil)
+		require.NoError(t, err)
+		return req
+	}
+	previous := newRequest("https://1.1.1.1/metadata.json")
+
+	tests := []struct {
+		name    string
+		uri     string
+		via     int
+		wantErr bool
+	}{
+		{name: "public https", uri: "https://1.1.1.1/moved.json", via: 1},
+		{name: "http", uri: "http://1.1.1.1/moved.json", via: 1, wantErr: true},
+		{name: "loopback", uri: "https://127.0.0.1/moved.json", via: 1, wantErr: true},
+		{name: "metadata service", uri: "https://169.254.169.254/latest/meta-data", via: 1, wantErr: true},
+		{name: "too many redirects", uri: "https://1.1.1.1/moved.json", via: maxMetadataRedirects, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			via := make([]*http.Request, tt.via)
+			for i := range via {
+				via[i] = previous
+			}
+			err := checkRedirect(newRequest(tt.uri), via)
+			require.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/dipdup-io/workerpool"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultResolverInterval = 30 * time.Second
+	resolverMaxAttempts     = 3
+	resolverBatchSize       = 50
+)
+
+// Resolver - receives off-chain documents of pending contract and token metadata. It works in background, so slow or unavailable metadata hosts don't block indexing. Record stops being pending when the document is received or after maximum count of attempts.
+type Resolver struct {
+	repo     metadata.Repository
+	fetcher  Fetcher
+	network  string
+	interval time.Duration
+
+	g workerpool.Group
+}
+
+// NewResolver -
+func NewResolver(network string, repo metadata.Repository, fetcher Fetcher) *Resolver {
+	return &Resolver{
+		repo:     repo,
+		fetcher:  fetcher,
+		network:  network,
+		interval: defaultResolverInterval,
+		g:        workerpool.NewGroup(),
+	}
+}
+
+// Start -
+func (r *Resolver) Start(ctx context.Context) {
+	r.g.GoCtx(ctx, r.run)
+}
+
+// Close -
+func (r *Resolver) Close() error {
+	r.g.Wait()
+	return nil
+}
+
+func (r *Resolver) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.process(ctx); err != nil {
+				log.Err(err).Str("network", r.network).Msg("metadata resolving")
+			}
+		}
+	}
+}
+
+// process - makes one attempt to resolve every pending record of the batch
+func (r *Resolver) process(ctx context.Context) error {
+	contracts, err := r.repo.PendingContractMetadata(ctx, resolverBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range contracts {
+		if err := r.resolveContract(ctx, &contracts[i]); err != nil {
+			return err
+		}
+	}
+
+	tokens, err := r.repo.PendingTokenMetadata(ctx, resolverBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range tokens {
+		if err := r.resolveToken(ctx, &tokens[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveContract - returned error is an error of the storage, unavailable or invalid document is not an error.
+func (r *Resolver) resolveContract(ctx context.Context, m *metadata.ContractMetadata) error {
+	data, err := r.fetcher.Fetch(ctx, m.URI)
+	if err == nil {
+		var tzip TZIP16
+		if tzip, err = ParseTZIP16(data); err == nil {
+			var resolved *metadata.ContractMetadata
+			if resolved, err = tzip.ToModel(m.Contract, m.URI, m.Level, m.Timestamp, data); err == nil {
+				resolved.ID = m.ID
+				resolved.Attempts = m.Attempts + 1
+				return r.repo.UpdateContractMetadata(ctx, resolved)
+			}
+		}
+	}
+
+	log.Warn().Err(err).Str("contract", m.Contract).Str("uri", m.URI).Msg("can't resolve contract metadata")
+	r.failed(&m.Attempts, &m.Pending)
+	return r.repo.UpdateContractMetadata(ctx, m)
+}
+
+// resolveToken - returned error is an error of the storage, unavailable or invalid document is not an error.
+func (r *Resolver) resolveToken(ctx context.Context, m *metadata.TokenMetadata) error {
+	data, err := r.fetcher.Fetch(ctx, m.URI)
+	if err == nil {
+		if err = mergeOffChain(m, data); err == nil {
+			m.Attempts += 1
+			m.Pending = false
+			return r.repo.UpdateTokenMetadata(ctx, m)
+		}
+	}
+
+	log.Warn().Err(err).Str("contract", m.Contract).Str("uri", m.URI).Msg("can't resolve token metadata")
+	r.failed(&m.Attempts, &m.Pending)
+	return r.repo.UpdateTokenMetadata(ctx, m)
+}
+
+func (r *Resolver) failed(attempts *int, pending *bool) {
+	*attempts += 1
+	*pending = *attempts < resolverMaxAttempts
+}
@@ -0,0 +1,77 @@
+package metadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	mock_metadata "github.com/baking-bad/bcdhub/internal/models/mock/metadata"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResolver_process(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_metadata.NewMockRepository(ctrl)
+
+	fetcher := stubFetcher{
+		"https://example.com/metadata.json": []byte(`{"name":"Test","interfaces":["TZIP-016"]}`),
+		"https://example.com/token/5.json":  []byte(`{"symbol":"OFF","name":"Off-chain","decimals":"0"}`),
+	}
+
+	repo.EXPECT().
+		PendingContractMetadata(gomock.Any(), resolverBatchSize).
+		Return([]metadata.ContractMetadata{
+			{ID: 1, Contract: testContract, URI: "https://example.com/metadata.json", Pending: true},
+			{ID: 2, Contract: testContract, URI: "https://example.com/unknown.json", Pending: true, Attempts: resolverMaxAttempts - 1},
+		}, nil).
+		Times(1)
+
+	var contracts []*metadata.ContractMetadata
+	repo.EXPECT().
+		UpdateContractMetadata(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *metadata.ContractMetadata) error {
+			contracts = append(contracts, m)
+			return nil
+		}).
+		Times(2)
+
+	repo.EXPECT().
+		PendingTokenMetadata(gomock.Any(), resolverBatchSize).
+		Return([]metadata.TokenMetadata{
+			{ID: 3, Contract: testContract, URI: "https://example.com/token/5.json", Symbol: "ON", Pending: true},
+		}, nil).
+		Times(1)
+
+	var token *metadata.TokenMetadata
+	repo.EXPECT().
+		UpdateTokenMetadata(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *metadata.TokenMetadata) error {
+			token = m
+			return nil
+		}).
+		Times(1)
+
+	err := NewResolver("mainnet", repo, fetcher).process(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, contracts, 2)
+	require.EqualValues(t, 1, contracts[0].ID)
+	require.False(t, contracts[0].Pending)
+	require.Equal(t, "Test", contracts[0].Name)
+	require.NotEmpty(t, contracts[0].Raw)
+
+	require.EqualValues(t, 2, contracts[1].ID)
+	require.False(t, contracts[1].Pending)
+	require.Equal(t, resolverMaxAttempts, contracts[1].Attempts)
+	require.Empty(t, contracts[1].Raw)
+
+	require.NotNil(t, token)
+	require.False(t, token.Pending)
+	require.Equal(t, "ON", token.Symbol)
+	require.Equal(t, "Off-chain", token.Name)
+	require.EqualValues(t, 0, *token.Decimals)
+	require.Empty(t, token.Extras)
+}
@@ -0,0 +1,275 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	stdJSON "encoding/json"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/contract/trees"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	tokenMetadataAnnot = "token_metadata"
+
+	fieldSymbol   = "symbol"
+	fieldName     = "name"
+	fieldDecimals = "decimals"
+)
+
+// TokenParser - decodes TZIP-21 token metadata from changes of `%token_metadata` big map
+type TokenParser struct {
+	remote bool
+}
+
+// NewTokenParser - creates token metadata parser. If `remote` is true token which references off-chain metadata by empty key is saved as pending and the document is merged by Resolver later.
+func NewTokenParser(remote bool) TokenParser {
+	return TokenParser{
+		remote: remote,
+	}
+}
+
+// Parse -
+func (p TokenParser) Parse(ctx context.Context, op *operation.Operation, store parsers.Store) error {
+	if op == nil || len(op.BigMapDiffs) == 0 {
+		return nil
+	}
+
+	bigMap, err := findBigMap(op, tokenMetadataAnnot)
+	if err != nil {
+		return err
+	}
+	if bigMap == nil || !bigMap.EqualType(trees.TokenMetadata.Nodes[0]) {
+		return nil
+	}
+
+	var ptr int64
+	switch {
+	case bigMap.Ptr != nil:
+		ptr = *bigMap.Ptr
+	case op.IsOrigination():
+		// storage of origination doesn't contain pointers, so the big map allocated by origination is detected by its values
+		allocated, ok := allocatedTokenMetadataPtr(op)
+		if !ok {
+			return nil
+		}
+		ptr = allocated
+	default:
+		return nil
+	}
+
+	for _, diff := range op.BigMapDiffs {
+		if diff.Ptr != ptr {
+			continue
+		}
+
+		model, err := p.parseDiff(op, diff)
+		if err != nil {
+			log.Warn().Err(err).Str("contract", op.Destination.Address).Str("key", string(diff.KeyBytes())).Msg("invalid token metadata")
+			continue
+		}
+		store.AddTokenMetadata(model)
+	}
+	return nil
+}
+
+func (p TokenParser) parseDiff(op *operation.Operation, diff *bigmapdiff.BigMapDiff) (*metadata.TokenMetadata, error) {
+	var key base.Node
+	if err := json.Unmarshal(diff.KeyBytes(), &key); err != nil {
+		return nil, err
+	}
+	if key.IntValue == nil {
+		return nil, errors.Errorf("invalid token id: %s", diff.KeyBytes())
+	}
+
+	model := &metadata.TokenMetadata{
+		Contract:  op.Destination.Address,
+		TokenId:   decimal.NewFromBigInt(key.IntValue.Int, 0),
+		Level:     op.Level,
+		Timestamp: op.Timestamp,
+	}
+
+	if len(diff.Value) == 0 {
+		model.Removed = true
+		return model, nil
+	}
+
+	fields, err := tokenInfo(diff.ValueBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if uri, ok := fields[""]; ok {
+		delete(fields, "")
+		var s string
+		if err := json.Unmarshal(uri, &s); err == nil {
+			model.URI = s
+			model.Pending = p.remote && IsRemote(s)
+		}
+	}
+
+	if err := setTokenFields(model, fields); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// allocatedTokenMetadataPtr - returns pointer of the only big map of the operation which all values are `token_info` of their keys
+func allocatedTokenMetadataPtr(op *operation.Operation) (int64, bool) {
+	candidates := make(map[int64]bool)
+	for _, diff := range op.BigMapDiffs {
+		valid, ok := candidates[diff.Ptr]
+		if ok && !valid {
+			continue
+		}
+		candidates[diff.Ptr] = isTokenInfoDiff(diff)
+	}
+
+	var (
+		ptr   int64
+		found bool
+	)
+	for candidate, valid := range candidates {
+		if !valid {
+			continue
+		}
+		if found {
+			return 0, false
+		}
+		ptr, found = candidate, true
+	}
+	return ptr, found
+}
+
+func isTokenInfoDiff(diff *bigmapdiff.BigMapDiff) bool {
+	var key base.Node
+	if err := json.Unmarshal(diff.KeyBytes(), &key); err != nil || key.IntValue == nil {
+		return false
+	}
+	if len(diff.Value) == 0 {
+		return false
+	}
+	var value base.Node
+	if err := json.Unmarshal(diff.ValueBytes(), &value); err != nil {
+		return false
+	}
+	if value.Prim != consts.Pair || len(value.Args) != 2 || value.Args[0].IntValue == nil || value.Args[0].IntValue.Cmp(key.IntValue.Int) != 0 {
+		return false
+	}
+	_, err := tokenInfo(diff.ValueBytes())
+	return err == nil
+}
+
+// setTokenFields - sets known fields of token metadata and saves the rest as extras
+func setTokenFields(model *metadata.TokenMetadata, fields map[string]stdJSON.RawMessage) error {
+	if value, ok := fields[fieldSymbol]; ok {
+		model.Symbol = stringField(value)
+		delete(fields, fieldSymbol)
+	}
+	if value, ok := fields[fieldName]; ok {
+		model.Name = stringField(value)
+		delete(fields, fieldName)
+	}
+	if value, ok := fields[fieldDecimals]; ok {
+		if decimals, err := strconv.ParseInt(stringField(value), 10, 64); err == nil {
+			model.Decimals = &decimals
+		}
+		delete(fields, fieldDecimals)
+	}
+
+	if len(fields) > 0 {
+		extras, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		model.Extras = extras
+	}
+	return nil
+}
+
+// mergeOffChain - adds fields of off-chain document which are not set on-chain to the token metadata
+func mergeOffChain(model *metadata.TokenMetadata, data []byte) error {
+	fields := make(map[string]stdJSON.RawMessage)
+	if len(model.Extras) > 0 {
+		if err := json.Unmarshal(model.Extras, &fields); err != nil {
+			return err
+		}
+	}
+	if model.Symbol != "" {
+		fields[fieldSymbol], _ = json.Marshal(model.Symbol)
+	}
+	if model.Name != "" {
+		fields[fieldName], _ = json.Marshal(model.Name)
+	}
+	if model.Decimals != nil {
+		fields[fieldDecimals], _ = json.Marshal(strconv.FormatInt(*model.Decimals, 10))
+	}
+
+	var offChain map[string]stdJSON.RawMessage
+	if err := json.Unmarshal(data, &offChain); err != nil {
+		return err
+	}
+	for key, value := range offChain {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+
+	model.Extras = nil
+	return setTokenFields(model, fields)
+}
+
+// tokenInfo - decodes `token_info` map of the `pair nat (map string bytes)` value. Bytes are stored as JSON if they are valid JSON and as UTF-8 string otherwise.
+func tokenInfo(value []byte) (map[string]stdJSON.RawMessage, error) {
+	var node base.Node
+	if err := json.Unmarshal(value, &node); err != nil {
+		return nil, err
+	}
+	if node.Prim != consts.Pair || len(node.Args) != 2 {
+		return nil, errors.Errorf("invalid token metadata value: %s", value)
+	}
+
+	info := node.Args[1]
+	fields := make(map[string]stdJSON.RawMessage, len(info.Args))
+	for _, elt := range info.Args {
+		if elt.Prim != consts.Elt || len(elt.Args) != 2 || elt.Args[0].StringValue == nil || elt.Args[1].BytesValue == nil {
+			return nil, errors.Errorf("invalid token info item: %s", value)
+		}
+		decoded, err := hex.DecodeString(*elt.Args[1].BytesValue)
+		if err != nil {
+			return nil, err
+		}
+		fields[*elt.Args[0].StringValue] = decodeBytes(decoded)
+	}
+	return fields, nil
+}
+
+func decodeBytes(data []byte) stdJSON.RawMessage {
+	if !utf8.Valid(data) {
+		result, _ := json.Marshal(hex.EncodeToString(data))
+		return result
+	}
+	if len(data) > 0 && (data[0] == '{' || data[0] == '[') && json.Valid(data) {
+		return data
+	}
+	result, _ := json.Marshal(string(data))
+	return result
+}
+
+func stringField(value stdJSON.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	return string(value)
+}
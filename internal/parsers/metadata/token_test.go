@@ -0,0 +1,177 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenScript = `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"big_map","args":[{"prim":"string"},{"prim":"bytes"}],"annots":["%metadata"]},{"prim":"big_map","args":[{"prim":"nat"},{"prim":"pair","args":[{"prim":"nat"},{"prim":"map","args":[{"prim":"string"},{"prim":"bytes"}]}]}],"annots":["%token_metadata"]}]}]},{"prim":"code","args":[[]]}]`
+
+func newTokenDiff(ptr int64, tokenID int, info map[string]string) *bigmapdiff.BigMapDiff {
+	diff := &bigmapdiff.BigMapDiff{
+		Ptr:      ptr,
+		Key:      []byte(fmt.Sprintf(`{"int":"%d"}`, tokenID)),
+		Contract: testContract,
+	}
+	if info == nil {
+		return diff
+	}
+
+	items := ""
+	for key, value := range info {
+		if items != "" {
+			items += ","
+		}
+		items += fmt.Sprintf(`{"prim":"Elt","args":[{"string":"%s"},{"bytes":"%s"}]}`, key, hex.EncodeToString([]byte(value)))
+	}
+	diff.Value = []byte(fmt.Sprintf(`{"prim":"Pair","args":[{"int":"%d"},[%s]]}`, tokenID, items))
+	return diff
+}
+
+func TestTokenParser_Parse(t *testing.T) {
+	script, err := ast.NewScriptWithoutCode([]byte(testTokenScript))
+	require.NoError(t, err)
+
+	op := &operation.Operation{
+		Level:           100,
+		Destination:     account.Account{Address: testContract},
+		AST:             script,
+		DeffatedStorage: []byte(`{"prim":"Pair","args":[{"int":"1"},{"int":"2"}]}`),
+		BigMapDiffs: []*bigmapdiff.BigMapDiff{
+			newDiff(t, 1, "", []byte("tezos-storage:content")),
+			newTokenDiff(2, 0, map[string]string{
+				"symbol":       "TST",
+				"name":         "Test token",
+				"decimals":     "6",
+				"thumbnailUri": "ipfs://QmThumb",
+				"attributes":   `[{"name":"rarity","value":"common"}]`,
+			}),
+			newTokenDiff(2, 1, nil),
+		},
+	}
+
+	store := parsers.NewTestStore()
+	err = NewTokenParser(false).Parse(context.Background(), op, store)
+	require.NoError(t, err)
+	require.Len(t, store.TokenMeta, 2)
+
+	token := store.TokenMeta[0]
+	require.Equal(t, testContract, token.Contract)
+	require.Equal(t, "0", token.TokenId.String())
+	require.EqualValues(t, 100, token.Level)
+	require.Equal(t, "TST", token.Symbol)
+	require.Equal(t, "Test token", token.Name)
+	require.NotNil(t, token.Decimals)
+	require.EqualValues(t, 6, *token.Decimals)
+	require.False(t, token.Removed)
+	require.JSONEq(t, `{"thumbnailUri":"ipfs://QmThumb","attributes":[{"name":"rarity","value":"common"}]}`, string(token.Extras))
+
+	removed := store.TokenMeta[1]
+	require.Equal(t, "1", removed.TokenId.String())
+	require.True(t, removed.Removed)
+}
+
+func TestTokenParser_ParseOrigination(t *testing.T) {
+	script, err := ast.NewScriptWithoutCode([]byte(testTokenScript))
+	require.NoError(t, err)
+
+	op := &operation.Operation{
+		Kind:            types.OperationKindOrigination,
+		Level:           100,
+		Destination:     account.Account{Address: testContract},
+		AST:             script,
+		DeffatedStorage: []byte(`{"prim":"Pair","args":[[],[]]}`),
+		BigMapDiffs: []*bigmapdiff.BigMapDiff{
+			newDiff(t, 7, "", []byte("tezos-storage:content")),
+			newTokenDiff(8, 0, map[string]string{
+				"symbol": "TST",
+			}),
+			newTokenDiff(8, 1, map[string]string{
+				"symbol": "TST1",
+			}),
+		},
+	}
+
+	store := parsers.NewTestStore()
+	err = NewTokenParser(false).Parse(context.Background(), op, store)
+	require.NoError(t, err)
+	require.Len(t, store.TokenMeta, 2)
+	require.Equal(t, "TST", store.TokenMeta[0].Symbol)
+	require.Equal(t, "1", store.TokenMeta[1].TokenId.String())
+	require.Equal(t, "TST1", store.TokenMeta[1].Symbol)
+}
+
+func TestTokenParser_ParseOffChain(t *testing.T) {
+	script, err := ast.NewScriptWithoutCode([]byte(testTokenScript))
+	require.NoError(t, err)
+
+	op := &operation.Operation{
+		Level:           100,
+		Destination:     account.Account{Address: testContract},
+		AST:             script,
+		DeffatedStorage: []byte(`{"prim":"Pair","args":[{"int":"1"},{"int":"2"}]}`),
+		BigMapDiffs: []*bigmapdiff.BigMapDiff{
+			newTokenDiff(2, 5, map[string]string{
+				"":       "https://example.com/token/5.json",
+				"symbol": "ON",
+			}),
+		},
+	}
+
+	store := parsers.NewTestStore()
+	err = NewTokenParser(true).Parse(context.Background(), op, store)
+	require.NoError(t, err)
+	require.Len(t, store.TokenMeta, 1)
+
+	token := store.TokenMeta[0]
+	require.Equal(t, "5", token.TokenId.String())
+	require.Equal(t, "https://example.com/token/5.json", token.URI)
+	require.True(t, token.Pending)
+	require.Equal(t, "ON", token.Symbol)
+	require.Empty(t, token.Name)
+}
+
+func Test_decodeBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			name: "string",
+			data: []byte("Test token"),
+			want: `"Test token"`,
+		}, {
+			name: "number is kept as string",
+			data: []byte("6"),
+			want: `"6"`,
+		}, {
+			name: "object",
+			data: []byte(`{"a":1}`),
+			want: `{"a":1}`,
+		}, {
+			name: "array",
+			data: []byte(`["TZIP-021"]`),
+			want: `["TZIP-021"]`,
+		}, {
+			name: "invalid UTF-8",
+			data: []byte{0xff, 0xfe},
+			want: `"fffe"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.JSONEq(t, tt.want, string(decodeBytes(tt.data)))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package metadata
+
+import (
+	"bytes"
+	stdJSON "encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidMetadata -
+var ErrInvalidMetadata = errors.New("invalid TZIP-16 metadata")
+
+var interfaceRegexp = regexp.MustCompile(`^TZIP-\d+`)
+
+// TZIP16 - contract metadata document described in https://tzip.tezosagora.org/proposal/tzip-16/
+type TZIP16 struct {
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Version     string             `json:"version,omitempty"`
+	License     *License           `json:"license,omitempty"`
+	Authors     []string           `json:"authors,omitempty"`
+	Homepage    string             `json:"homepage,omitempty"`
+	Source      *Source            `json:"source,omitempty"`
+	Interfaces  []string           `json:"interfaces,omitempty"`
+	Errors      stdJSON.RawMessage `json:"errors,omitempty"`
+	Views       []View             `json:"views,omitempty"`
+}
+
+// License -
+type License struct {
+	Name    string `json:"name"`
+	Details string `json:"details,omitempty"`
+}
+
+// Source -
+type Source struct {
+	Tools    []string `json:"tools,omitempty"`
+	Location string   `json:"location,omitempty"`
+}
+
+// View - off-chain view
+type View struct {
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	Pure            bool             `json:"pure,omitempty"`
+	Implementations []Implementation `json:"implementations"`
+}
+
+// Implementation - implementation of off-chain view
+type Implementation struct {
+	MichelsonStorageView stdJSON.RawMessage `json:"michelsonStorageView,omitempty"`
+	RestApiQuery         stdJSON.RawMessage `json:"restApiQuery,omitempty"`
+}
+
+// ParseTZIP16 - decodes metadata document and validates it against TZIP-16 schema
+func ParseTZIP16(data []byte) (TZIP16, error) {
+	var result TZIP16
+
+	if len(bytes.TrimSpace(data)) == 0 || bytes.TrimSpace(data)[0] != '{' {
+		return result, errors.Wrap(ErrInvalidMetadata, "metadata must be JSON object")
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, errors.Wrap(ErrInvalidMetadata, err.Error())
+	}
+
+	if err := result.Validate(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Validate - checks restrictions of TZIP-16 schema which can't be expressed by JSON types
+func (t TZIP16) Validate() error {
+	if t.License != nil && t.License.Name == "" {
+		return errors.Wrap(ErrInvalidMetadata, "license name is required")
+	}
+
+	for i := range t.Interfaces {
+		if !interfaceRegexp.MatchString(t.Interfaces[i]) {
+			return errors.Wrapf(ErrInvalidMetadata, "invalid interface: %s", t.Interfaces[i])
+		}
+	}
+
+	for i := range t.Views {
+		if t.Views[i].Name == "" {
+			return errors.Wrapf(ErrInvalidMetadata, "view %d: name is required", i)
+		}
+		if len(t.Views[i].Implementations) == 0 {
+			return errors.Wrapf(ErrInvalidMetadata, "view %s: implementations are required", t.Views[i].Name)
+		}
+		for j, impl := range t.Views[i].Implementations {
+			if len(impl.MichelsonStorageView) == 0 && len(impl.RestApiQuery) == 0 {
+				return errors.Wrapf(ErrInvalidMetadata, "view %s: implementation %d is empty", t.Views[i].Name, j)
+			}
+		}
+	}
+
+	if len(t.Errors) > 0 {
+		var errs []stdJSON.RawMessage
+		if err := json.Unmarshal(t.Errors, &errs); err != nil {
+			return errors.Wrap(ErrInvalidMetadata, "errors must be an array")
+		}
+	}
+
+	return nil
+}
+
+// ToModel -
+func (t TZIP16) ToModel(contract, uri string, level int64, timestamp time.Time, raw []byte) (*metadata.ContractMetadata, error) {
+	model := &metadata.ContractMetadata{
+		Contract:    contract,
+		Level:       level,
+		Timestamp:   timestamp,
+		URI:         uri,
+		Name:        t.Name,
+		Description: t.Description,
+		Version:     t.Version,
+		Homepage:    t.Homepage,
+		Authors:     t.Authors,
+		Interfaces:  t.Interfaces,
+		Raw:         raw,
+	}
+
+	if t.License != nil {
+		license, err := json.Marshal(t.License)
+		if err != nil {
+			return nil, err
+		}
+		model.License = license
+	}
+
+	if len(t.Views) > 0 {
+		views, err := json.Marshal(t.Views)
+		if err != nil {
+			return nil, err
+		}
+		model.Views = views
+	}
+
+	return model, nil
+}
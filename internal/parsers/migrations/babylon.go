@@ -66,13 +66,14 @@ func (p *Babylon) Parse(ctx context.Context, script noderpc.Script, old *modelsC
 	old.BabylonID = contractScript.ID
 
 	m := &migration.Migration{
-		ContractID:     old.ID,
-		Contract:       *old,
-		Level:          next.StartLevel,
-		ProtocolID:     next.ID,
-		PrevProtocolID: previous.ID,
-		Timestamp:      timestamp,
-		Kind:           types.MigrationKindUpdate,
+		ContractID:      old.ID,
+		Contract:        *old,
+		Level:           next.StartLevel,
+		ProtocolID:      next.ID,
+		PrevProtocolID:  previous.ID,
+		Timestamp:       timestamp,
+		Kind:            types.MigrationKindUpdate,
+		DeffatedStorage: script.Storage,
 	}
 
 	return tx.Migrations(ctx, m)
@@ -0,0 +1,308 @@
+package operations
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	astContract "github.com/baking-bad/bcdhub/internal/bcd/contract"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	modelsFilter "github.com/baking-bad/bcdhub/internal/models/filter"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+// Filter - selects operations for selective indexing. Operation content is indexed with all its internal operations if any of them
+// touches indexed contract, contract from the allow-list or originates contract whose code hash or tags are in the filter.
+// Contracts originated by indexed operations become indexed too, so the filter keeps state of all indexed contracts consistent.
+// Global constants registrations are always indexed because indexed contracts may use them.
+type Filter struct {
+	addresses map[string]struct{}
+	hashes    map[string]struct{}
+	tags      types.Tags
+
+	contracts contract.Repository
+	storage   models.GeneralRepository
+
+	// backfill - if it's set, only operations which touch these contracts or originate contracts matched by hashes and tags
+	// and don't touch other indexed contracts are matched
+	backfill map[string]struct{}
+
+	// indexed - cache of checked contracts. Contract which isn't indexed can become indexed only by origination matched by the filter,
+	// so negative results are cached too.
+	indexed map[string]bool
+	mx      sync.Mutex
+}
+
+// NewFilter -
+func NewFilter(cfg config.FilterConfig, contracts contract.Repository, storage models.GeneralRepository) *Filter {
+	f := &Filter{
+		addresses: make(map[string]struct{}, len(cfg.Addresses)),
+		hashes:    make(map[string]struct{}, len(cfg.ScriptHashes)),
+		tags:      types.NewTags(cfg.Tags),
+		contracts: contracts,
+		storage:   storage,
+		indexed:   make(map[string]bool),
+	}
+	for i := range cfg.Addresses {
+		f.addresses[cfg.Addresses[i]] = struct{}{}
+	}
+	for i := range cfg.ScriptHashes {
+		f.hashes[cfg.ScriptHashes[i]] = struct{}{}
+	}
+	return f
+}
+
+// Missing - returns contracts of the allow-list which are not indexed yet
+func (f *Filter) Missing(ctx context.Context) ([]string, error) {
+	missing := make([]string, 0)
+	for address := range f.addresses {
+		if !bcd.IsContract(address) {
+			continue
+		}
+		f.mx.Lock()
+		indexed, err := f.isIndexed(ctx, address)
+		f.mx.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if !indexed {
+			missing = append(missing, address)
+		}
+	}
+	return missing, nil
+}
+
+// Rules - returns code hashes and tags of the filter
+func (f *Filter) Rules() []modelsFilter.Rule {
+	hashes := make([]string, 0, len(f.hashes))
+	for hash := range f.hashes {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	tags := f.tags.ToArray()
+	rules := make([]modelsFilter.Rule, 0, len(hashes)+len(tags))
+	for i := range hashes {
+		rules = append(rules, modelsFilter.Rule{Kind: modelsFilter.RuleKindScriptHash, Value: hashes[i]})
+	}
+	for i := range tags {
+		rules = append(rules, modelsFilter.Rule{Kind: modelsFilter.RuleKindTag, Value: tags[i]})
+	}
+	return rules
+}
+
+// NewRules - returns code hashes and tags of the filter which are absent in `indexed` rules
+func (f *Filter) NewRules(indexed []modelsFilter.Rule) []modelsFilter.Rule {
+	known := make(map[modelsFilter.Rule]struct{}, len(indexed))
+	for i := range indexed {
+		known[modelsFilter.Rule{Kind: indexed[i].Kind, Value: indexed[i].Value}] = struct{}{}
+	}
+
+	rules := make([]modelsFilter.Rule, 0)
+	for _, rule := range f.Rules() {
+		if _, ok := known[rule]; !ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// Backfill - returns filter which matches operations touching `addresses` or originating contracts matched by `rules` for indexing history
+// of the contracts which were added to the filter later. Operations which touch other indexed contracts are skipped because they are indexed already.
+func (f *Filter) Backfill(addresses []string, rules []modelsFilter.Rule) *Filter {
+	backfill := &Filter{
+		addresses: f.addresses,
+		hashes:    make(map[string]struct{}),
+		contracts: f.contracts,
+		storage:   f.storage,
+		backfill:  make(map[string]struct{}, len(addresses)),
+		indexed:   make(map[string]bool),
+	}
+	for i := range addresses {
+		backfill.backfill[addresses[i]] = struct{}{}
+	}
+
+	tags := make([]string, 0)
+	for i := range rules {
+		switch rules[i].Kind {
+		case modelsFilter.RuleKindScriptHash:
+			backfill.hashes[rules[i].Value] = struct{}{}
+		case modelsFilter.RuleKindTag:
+			tags = append(tags, rules[i].Value)
+		}
+	}
+	backfill.tags = types.NewTags(tags)
+	return backfill
+}
+
+// Reset - drops cache of checked contracts. It has to be called after rollback because contracts originated in the rolled back blocks are removed.
+func (f *Filter) Reset() {
+	f.mx.Lock()
+	f.indexed = make(map[string]bool)
+	f.mx.Unlock()
+}
+
+// Match - returns true if the operation content has to be indexed
+func (f *Filter) Match(ctx context.Context, operation noderpc.Operation) (bool, error) {
+	if operation.Kind == consts.RegisterGlobalConstant {
+		return f.backfill == nil, nil
+	}
+
+	var touched touchedContracts
+	touched.collect(operation)
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.backfill != nil {
+		return f.matchBackfill(ctx, touched)
+	}
+
+	matched := false
+	for _, address := range touched.addresses {
+		if _, ok := f.addresses[address]; ok {
+			matched = true
+			break
+		}
+		indexed, err := f.isIndexed(ctx, address)
+		if err != nil {
+			return false, err
+		}
+		if indexed {
+			matched = true
+			break
+		}
+	}
+	for i := 0; i < len(touched.originations) && !matched; i++ {
+		matched = f.matchScript(touched.originations[i].script)
+	}
+
+	if matched {
+		for i := range touched.originations {
+			for _, address := range touched.originations[i].addresses {
+				f.indexed[address] = true
+			}
+		}
+	}
+	return matched, nil
+}
+
+func (f *Filter) matchBackfill(ctx context.Context, touched touchedContracts) (bool, error) {
+	matched := false
+	for _, address := range touched.addresses {
+		if _, ok := f.backfill[address]; ok {
+			matched = true
+			continue
+		}
+		indexed, err := f.isIndexed(ctx, address)
+		if err != nil {
+			return false, err
+		}
+		if indexed {
+			return false, nil
+		}
+	}
+
+	// hashes and tags of the backfill filter are the rules added later
+	for i := 0; i < len(touched.originations) && !matched; i++ {
+		matched = f.matchScript(touched.originations[i].script)
+	}
+
+	if matched {
+		for i := range touched.originations {
+			for _, address := range touched.originations[i].addresses {
+				f.backfill[address] = struct{}{}
+			}
+		}
+	}
+	return matched, nil
+}
+
+// isIndexed - checks the contract is saved to the database
+func (f *Filter) isIndexed(ctx context.Context, address string) (bool, error) {
+	if indexed, ok := f.indexed[address]; ok {
+		return indexed, nil
+	}
+
+	if _, err := f.contracts.Get(ctx, address); err != nil {
+		if !f.storage.IsRecordNotFound(err) {
+			return false, err
+		}
+		f.indexed[address] = false
+		return false, nil
+	}
+	f.indexed[address] = true
+	return true, nil
+}
+
+// matchScript - checks code hash and tags of the originated contract. Scripts which can't be parsed without global constants are matched by hash only.
+func (f *Filter) matchScript(script []byte) bool {
+	if len(script) == 0 || (len(f.hashes) == 0 && f.tags == 0) {
+		return false
+	}
+	parser, err := astContract.NewParser(script)
+	if err != nil {
+		return false
+	}
+	if _, ok := f.hashes[parser.Hash]; ok {
+		return true
+	}
+	if f.tags == 0 {
+		return false
+	}
+	if err := parser.Parse(); err != nil {
+		return false
+	}
+	return types.NewTags(parser.Tags.Values())&f.tags != 0
+}
+
+type touchedOrigination struct {
+	script    []byte
+	addresses []string
+}
+
+// touchedContracts - contracts which are touched by the operation content and its internal operations
+type touchedContracts struct {
+	addresses    []string
+	originations []touchedOrigination
+}
+
+func (t *touchedContracts) collect(operation noderpc.Operation) {
+	t.add(operation.Source)
+	if operation.Destination != nil {
+		t.add(*operation.Destination)
+	}
+	t.add(operation.TicketTicketer)
+
+	if result := operation.GetResult(); result != nil && len(result.Originated) > 0 {
+		for i := range result.Originated {
+			t.add(result.Originated[i])
+		}
+		t.originations = append(t.originations, touchedOrigination{
+			script:    operation.Script,
+			addresses: result.Originated,
+		})
+	}
+
+	if operation.Metadata == nil {
+		return
+	}
+	internals := operation.Metadata.Internal
+	if internals == nil {
+		internals = operation.Metadata.InternalOperations
+	}
+	for i := range internals {
+		t.collect(internals[i])
+	}
+}
+
+func (t *touchedContracts) add(address string) {
+	if bcd.IsContract(address) {
+		t.addresses = append(t.addresses, address)
+	}
+}
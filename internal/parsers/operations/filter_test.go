@@ -0,0 +1,259 @@
+package operations
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	astContract "github.com/baking-bad/bcdhub/internal/bcd/contract"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	modelsFilter "github.com/baking-bad/bcdhub/internal/models/filter"
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_contract "github.com/baking-bad/bcdhub/internal/models/mock/contract"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	filterSource    = "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+	filterAllowed   = "KT1BRudFZEXLYANgmZTka1xCDN5nWTMWY7SZ"
+	filterIndexed   = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	filterUnknown   = "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"
+	filterOriginate = "KT1W3fGSo8XfRSESPAg3Jngzt3D8xpPqW64i"
+	filterScript    = `{"code":[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":{"prim":"Unit"}}`
+)
+
+func filterTransaction(destination string, internals ...noderpc.Operation) noderpc.Operation {
+	return noderpc.Operation{
+		Kind:        consts.Transaction,
+		Source:      filterSource,
+		Destination: &destination,
+		Metadata: &noderpc.OperationMetadata{
+			Internal: internals,
+		},
+	}
+}
+
+func filterOrigination(source, address string) noderpc.Operation {
+	return noderpc.Operation{
+		Kind:   consts.Origination,
+		Source: source,
+		Script: []byte(filterScript),
+		Metadata: &noderpc.OperationMetadata{
+			OperationResult: &noderpc.OperationResult{
+				Originated: []string{address},
+			},
+		},
+	}
+}
+
+func newFilterMocks(t *testing.T) (*mock_contract.MockRepository, *mock_general.MockGeneralRepository) {
+	ctrl := gomock.NewController(t)
+	contracts := mock_contract.NewMockRepository(ctrl)
+	contracts.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, address string) (contract.Contract, error) {
+			if address == filterIndexed {
+				return contract.Contract{}, nil
+			}
+			return contract.Contract{}, sql.ErrNoRows
+		}).
+		AnyTimes()
+
+	general := mock_general.NewMockGeneralRepository(ctrl)
+	general.EXPECT().
+		IsRecordNotFound(gomock.Any()).
+		DoAndReturn(func(err error) bool {
+			return errors.Is(err, sql.ErrNoRows)
+		}).
+		AnyTimes()
+	return contracts, general
+}
+
+func TestFilter_Match(t *testing.T) {
+	parser, err := astContract.NewParser([]byte(filterScript))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		cfg       config.FilterConfig
+		operation noderpc.Operation
+		want      bool
+	}{
+		{
+			name:      "allowed destination",
+			cfg:       config.FilterConfig{Addresses: []string{filterAllowed}},
+			operation: filterTransaction(filterAllowed),
+			want:      true,
+		}, {
+			name:      "indexed destination",
+			cfg:       config.FilterConfig{Addresses: []string{filterAllowed}},
+			operation: filterTransaction(filterIndexed),
+			want:      true,
+		}, {
+			name:      "unknown destination",
+			cfg:       config.FilterConfig{Addresses: []string{filterAllowed}},
+			operation: filterTransaction(filterUnknown),
+			want:      false,
+		}, {
+			name:      "allowed internal destination",
+			cfg:       config.FilterConfig{Addresses: []string{filterAllowed}},
+			operation: filterTransaction(filterUnknown, filterTransaction(filterAllowed)),
+			want:      true,
+		}, {
+			name:      "origination with allowed hash",
+			cfg:       config.FilterConfig{ScriptHashes: []string{parser.Hash}},
+			operation: filterOrigination(filterSource, filterOriginate),
+			want:      true,
+		}, {
+			name:      "origination with other hash",
+			cfg:       config.FilterConfig{ScriptHashes: []string{"unknown"}},
+			operation: filterOrigination(filterSource, filterOriginate),
+			want:      false,
+		}, {
+			name:      "origination with missing tag",
+			cfg:       config.FilterConfig{Tags: []string{"fa2"}},
+			operation: filterOrigination(filterSource, filterOriginate),
+			want:      false,
+		}, {
+			name: "global constant",
+			cfg:  config.FilterConfig{Addresses: []string{filterAllowed}},
+			operation: noderpc.Operation{
+				Kind:   consts.RegisterGlobalConstant,
+				Source: filterSource,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contracts, general := newFilterMocks(t)
+			filter := NewFilter(tt.cfg, contracts, general)
+
+			got, err := filter.Match(context.Background(), tt.operation)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter_OriginatedByIndexed(t *testing.T) {
+	contracts, general := newFilterMocks(t)
+	filter := NewFilter(config.FilterConfig{Addresses: []string{filterAllowed}}, contracts, general)
+
+	// contract originated by the allowed one becomes indexed
+	matched, err := filter.Match(context.Background(), filterTransaction(filterAllowed, filterOrigination(filterAllowed, filterOriginate)))
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = filter.Match(context.Background(), filterTransaction(filterOriginate))
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	// origination is rolled back, so the contract is absent in the database
+	filter.Reset()
+	matched, err = filter.Match(context.Background(), filterTransaction(filterOriginate))
+	require.NoError(t, err)
+	require.False(t, matched)
+}
+
+func TestFilter_Backfill(t *testing.T) {
+	contracts, general := newFilterMocks(t)
+	filter := NewFilter(config.FilterConfig{Addresses: []string{filterAllowed, filterUnknown}}, contracts, general)
+
+	missing, err := filter.Missing(context.Background())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{filterAllowed, filterUnknown}, missing)
+
+	backfill := filter.Backfill([]string{filterUnknown}, nil)
+
+	tests := []struct {
+		name      string
+		operation noderpc.Operation
+		want      bool
+	}{
+		{
+			name:      "missing contract",
+			operation: filterTransaction(filterUnknown),
+			want:      true,
+		}, {
+			name:      "missing contract with indexed one",
+			operation: filterTransaction(filterIndexed, filterTransaction(filterUnknown)),
+			want:      false,
+		}, {
+			name:      "other contract",
+			operation: filterTransaction(filterAllowed),
+			want:      false,
+		}, {
+			name: "global constant",
+			operation: noderpc.Operation{
+				Kind:   consts.RegisterGlobalConstant,
+				Source: filterSource,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := backfill.Match(context.Background(), tt.operation)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter_NewRules(t *testing.T) {
+	contracts, general := newFilterMocks(t)
+	filter := NewFilter(config.FilterConfig{ScriptHashes: []string{"hash_2", "hash_1"}, Tags: []string{"fa2", "unknown"}}, contracts, general)
+
+	require.Equal(t, []modelsFilter.Rule{
+		{Kind: modelsFilter.RuleKindScriptHash, Value: "hash_1"},
+		{Kind: modelsFilter.RuleKindScriptHash, Value: "hash_2"},
+		{Kind: modelsFilter.RuleKindTag, Value: "fa2"},
+	}, filter.Rules())
+
+	indexed := []modelsFilter.Rule{
+		{ID: 1, Kind: modelsFilter.RuleKindScriptHash, Value: "hash_1"},
+		{ID: 2, Kind: modelsFilter.RuleKindTag, Value: "fa1-2"},
+	}
+	require.Equal(t, []modelsFilter.Rule{
+		{Kind: modelsFilter.RuleKindScriptHash, Value: "hash_2"},
+		{Kind: modelsFilter.RuleKindTag, Value: "fa2"},
+	}, filter.NewRules(indexed))
+}
+
+func TestFilter_BackfillRules(t *testing.T) {
+	parser, err := astContract.NewParser([]byte(filterScript))
+	require.NoError(t, err)
+
+	contracts, general := newFilterMocks(t)
+	filter := NewFilter(config.FilterConfig{ScriptHashes: []string{parser.Hash}}, contracts, general)
+	backfill := filter.Backfill(nil, filter.Rules())
+
+	// origination by indexed contract is saved already
+	matched, err := backfill.Match(context.Background(), filterTransaction(filterIndexed, filterOrigination(filterIndexed, filterUnknown)))
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	matched, err = backfill.Match(context.Background(), filterOrigination(filterSource, filterOriginate))
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	// calls of the contract originated with the new hash are backfilled too
+	matched, err = backfill.Match(context.Background(), filterTransaction(filterOriginate))
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = backfill.Match(context.Background(), filterTransaction(filterAllowed))
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	// filter without new rules doesn't match originations
+	matched, err = filter.Backfill(nil, nil).Match(context.Background(), filterOrigination(filterSource, filterOriginate))
+	require.NoError(t, err)
+	require.False(t, matched)
+}
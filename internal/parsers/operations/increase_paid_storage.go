@@ -0,0 +1,76 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// IncreasePaidStorage -
+type IncreasePaidStorage struct {
+	*ParseParams
+}
+
+// NewIncreasePaidStorage -
+func NewIncreasePaidStorage(params *ParseParams) IncreasePaidStorage {
+	return IncreasePaidStorage{params}
+}
+
+// Parse -
+func (p IncreasePaidStorage) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	source := account.Account{
+		Address:         data.Source,
+		Type:            types.NewAccountType(data.Source),
+		Level:           p.head.Level,
+		OperationsCount: 1,
+		LastAction:      p.head.Timestamp,
+	}
+
+	increase := operation.Operation{
+		Source:       source,
+		Initiator:    source,
+		StorageLimit: data.StorageLimit,
+		Fee:          data.Fee,
+		Counter:      data.Counter,
+		GasLimit:     data.GasLimit,
+		Hash:         p.hash,
+		ProtocolID:   p.protocol.ID,
+		Level:        p.head.Level,
+		Timestamp:    p.head.Timestamp,
+		Kind:         types.NewOperationKind(data.Kind),
+		ContentIndex: p.contentIdx,
+	}
+
+	if data.Destination != nil {
+		increase.Destination = account.Account{
+			Address:         *data.Destination,
+			Type:            types.NewAccountType(*data.Destination),
+			Level:           p.head.Level,
+			OperationsCount: 1,
+			LastAction:      p.head.Timestamp,
+		}
+		store.AddAccounts(increase.Destination)
+	}
+
+	if p.main == nil {
+		p.main = &increase
+	}
+
+	parseOperationResult(data, &increase, store)
+
+	// result of the operation doesn't contain paid storage size diff: amount of the operation is the count of bytes paid for the destination contract
+	if increase.IsApplied() && increase.PaidStorageSizeDiff == 0 && data.Amount != nil {
+		increase.PaidStorageSizeDiff = *data.Amount
+	}
+	increase.SetBurned(*p.protocol.Constants)
+	p.stackTrace.Add(increase)
+
+	store.AddOperations(&increase)
+	store.AddAccounts(increase.Source)
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/baking-bad/bcdhub/internal/parsers/metadata"
+)
+
+// parseMetadata - receives TZIP-16 contract metadata and TZIP-21 token metadata changed by the operation
+func (p *ParseParams) parseMetadata(ctx context.Context, op *operation.Operation, store parsers.Store) error {
+	if err := metadata.NewContractParser(p.ctx.BigMapDiffs, p.ctx.Storage, p.remoteMetadata).Parse(ctx, op, store); err != nil {
+		return err
+	}
+	return metadata.NewTokenParser(p.remoteMetadata).Parse(ctx, op, store)
+}
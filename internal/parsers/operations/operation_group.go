@@ -49,6 +49,16 @@ func (opg Group) Parse(ctx context.Context, data noderpc.LightOperationGroup, st
 			return err
 		}
 
+		if opg.filter != nil {
+			matched, err := opg.filter.Match(ctx, operation)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				continue
+			}
+		}
+
 		contentParser := NewContent(opg.ParseParams)
 		if err := contentParser.Parse(ctx, operation, store); err != nil {
 			return err
@@ -59,20 +69,38 @@ func (opg Group) Parse(ctx context.Context, data noderpc.LightOperationGroup, st
 	return nil
 }
 
+// NeedParse - returns true if the operation group contains operations which are indexed, i.e. parsing of the group depends on the indexed state of contracts
+func NeedParse(group noderpc.LightOperationGroup) bool {
+	for i := range group.Contents {
+		if (Group{}).needParse(group.Contents[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 func (Group) needParse(item noderpc.LightOperation) bool {
-	var destination string
+	var destination, entrypoint string
 	if item.Destination != nil {
 		destination = *item.Destination
 	}
+	if item.Parameters != nil {
+		entrypoint = item.Parameters.Entrypoint
+	}
 	prefixCondition := bcd.IsContract(item.Source) || bcd.IsContract(destination)
 	transactionCondition := item.Kind == consts.Transaction && prefixCondition
 	originationCondition := (item.Kind == consts.Origination || item.Kind == consts.OriginationNew || item.Kind == consts.TxRollupOrigination)
 	registerGlobalConstantCondition := item.Kind == consts.RegisterGlobalConstant
 	eventCondition := item.Kind == consts.Event
 	transferTicketCondition := item.Kind == consts.TransferTicket
-	srCondition := item.Kind == consts.SrOriginate || item.Kind == consts.SrExecuteOutboxMessage
+	srCondition := item.Kind == consts.SrOriginate || item.Kind == consts.SrExecuteOutboxMessage ||
+		item.Kind == consts.SrAddMessages || item.Kind == consts.SrCement || item.Kind == consts.SrPublish ||
+		item.Kind == consts.SrRefute || item.Kind == consts.SrTimeout || item.Kind == consts.SrRecoverBond
+	increasePaidStorageCondition := item.Kind == consts.IncreasePaidStorage
+	stakingCondition := isStaking(item.Kind, item.Source, destination, entrypoint)
 	return originationCondition || transactionCondition || srCondition ||
-		registerGlobalConstantCondition || eventCondition || transferTicketCondition
+		registerGlobalConstantCondition || eventCondition || transferTicketCondition ||
+		increasePaidStorageCondition || stakingCondition
 }
 
 // Content -
@@ -92,7 +120,11 @@ func (content Content) Parse(ctx context.Context, data noderpc.Operation, store
 	case consts.Origination, consts.OriginationNew:
 		operationParser = NewOrigination(content.ParseParams)
 	case consts.Transaction:
-		operationParser = NewTransaction(content.ParseParams)
+		if data.Destination != nil && isStaking(data.Kind, data.Source, *data.Destination, stakingEntrypoint(data)) {
+			operationParser = NewStaking(content.ParseParams)
+		} else {
+			operationParser = NewTransaction(content.ParseParams)
+		}
 	case consts.RegisterGlobalConstant:
 		operationParser = NewRegisterGlobalConstant(content.ParseParams)
 	case consts.TxRollupOrigination:
@@ -105,6 +137,20 @@ func (content Content) Parse(ctx context.Context, data noderpc.Operation, store
 		operationParser = NewSrOriginate(content.ParseParams)
 	case consts.SrExecuteOutboxMessage:
 		operationParser = NewSrExecuteOutboxMessage(content.ParseParams)
+	case consts.SrAddMessages:
+		operationParser = NewSrAddMessages(content.ParseParams)
+	case consts.SrCement:
+		operationParser = NewSrCement(content.ParseParams)
+	case consts.SrPublish:
+		operationParser = NewSrPublish(content.ParseParams)
+	case consts.SrRefute:
+		operationParser = NewSrRefute(content.ParseParams)
+	case consts.SrTimeout:
+		operationParser = NewSrTimeout(content.ParseParams)
+	case consts.SrRecoverBond:
+		operationParser = NewSrRecoverBond(content.ParseParams)
+	case consts.IncreasePaidStorage:
+		operationParser = NewIncreasePaidStorage(content.ParseParams)
 	default:
 		return nil
 	}
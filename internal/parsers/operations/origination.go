@@ -111,6 +111,10 @@ func (p Origination) appliedHandler(ctx context.Context, item noderpc.Operation,
 		return err
 	}
 
+	if err := p.parseMetadata(ctx, origination, store); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -28,6 +28,12 @@ type ParseParams struct {
 	protocol   *protocol.Protocol
 
 	withEvents bool
+
+	remoteMetadata bool
+	filter         *Filter
+
+	// rollups - cache of smart rollups which were checked by inbox messages of the block
+	rollups map[string]bool
 }
 
 // ParseParamsOption -
@@ -68,12 +74,27 @@ func WithMainOperation(main *operation.Operation) ParseParamsOption {
 	}
 }
 
+// WithRemoteMetadata - enables saving of metadata referenced by off-chain URI as pending. Its documents are received in background by metadata.Resolver. If it's not set only on-chain metadata is indexed.
+func WithRemoteMetadata(remote bool) ParseParamsOption {
+	return func(dp *ParseParams) {
+		dp.remoteMetadata = remote
+	}
+}
+
+// WithFilter - sets filter of selective indexing. If it's not set all operations are indexed.
+func WithFilter(filter *Filter) ParseParamsOption {
+	return func(dp *ParseParams) {
+		dp.filter = filter
+	}
+}
+
 // NewParseParams -
 func NewParseParams(ctx context.Context, configContext *config.Context, opts ...ParseParamsOption) (*ParseParams, error) {
 	params := &ParseParams{
 		ctx:        configContext,
 		stackTrace: stacktrace.New(),
 		withEvents: configContext.Network == types.Mainnet,
+		rollups:    make(map[string]bool),
 	}
 	for i := range opts {
 		opts[i](params)
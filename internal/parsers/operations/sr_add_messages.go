@@ -0,0 +1,102 @@
+package operations
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/pkg/errors"
+)
+
+// targetedMessageTag - tag of external message framed for the specific rollup: tag byte, 20 bytes of rollup address and payload
+const targetedMessageTag = 0x00
+
+// SrAddMessages -
+type SrAddMessages struct {
+	*ParseParams
+}
+
+// NewSrAddMessages -
+func NewSrAddMessages(params *ParseParams) SrAddMessages {
+	return SrAddMessages{params}
+}
+
+// Parse -
+func (p SrAddMessages) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	operation := newSrOperation(p.ParseParams, data, store)
+
+	if operation.IsApplied() {
+		operation.SmartRollupMessages = make([]*smartrollup.Message, 0, len(data.Message))
+		for i := range data.Message {
+			raw, err := hex.DecodeString(data.Message[i])
+			if err != nil {
+				return errors.Wrap(err, "inbox message decoding")
+			}
+			message := &smartrollup.Message{
+				Timestamp: operation.Timestamp,
+				Level:     operation.Level,
+				Index:     int64(i),
+				Raw:       raw,
+			}
+
+			if rollup, payload, ok := decodeTargetedMessage(raw); ok {
+				known, err := p.isKnownRollup(ctx, rollup, store)
+				if err != nil {
+					return err
+				}
+				if known {
+					message.Rollup = rollup
+					message.Payload = payload
+				}
+			}
+			operation.SmartRollupMessages = append(operation.SmartRollupMessages, message)
+		}
+	}
+
+	store.AddOperations(operation)
+	store.AddAccounts(operation.Source)
+	return nil
+}
+
+// isKnownRollup - checks the rollup is originated. Rollup originated earlier in the same block is not saved yet, so it's looked up in the store first. Results of the database lookups are cached for the block.
+func (p SrAddMessages) isKnownRollup(ctx context.Context, address string, store parsers.Store) (bool, error) {
+	if known := p.rollups[address]; known {
+		return true, nil
+	}
+
+	for _, rollup := range store.ListSmartRollups() {
+		if rollup.Address.Address == address {
+			p.rollups[address] = true
+			return true, nil
+		}
+	}
+
+	if known, ok := p.rollups[address]; ok {
+		return known, nil
+	}
+
+	if _, err := p.ctx.SmartRollups.Get(ctx, address); err != nil {
+		if !p.ctx.Storage.IsRecordNotFound(err) {
+			return false, errors.Wrap(err, "receiving smart rollup")
+		}
+		p.rollups[address] = false
+		return false, nil
+	}
+	p.rollups[address] = true
+	return true, nil
+}
+
+// decodeTargetedMessage - decodes external message framed by the rollup address. Returns false if message isn't framed.
+func decodeTargetedMessage(raw []byte) (string, []byte, bool) {
+	if len(raw) < 21 || raw[0] != targetedMessageTag {
+		return "", nil, false
+	}
+	rollup, err := encoding.EncodeBase58(raw[1:21], []byte(encoding.PrefixOriginatedSmartRollup))
+	if err != nil {
+		return "", nil, false
+	}
+	return rollup, raw[21:], true
+}
@@ -0,0 +1,46 @@
+package operations
+
+import (
+	"context"
+
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// SrCement -
+type SrCement struct {
+	*ParseParams
+}
+
+// NewSrCement -
+func NewSrCement(params *ParseParams) SrCement {
+	return SrCement{params}
+}
+
+// Parse -
+func (p SrCement) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	operation := newSrOperation(p.ParseParams, data, store)
+
+	if operation.IsApplied() && data.Rollup != nil {
+		commitment := &smartrollup.Commitment{
+			Timestamp: operation.Timestamp,
+			Level:     operation.Level,
+			Action:    smartrollup.CommitmentCement,
+			Rollup:    *data.Rollup,
+		}
+		if result := data.GetResult(); result != nil {
+			commitment.Hash = result.CommitmentHash
+			commitment.InboxLevel = result.InboxLevel
+		}
+		// before Nairobi cemented commitment was the operation's field
+		if commitment.Hash == "" && len(data.Commitment) > 0 {
+			_ = json.Unmarshal(data.Commitment, &commitment.Hash)
+		}
+		operation.SmartRollupCommitments = []*smartrollup.Commitment{commitment}
+	}
+
+	store.AddOperations(operation)
+	store.AddAccounts(operation.Source)
+	return nil
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/hex"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/rollup"
+	bcdTypes "github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/models/account"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/parsers"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 // SrExecuteOutboxMessage -
@@ -75,6 +80,12 @@ func (p SrExecuteOutboxMessage) Parse(ctx context.Context, data noderpc.Operatio
 			return errors.Wrap(err, "outbox proof decoding")
 		}
 		operation.Payload = append(operation.Payload, proof...)
+
+		if data.Rollup != nil {
+			if err := p.parseOutput(ctx, data, proof, &operation); err != nil {
+				return err
+			}
+		}
 	}
 
 	store.AddOperations(&operation)
@@ -96,3 +107,98 @@ func (p SrExecuteOutboxMessage) fillInternal(tx *operation.Operation) {
 	tx.Internal = true
 	tx.Initiator = p.main.Source
 }
+
+// parseOutput - decodes executed outbox message and links its transactions with internal operations.
+// Internal operations are listed in depth-first execution order, so operations emitted by destinations are placed between transactions of the batch.
+// Transaction is linked with the next internal transaction sent by the rollup to the same destination and entrypoint.
+func (p SrExecuteOutboxMessage) parseOutput(ctx context.Context, data noderpc.Operation, proof []byte, operation *operation.Operation) error {
+	state, err := p.outputState(ctx, *data.Rollup, data.CementedCommitment, proof)
+	if err != nil {
+		return err
+	}
+
+	output, err := rollup.DecodeOutput(proof, state, p.head.Level)
+	if err != nil {
+		log.Warn().Err(err).Str("hash", encoding.MustEncodeOperationHash(operation.Hash)).Msg("outbox message decoding")
+		return nil
+	}
+
+	var internals []noderpc.Operation
+	if data.Metadata != nil {
+		internals = data.Metadata.Internal
+		if internals == nil {
+			internals = data.Metadata.InternalOperations
+		}
+	}
+
+	operation.SmartRollupOutbox = make([]*smartrollup.OutboxTransaction, 0, len(output.Transactions))
+	var next int
+	for i, tx := range output.Transactions {
+		params, err := json.Marshal(bcdTypes.Parameters{
+			Entrypoint: tx.Entrypoint,
+			Value:      tx.Parameters,
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("outbox transaction parameters")
+			continue
+		}
+
+		transaction := &smartrollup.OutboxTransaction{
+			Timestamp:      operation.Timestamp,
+			Level:          operation.Level,
+			Rollup:         *data.Rollup,
+			OutboxLevel:    output.OutboxLevel,
+			MessageIndex:   output.MessageIndex,
+			Index:          int64(i),
+			Kind:           output.Kind,
+			Destination:    tx.Destination,
+			Entrypoint:     tx.Entrypoint,
+			Parameters:     params,
+			ParametersType: tx.ParametersType,
+		}
+		if idx := findOutboxInternal(internals[next:], *data.Rollup, tx); idx >= 0 {
+			next += idx + 1
+			if internals[next-1].Nonce != nil {
+				nonce := *internals[next-1].Nonce
+				transaction.Nonce = &nonce
+			}
+		}
+		operation.SmartRollupOutbox = append(operation.SmartRollupOutbox, transaction)
+	}
+	return nil
+}
+
+// outputState - returns state hash of the output proof. It's the compressed state of the cemented commitment.
+// If the commitment wasn't indexed, the state is taken from the context proof of `wasm_2_0_0` PVM.
+func (p SrExecuteOutboxMessage) outputState(ctx context.Context, address, cemented string, proof []byte) ([]byte, error) {
+	commitment, err := p.ctx.SmartRollups.Commitment(ctx, address, cemented)
+	switch {
+	case err == nil:
+		state, err := encoding.DecodeBase58(commitment.CompressedState)
+		if err != nil {
+			return nil, errors.Wrap(err, "compressed state decoding")
+		}
+		return state, nil
+	case p.ctx.Storage.IsRecordNotFound(err):
+		state, _ := rollup.ProofState(proof)
+		return state, nil
+	default:
+		return nil, errors.Wrap(err, "receiving cemented commitment")
+	}
+}
+
+// findOutboxInternal - returns index of the first internal transaction of the outbox transaction or -1
+func findOutboxInternal(internals []noderpc.Operation, address string, tx rollup.Transaction) int {
+	for i := range internals {
+		if internals[i].Kind != consts.Transaction || internals[i].Source != address {
+			continue
+		}
+		if internals[i].Destination == nil || *internals[i].Destination != tx.Destination {
+			continue
+		}
+		if bcdTypes.NewParameters(internals[i].Parameters).Entrypoint == tx.Entrypoint {
+			return i
+		}
+	}
+	return -1
+}
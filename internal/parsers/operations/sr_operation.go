@@ -0,0 +1,62 @@
+package operations
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// newSrOperation - builds operation of smart rollup operation kinds which don't have own fields in the operation model.
+// Destination of the operation is the rollup if it's set.
+func newSrOperation(p *ParseParams, data noderpc.Operation, store parsers.Store) *operation.Operation {
+	source := account.Account{
+		Address:         data.Source,
+		Type:            types.NewAccountType(data.Source),
+		Level:           p.head.Level,
+		OperationsCount: 1,
+		LastAction:      p.head.Timestamp,
+	}
+
+	operation := &operation.Operation{
+		Source:       source,
+		Initiator:    source,
+		Fee:          data.Fee,
+		Counter:      data.Counter,
+		StorageLimit: data.StorageLimit,
+		GasLimit:     data.GasLimit,
+		Hash:         p.hash,
+		ProtocolID:   p.protocol.ID,
+		Level:        p.head.Level,
+		Timestamp:    p.head.Timestamp,
+		Kind:         types.NewOperationKind(data.Kind),
+		ContentIndex: p.contentIdx,
+	}
+	if data.Rollup != nil {
+		operation.Destination = account.Account{
+			Address:         *data.Rollup,
+			Type:            types.NewAccountType(*data.Rollup),
+			Level:           p.head.Level,
+			OperationsCount: 1,
+			LastAction:      p.head.Timestamp,
+		}
+		store.AddAccounts(operation.Destination)
+	}
+
+	if p.main == nil {
+		p.main = operation
+	} else {
+		operation.Counter = p.main.Counter
+		operation.Hash = p.main.Hash
+		operation.Level = p.main.Level
+		operation.Timestamp = p.main.Timestamp
+		operation.Internal = true
+		operation.Initiator = p.main.Source
+	}
+
+	parseOperationResult(data, operation, store)
+	operation.SetBurned(*p.protocol.Constants)
+	p.stackTrace.Add(*operation)
+	return operation
+}
@@ -0,0 +1,328 @@
+package operations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/rollup"
+	"github.com/baking-bad/bcdhub/internal/config"
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_smart_rollup "github.com/baking-bad/bcdhub/internal/models/mock/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/baking-bad/bcdhub/internal/parsers/stacktrace"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	srSource  = "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+	srRollup  = "sr18wx6ezkeRjt1SZSeZ2UQzQN3Uc3YLMLqg"
+	srUnknown = "sr1CbrWMFJsxFqhbRsgLBDT24nmLAyvFXSSy"
+)
+
+func newSrTestParams(ctx *config.Context) *ParseParams {
+	return &ParseParams{
+		ctx:        ctx,
+		stackTrace: stacktrace.New(),
+		rollups:    make(map[string]bool),
+		protocol:   &protocol.Protocol{ID: 1, Constants: &protocol.Constants{}},
+		head: noderpc.Header{
+			Level:     100,
+			Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func appliedResult(result noderpc.OperationResult) *noderpc.OperationMetadata {
+	result.Status = consts.Applied
+	return &noderpc.OperationMetadata{
+		OperationResult: &result,
+	}
+}
+
+func TestSrAddMessages_Parse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rollups := mock_smart_rollup.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+
+	rollups.EXPECT().Get(gomock.Any(), srRollup).Return(smartrollup.SmartRollup{}, nil).Times(1)
+	rollups.EXPECT().Get(gomock.Any(), srUnknown).Return(smartrollup.SmartRollup{}, sql.ErrNoRows).Times(1)
+	storage.EXPECT().IsRecordNotFound(sql.ErrNoRows).Return(true).AnyTimes()
+
+	params := newSrTestParams(&config.Context{
+		SmartRollups: rollups,
+		Storage:      storage,
+	})
+
+	data := noderpc.Operation{
+		Kind:   consts.SrAddMessages,
+		Source: srSource,
+		Message: []string{
+			"001fe3404f2e3b82a6d1abfa8780993f4a48565b84deadbeef",
+			"0047f6c47fa1e4b3d40ea01eb05dd0d84d4ac7f2bedeadbeef",
+			"01deadbeef",
+		},
+		Metadata: appliedResult(noderpc.OperationResult{}),
+	}
+
+	store := parsers.NewTestStore()
+	require.NoError(t, NewSrAddMessages(params).Parse(context.Background(), data, store))
+	require.Len(t, store.Operations, 1)
+
+	operation := store.Operations[0]
+	require.Equal(t, types.OperationKindSrAddMessages, operation.Kind)
+	require.Len(t, operation.SmartRollupMessages, 3)
+
+	known := operation.SmartRollupMessages[0]
+	require.Equal(t, srRollup, known.Rollup)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, known.Payload)
+	require.EqualValues(t, 100, known.Level)
+
+	for _, message := range operation.SmartRollupMessages[1:] {
+		require.Empty(t, message.Rollup)
+		require.Empty(t, message.Payload)
+		require.NotEmpty(t, message.Raw)
+	}
+	require.EqualValues(t, 2, operation.SmartRollupMessages[2].Index)
+}
+
+func TestSrAddMessages_ParseRollupsOfBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rollups := mock_smart_rollup.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+
+	// rollup originated in the block isn't requested, other one is requested once per block
+	rollups.EXPECT().Get(gomock.Any(), srRollup).Return(smartrollup.SmartRollup{}, nil).Times(1)
+
+	params := newSrTestParams(&config.Context{
+		SmartRollups: rollups,
+		Storage:      storage,
+	})
+
+	store := parsers.NewTestStore()
+	originated := &smartrollup.SmartRollup{}
+	originated.Address.Address = srUnknown
+	store.AddSmartRollups(originated)
+
+	for i := 0; i < 2; i++ {
+		data := noderpc.Operation{
+			Kind:   consts.SrAddMessages,
+			Source: srSource,
+			Message: []string{
+				"001fe3404f2e3b82a6d1abfa8780993f4a48565b84deadbeef",
+				"0047f6c47fa1e4b3d40ea01eb05dd0d84d4ac7f2bedeadbeef",
+			},
+			Metadata: appliedResult(noderpc.OperationResult{}),
+		}
+		require.NoError(t, NewSrAddMessages(params).Parse(context.Background(), data, store))
+	}
+	require.Len(t, store.Operations, 2)
+
+	for _, operation := range store.Operations {
+		require.Len(t, operation.SmartRollupMessages, 2)
+		require.Equal(t, srRollup, operation.SmartRollupMessages[0].Rollup)
+		require.Equal(t, srUnknown, operation.SmartRollupMessages[1].Rollup)
+		require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, operation.SmartRollupMessages[1].Payload)
+	}
+}
+
+func TestSrPublish_Parse(t *testing.T) {
+	rollup := srRollup
+	data := noderpc.Operation{
+		Kind:       consts.SrPublish,
+		Source:     srSource,
+		Rollup:     &rollup,
+		Commitment: []byte(`{"compressed_state":"srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6","inbox_level":1034,"predecessor":"src13MtM1eBzxCH1FBhLAkAiWGW6JbjvycLeH6vuz5k9GSiTYTCTja","number_of_ticks":"880000000000"}`),
+		Metadata: appliedResult(noderpc.OperationResult{
+			StakedHash:       "src131RsXj71miKm35DabLwzaJs2AgpQKYXQGRvw16AwXz3yvmXUHL",
+			PublishedAtLevel: 99,
+		}),
+	}
+
+	store := parsers.NewTestStore()
+	require.NoError(t, NewSrPublish(newSrTestParams(&config.Context{})).Parse(context.Background(), data, store))
+	require.Len(t, store.Operations, 1)
+
+	operation := store.Operations[0]
+	require.Equal(t, srRollup, operation.Destination.Address)
+	require.Equal(t, []*smartrollup.Commitment{
+		{
+			Timestamp:        operation.Timestamp,
+			Level:            100,
+			Action:           smartrollup.CommitmentPublish,
+			Rollup:           srRollup,
+			Staker:           srSource,
+			Hash:             "src131RsXj71miKm35DabLwzaJs2AgpQKYXQGRvw16AwXz3yvmXUHL",
+			Predecessor:      "src13MtM1eBzxCH1FBhLAkAiWGW6JbjvycLeH6vuz5k9GSiTYTCTja",
+			CompressedState:  "srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6",
+			InboxLevel:       1034,
+			NumberOfTicks:    880000000000,
+			PublishedAtLevel: 99,
+		},
+	}, operation.SmartRollupCommitments)
+	require.Contains(t, store.Accounts, srRollup)
+}
+
+func TestSrRefute_Parse(t *testing.T) {
+	rollup := srRollup
+	data := noderpc.Operation{
+		Kind:     consts.SrRefute,
+		Source:   srSource,
+		Rollup:   &rollup,
+		Opponent: "tz1TiuhDeTXb7gXDF1Yt1Ee5GVAt8g7MrM9F",
+		Refutation: &noderpc.Refutation{
+			Kind:   smartrollup.MoveMove,
+			Choice: "176000000003",
+			Step:   []byte(`[]`),
+		},
+		Metadata: appliedResult(noderpc.OperationResult{
+			GameStatus: &noderpc.GameStatus{
+				Status: smartrollup.GameLoser,
+				Loser:  "tz1TiuhDeTXb7gXDF1Yt1Ee5GVAt8g7MrM9F",
+				Reason: "conflict_resolved",
+			},
+		}),
+	}
+
+	store := parsers.NewTestStore()
+	require.NoError(t, NewSrRefute(newSrTestParams(&config.Context{})).Parse(context.Background(), data, store))
+	require.Len(t, store.Operations, 1)
+
+	moves := store.Operations[0].SmartRollupGameMoves
+	require.Len(t, moves, 1)
+	require.Equal(t, srSource, moves[0].Player)
+	require.Equal(t, "tz1TiuhDeTXb7gXDF1Yt1Ee5GVAt8g7MrM9F", moves[0].Opponent)
+	require.Equal(t, smartrollup.MoveMove, moves[0].Kind)
+	require.Equal(t, "176000000003", moves[0].Choice)
+	require.True(t, moves[0].IsFinished())
+	require.Equal(t, "tz1TiuhDeTXb7gXDF1Yt1Ee5GVAt8g7MrM9F", moves[0].Loser)
+}
+
+const (
+	srCommitment = "src13MtM1eBzxCH1FBhLAkAiWGW6JbjvycLeH6vuz5k9GSiTYTCTja"
+	srState      = "srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6"
+)
+
+// srOutboxProof - header of the context proof with the state before the proof, the rest of PVM proof, state hash, outbox level 90, message index 1 and
+// untyped batch with `Unit` transactions to KT1BRudFZEXLYANgmZTka1xCDN5nWTMWY7SZ%default and KT1BRudFZEXLYANgmZTka1xCDN5nWTMWY7SZ%mint
+func srOutboxProof(t *testing.T) string {
+	state, err := encoding.DecodeBase58(srState)
+	require.NoError(t, err)
+	stateHex := hex.EncodeToString(state)
+
+	return "000201" + stateHex + "01" + strings.Repeat("bb", 32) + "8181818181" + stateHex + "0000005a" + "01" +
+		"00" + "00000039" +
+		"030b" + "2a3f2b9bf7b9a7b5b6e0e36b8fe35d3d63bb3a2e" + "07" + "64656661756c74" +
+		"030b" + "2a3f2b9bf7b9a7b5b6e0e36b8fe35d3d63bb3a2e" + "04" + "6d696e74"
+}
+
+func TestSrExecuteOutboxMessage_Parse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rollups := mock_smart_rollup.NewMockRepository(ctrl)
+	rollups.EXPECT().Commitment(gomock.Any(), srRollup, srCommitment).Return(smartrollup.Commitment{
+		Hash:            srCommitment,
+		CompressedState: srState,
+	}, nil).Times(1)
+
+	rawDestination, err := hex.DecodeString("2a3f2b9bf7b9a7b5b6e0e36b8fe35d3d63bb3a2e")
+	require.NoError(t, err)
+	destination, err := encoding.EncodeBase58(rawDestination, []byte(encoding.PrefixPublicKeyKT1))
+	require.NoError(t, err)
+
+	address := srRollup
+	receiver := srSource
+	nonces := []int64{4, 5, 6}
+	data := noderpc.Operation{
+		Kind:               consts.SrExecuteOutboxMessage,
+		Source:             srSource,
+		Rollup:             &address,
+		CementedCommitment: srCommitment,
+		OutputProof:        srOutboxProof(t),
+		Metadata: &noderpc.OperationMetadata{
+			OperationResult: &noderpc.OperationResult{
+				Status: consts.Applied,
+			},
+			// the first destination emits its own transaction which is executed before the second transaction of the batch
+			Internal: []noderpc.Operation{
+				{Kind: consts.Transaction, Source: srRollup, Destination: &destination, Nonce: &nonces[0]},
+				{Kind: consts.Transaction, Source: destination, Destination: &receiver, Nonce: &nonces[1]},
+				{Kind: consts.Transaction, Source: srRollup, Destination: &destination, Nonce: &nonces[2], Parameters: []byte(`{"entrypoint":"mint","value":{"prim":"Unit"}}`)},
+			},
+		},
+	}
+
+	store := parsers.NewTestStore()
+	require.NoError(t, NewSrExecuteOutboxMessage(newSrTestParams(&config.Context{
+		SmartRollups: rollups,
+	})).Parse(context.Background(), data, store))
+	require.Len(t, store.Operations, 1)
+
+	outbox := store.Operations[0].SmartRollupOutbox
+	require.Len(t, outbox, 2)
+	require.Equal(t, &smartrollup.OutboxTransaction{
+		Timestamp:    store.Operations[0].Timestamp,
+		Level:        100,
+		Nonce:        &nonces[0],
+		Rollup:       srRollup,
+		OutboxLevel:  90,
+		MessageIndex: 1,
+		Kind:         rollup.KindUntyped,
+		Destination:  destination,
+		Entrypoint:   "default",
+		Parameters:   []byte(`{"entrypoint":"default","value":{"prim":"Unit"}}`),
+	}, outbox[0])
+	require.Equal(t, "mint", outbox[1].Entrypoint)
+	require.EqualValues(t, 1, outbox[1].Index)
+	require.Equal(t, &nonces[2], outbox[1].Nonce)
+}
+
+func TestSrExecuteOutboxMessage_ParseUnknownCommitment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rollups := mock_smart_rollup.NewMockRepository(ctrl)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+
+	rollups.EXPECT().Commitment(gomock.Any(), srRollup, srCommitment).Return(smartrollup.Commitment{}, sql.ErrNoRows).Times(1)
+	storage.EXPECT().IsRecordNotFound(sql.ErrNoRows).Return(true).Times(1)
+
+	address := srRollup
+	data := noderpc.Operation{
+		Kind:               consts.SrExecuteOutboxMessage,
+		Source:             srSource,
+		Rollup:             &address,
+		CementedCommitment: srCommitment,
+		OutputProof:        srOutboxProof(t),
+		Metadata:           appliedResult(noderpc.OperationResult{}),
+	}
+
+	store := parsers.NewTestStore()
+	require.NoError(t, NewSrExecuteOutboxMessage(newSrTestParams(&config.Context{
+		SmartRollups: rollups,
+		Storage:      storage,
+	})).Parse(context.Background(), data, store))
+	require.Len(t, store.Operations, 1)
+
+	// state is taken from the context proof
+	outbox := store.Operations[0].SmartRollupOutbox
+	require.Len(t, outbox, 2)
+	require.EqualValues(t, 90, outbox[0].OutboxLevel)
+	require.EqualValues(t, 1, outbox[0].MessageIndex)
+	require.Nil(t, outbox[0].Nonce)
+}
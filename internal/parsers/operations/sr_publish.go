@@ -0,0 +1,53 @@
+package operations
+
+import (
+	"context"
+
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/pkg/errors"
+)
+
+// SrPublish -
+type SrPublish struct {
+	*ParseParams
+}
+
+// NewSrPublish -
+func NewSrPublish(params *ParseParams) SrPublish {
+	return SrPublish{params}
+}
+
+// Parse -
+func (p SrPublish) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	operation := newSrOperation(p.ParseParams, data, store)
+
+	if operation.IsApplied() && data.Rollup != nil {
+		var content noderpc.Commitment
+		if err := json.Unmarshal(data.Commitment, &content); err != nil {
+			return errors.Wrap(err, "published commitment decoding")
+		}
+
+		commitment := &smartrollup.Commitment{
+			Timestamp:       operation.Timestamp,
+			Level:           operation.Level,
+			Action:          smartrollup.CommitmentPublish,
+			Rollup:          *data.Rollup,
+			Staker:          data.Source,
+			Predecessor:     content.Predecessor,
+			CompressedState: content.CompressedState,
+			InboxLevel:      content.InboxLevel,
+			NumberOfTicks:   content.NumberOfTicks,
+		}
+		if result := data.GetResult(); result != nil {
+			commitment.Hash = result.StakedHash
+			commitment.PublishedAtLevel = result.PublishedAtLevel
+		}
+		operation.SmartRollupCommitments = []*smartrollup.Commitment{commitment}
+	}
+
+	store.AddOperations(operation)
+	store.AddAccounts(operation.Source)
+	return nil
+}
@@ -0,0 +1,39 @@
+package operations
+
+import (
+	"context"
+
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// SrRecoverBond -
+type SrRecoverBond struct {
+	*ParseParams
+}
+
+// NewSrRecoverBond -
+func NewSrRecoverBond(params *ParseParams) SrRecoverBond {
+	return SrRecoverBond{params}
+}
+
+// Parse -
+func (p SrRecoverBond) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	operation := newSrOperation(p.ParseParams, data, store)
+
+	if operation.IsApplied() && data.Rollup != nil {
+		operation.SmartRollupRecoveries = []*smartrollup.BondRecovery{
+			{
+				Timestamp: operation.Timestamp,
+				Level:     operation.Level,
+				Rollup:    *data.Rollup,
+				Staker:    data.Staker,
+			},
+		}
+	}
+
+	store.AddOperations(operation)
+	store.AddAccounts(operation.Source)
+	return nil
+}
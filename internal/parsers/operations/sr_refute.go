@@ -0,0 +1,55 @@
+package operations
+
+import (
+	"context"
+
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// SrRefute -
+type SrRefute struct {
+	*ParseParams
+}
+
+// NewSrRefute -
+func NewSrRefute(params *ParseParams) SrRefute {
+	return SrRefute{params}
+}
+
+// Parse -
+func (p SrRefute) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	operation := newSrOperation(p.ParseParams, data, store)
+
+	if operation.IsApplied() && data.Rollup != nil && data.Refutation != nil {
+		move := &smartrollup.GameMove{
+			Timestamp:          operation.Timestamp,
+			Level:              operation.Level,
+			Rollup:             *data.Rollup,
+			Kind:               data.Refutation.Kind,
+			Player:             data.Source,
+			Opponent:           data.Opponent,
+			PlayerCommitment:   data.Refutation.PlayerCommitmentHash,
+			OpponentCommitment: data.Refutation.OpponentCommitmentHash,
+			Choice:             data.Refutation.Choice,
+			Step:               data.Refutation.Step,
+		}
+		setGameStatus(move, data.GetResult())
+		operation.SmartRollupGameMoves = []*smartrollup.GameMove{move}
+	}
+
+	store.AddOperations(operation)
+	store.AddAccounts(operation.Source)
+	return nil
+}
+
+func setGameStatus(move *smartrollup.GameMove, result *noderpc.OperationResult) {
+	move.Status = smartrollup.GameOngoing
+	if result == nil || result.GameStatus == nil {
+		return
+	}
+	move.Status = result.GameStatus.Status
+	move.Loser = result.GameStatus.Loser
+	move.Reason = result.GameStatus.Reason
+}
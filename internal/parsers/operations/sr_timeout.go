@@ -0,0 +1,41 @@
+package operations
+
+import (
+	"context"
+
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// SrTimeout -
+type SrTimeout struct {
+	*ParseParams
+}
+
+// NewSrTimeout -
+func NewSrTimeout(params *ParseParams) SrTimeout {
+	return SrTimeout{params}
+}
+
+// Parse -
+func (p SrTimeout) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	operation := newSrOperation(p.ParseParams, data, store)
+
+	if operation.IsApplied() && data.Rollup != nil && data.Stakers != nil {
+		move := &smartrollup.GameMove{
+			Timestamp: operation.Timestamp,
+			Level:     operation.Level,
+			Rollup:    *data.Rollup,
+			Kind:      smartrollup.MoveTimeout,
+			Player:    data.Stakers.Alice,
+			Opponent:  data.Stakers.Bob,
+		}
+		setGameStatus(move, data.GetResult())
+		operation.SmartRollupGameMoves = []*smartrollup.GameMove{move}
+	}
+
+	store.AddOperations(operation)
+	store.AddAccounts(operation.Source)
+	return nil
+}
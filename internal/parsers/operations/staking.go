@@ -0,0 +1,92 @@
+package operations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	modelsTypes "github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+)
+
+// Staking - parser of staking pseudo-operations: `stake`, `unstake`, `finalize_unstake` and `set_delegate_parameters`.
+// They are transactions from implicit account to itself which are called pseudo-entrypoints.
+type Staking struct {
+	*ParseParams
+}
+
+// NewStaking -
+func NewStaking(params *ParseParams) Staking {
+	return Staking{params}
+}
+
+// Parse -
+func (p Staking) Parse(ctx context.Context, data noderpc.Operation, store parsers.Store) error {
+	source := account.Account{
+		Address:         data.Source,
+		Type:            modelsTypes.NewAccountType(data.Source),
+		Level:           p.head.Level,
+		OperationsCount: 1,
+		LastAction:      p.head.Timestamp,
+	}
+
+	entrypoint := stakingEntrypoint(data)
+
+	staking := operation.Operation{
+		Hash:         p.hash,
+		ProtocolID:   p.protocol.ID,
+		Level:        p.head.Level,
+		Timestamp:    p.head.Timestamp,
+		Kind:         modelsTypes.NewOperationKind(entrypoint),
+		Initiator:    source,
+		Source:       source,
+		Destination:  source,
+		Fee:          data.Fee,
+		Counter:      data.Counter,
+		GasLimit:     data.GasLimit,
+		StorageLimit: data.StorageLimit,
+		Parameters:   data.Parameters,
+		ContentIndex: p.contentIdx,
+	}
+	if data.Amount != nil {
+		staking.Amount = *data.Amount
+	}
+	if err := staking.Entrypoint.Set(entrypoint); err != nil {
+		return err
+	}
+
+	if p.main == nil {
+		p.main = &staking
+	}
+
+	parseOperationResult(data, &staking, store)
+	staking.SetBurned(*p.protocol.Constants)
+	p.stackTrace.Add(staking)
+
+	store.AddOperations(&staking)
+	store.AddAccounts(staking.Source)
+	return nil
+}
+
+// isStaking - returns true if the operation is a staking pseudo-operation
+func isStaking(kind, source, destination, entrypoint string) bool {
+	if kind != consts.Transaction || source != destination || !isImplicit(source) {
+		return false
+	}
+	return modelsTypes.NewOperationKind(entrypoint).IsStaking()
+}
+
+func isImplicit(address string) bool {
+	return len(address) == 36 && strings.HasPrefix(address, "tz")
+}
+
+func stakingEntrypoint(data noderpc.Operation) string {
+	if len(data.Parameters) == 0 {
+		return consts.DefaultEntrypoint
+	}
+	return types.NewParameters(data.Parameters).Entrypoint
+}
@@ -0,0 +1,155 @@
+package operations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncreasePaidStorage_Parse(t *testing.T) {
+	destination := "KT1VG2WtYdSWz5E7chTeAdDPZNy2MpP8pTfL"
+	amount := int64(10)
+	data := noderpc.Operation{
+		Kind:        consts.IncreasePaidStorage,
+		Source:      srSource,
+		Destination: &destination,
+		Amount:      &amount,
+		Metadata:    appliedResult(noderpc.OperationResult{}),
+	}
+
+	params := newSrTestParams(&config.Context{})
+	params.protocol.Constants.CostPerByte = 250
+
+	store := parsers.NewTestStore()
+	require.NoError(t, NewContent(params).Parse(context.Background(), data, store))
+	require.Len(t, store.Operations, 1)
+
+	operation := store.Operations[0]
+	require.Equal(t, types.OperationKindIncreasePaidStorage, operation.Kind)
+	require.Equal(t, destination, operation.Destination.Address)
+	require.EqualValues(t, 10, operation.PaidStorageSizeDiff)
+	require.EqualValues(t, 2500, operation.Burned)
+	require.Zero(t, operation.Amount)
+}
+
+func TestStaking_Parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		parameters string
+		want       types.OperationKind
+	}{
+		{
+			name:       "stake",
+			source:     srSource,
+			parameters: `{"entrypoint":"stake","value":{"prim":"Unit"}}`,
+			want:       types.OperationKindStake,
+		}, {
+			name:       "unstake",
+			source:     srSource,
+			parameters: `{"entrypoint":"unstake","value":{"prim":"Unit"}}`,
+			want:       types.OperationKindUnstake,
+		}, {
+			name:       "finalize_unstake",
+			source:     srSource,
+			parameters: `{"entrypoint":"finalize_unstake","value":{"prim":"Unit"}}`,
+			want:       types.OperationKindFinalizeUnstake,
+		}, {
+			name:       "set_delegate_parameters",
+			source:     srSource,
+			parameters: `{"entrypoint":"set_delegate_parameters","value":{"prim":"Pair","args":[{"int":"5000000"},{"prim":"Pair","args":[{"int":"1000000000"},{"prim":"Unit"}]}]}}`,
+			want:       types.OperationKindSetDelegateParameters,
+		}, {
+			name:   "self transfer",
+			source: srSource,
+			want:   types.OperationKindTransaction,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destination := tt.source
+			amount := int64(1000000)
+			data := noderpc.Operation{
+				Kind:        consts.Transaction,
+				Source:      tt.source,
+				Destination: &destination,
+				Amount:      &amount,
+				Metadata:    appliedResult(noderpc.OperationResult{}),
+			}
+			if tt.parameters != "" {
+				data.Parameters = []byte(tt.parameters)
+			}
+
+			store := parsers.NewTestStore()
+			require.NoError(t, NewContent(newSrTestParams(&config.Context{})).Parse(context.Background(), data, store))
+			require.Len(t, store.Operations, 1)
+
+			operation := store.Operations[0]
+			require.Equal(t, tt.want, operation.Kind)
+			require.Equal(t, tt.source, operation.Destination.Address)
+			require.EqualValues(t, amount, operation.Amount)
+			if tt.want.IsStaking() {
+				require.Equal(t, tt.want.String(), operation.Entrypoint.String())
+			}
+		})
+	}
+}
+
+func TestNeedParse_Staking(t *testing.T) {
+	destination := srSource
+	other := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	tests := []struct {
+		name string
+		op   noderpc.LightOperation
+		want bool
+	}{
+		{
+			name: "stake",
+			op: noderpc.LightOperation{
+				Kind:        consts.Transaction,
+				Source:      srSource,
+				Destination: &destination,
+				Parameters:  &noderpc.LightParameters{Entrypoint: consts.Stake},
+			},
+			want: true,
+		}, {
+			name: "stake to other account",
+			op: noderpc.LightOperation{
+				Kind:        consts.Transaction,
+				Source:      srSource,
+				Destination: &other,
+				Parameters:  &noderpc.LightParameters{Entrypoint: consts.Stake},
+			},
+			want: false,
+		}, {
+			name: "self transfer",
+			op: noderpc.LightOperation{
+				Kind:        consts.Transaction,
+				Source:      srSource,
+				Destination: &destination,
+			},
+			want: false,
+		}, {
+			name: "increase paid storage",
+			op: noderpc.LightOperation{
+				Kind:   consts.IncreasePaidStorage,
+				Source: srSource,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := noderpc.LightOperationGroup{
+				Contents: []noderpc.LightOperation{tt.op},
+			}
+			require.Equal(t, tt.want, NeedParse(group))
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/contract/trees"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	modelsTypes "github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/parsers"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	mintEntrypoint = "mint"
+	burnEntrypoint = "burn"
+)
+
+type ledgerType int
+
+const (
+	ledgerUnknown ledgerType = iota
+	ledgerSingleAsset
+	ledgerNftAsset
+	ledgerMultiAsset
+)
+
+// TokenTransferParser - decodes FA1.2 and FA2 token movements of the applied transaction
+// from `transfer`, `mint` and `burn` calls and from ledger big map diffs.
+type TokenTransferParser struct {
+	ctx *config.Context
+}
+
+// NewTokenTransferParser -
+func NewTokenTransferParser(ctx *config.Context) TokenTransferParser {
+	return TokenTransferParser{ctx}
+}
+
+// Parse -
+func (p TokenTransferParser) Parse(ctx context.Context, tx *operation.Operation, store parsers.Store) error {
+	if !tx.IsApplied() || tx.AST == nil {
+		return nil
+	}
+	if !tx.Tags.Has(modelsTypes.FA12Tag) && !tx.Tags.Has(modelsTypes.FA2Tag) && !tx.Tags.Has(modelsTypes.LedgerTag) {
+		return nil
+	}
+
+	entrypoint := tx.Entrypoint.String()
+	if entrypoint == consts.TransferEntrypoint {
+		transfers, err := p.fromTransferParameters(tx)
+		if err != nil {
+			return err
+		}
+		if len(transfers) > 0 {
+			tx.TokenTransfers = transfers
+			return nil
+		}
+	}
+
+	transfers, err := p.fromLedger(ctx, tx, store)
+	if err != nil {
+		return err
+	}
+	if len(transfers) > 0 {
+		tx.TokenTransfers = transfers
+		return nil
+	}
+
+	if entrypoint == mintEntrypoint || entrypoint == burnEntrypoint {
+		transfers, err := p.fromMintOrBurnParameters(tx)
+		if err != nil {
+			return err
+		}
+		tx.TokenTransfers = transfers
+	}
+	return nil
+}
+
+func (p TokenTransferParser) unwrapParameters(tx *operation.Operation) (*base.Node, error) {
+	if len(tx.Parameters) == 0 {
+		return nil, nil
+	}
+	param, err := tx.AST.ParameterType()
+	if err != nil {
+		return nil, err
+	}
+	subTree, err := param.FromParameters(types.NewParameters(tx.Parameters))
+	if err != nil {
+		return nil, err
+	}
+	node, _ := subTree.UnwrapAndGetEntrypointName()
+	if node == nil {
+		return nil, nil
+	}
+	return node.ToBaseNode(false)
+}
+
+func (p TokenTransferParser) fromTransferParameters(tx *operation.Operation) ([]*token.Transfer, error) {
+	value, err := p.unwrapParameters(tx)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	switch {
+	case tx.Tags.Has(modelsTypes.FA2Tag):
+		return p.fa2Transfers(tx, value), nil
+	case tx.Tags.Has(modelsTypes.FA12Tag):
+		return p.fa12Transfer(tx, value), nil
+	default:
+		return nil, nil
+	}
+}
+
+// fa12Transfer - (pair (address :from) (pair (address :to) (nat :value)))
+func (p TokenTransferParser) fa12Transfer(tx *operation.Operation, value *base.Node) []*token.Transfer {
+	args := flatPair(value)
+	if len(args) != 3 {
+		return nil
+	}
+	from, ok := addressValue(args[0])
+	if !ok {
+		return nil
+	}
+	to, ok := addressValue(args[1])
+	if !ok {
+		return nil
+	}
+	amount, ok := intValue(args[2])
+	if !ok {
+		return nil
+	}
+	return appendTransfer(nil, p.newTransfer(tx, from, to, decimal.Zero, amount))
+}
+
+// fa2Transfers - (list (pair (address %from_) (list %txs (pair (address %to_) (pair (nat %token_id) (nat %amount))))))
+func (p TokenTransferParser) fa2Transfers(tx *operation.Operation, value *base.Node) []*token.Transfer {
+	if value.Prim != consts.PrimArray {
+		return nil
+	}
+
+	transfers := make([]*token.Transfer, 0)
+	for _, item := range value.Args {
+		args := flatPair(item)
+		if len(args) != 2 || args[1].Prim != consts.PrimArray {
+			return nil
+		}
+		from, ok := addressValue(args[0])
+		if !ok {
+			return nil
+		}
+		for _, dst := range args[1].Args {
+			txArgs := flatPair(dst)
+			if len(txArgs) != 3 {
+				return nil
+			}
+			to, ok := addressValue(txArgs[0])
+			if !ok {
+				return nil
+			}
+			tokenID, ok := intValue(txArgs[1])
+			if !ok {
+				return nil
+			}
+			amount, ok := intValue(txArgs[2])
+			if !ok {
+				return nil
+			}
+			transfers = appendTransfer(transfers, p.newTransfer(tx, from, to, tokenID, amount))
+		}
+	}
+	return transfers
+}
+
+// fromMintOrBurnParameters - (pair (address) (nat)) which is the most common shape of FA1.2 `mint` and `burn`
+func (p TokenTransferParser) fromMintOrBurnParameters(tx *operation.Operation) ([]*token.Transfer, error) {
+	value, err := p.unwrapParameters(tx)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	args := flatPair(value)
+	if len(args) != 2 {
+		return nil, nil
+	}
+	holder, ok := addressValue(args[0])
+	if !ok {
+		return nil, nil
+	}
+	amount, ok := intValue(args[1])
+	if !ok {
+		return nil, nil
+	}
+
+	if tx.Entrypoint.String() == mintEntrypoint {
+		return appendTransfer(nil, p.newTransfer(tx, "", holder, decimal.Zero, amount)), nil
+	}
+	return appendTransfer(nil, p.newTransfer(tx, holder, "", decimal.Zero, amount)), nil
+}
+
+func (p TokenTransferParser) fromLedger(ctx context.Context, tx *operation.Operation, store parsers.Store) ([]*token.Transfer, error) {
+	if len(tx.BigMapDiffs) == 0 || len(tx.DeffatedStorage) == 0 {
+		return nil, nil
+	}
+
+	storage, err := tx.AST.StorageType()
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SettleFromBytes(tx.DeffatedStorage); err != nil {
+		return nil, err
+	}
+
+	node := storage.FindByName("ledger", false)
+	if node == nil {
+		return nil, nil
+	}
+	bigMap, ok := node.(*ast.BigMap)
+	if !ok || bigMap.Ptr == nil {
+		return nil, nil
+	}
+
+	var typ ledgerType
+	switch {
+	case node.EqualType(trees.NewNftLedgerSingleAsset.Nodes[0]):
+		typ = ledgerSingleAsset
+	case node.EqualType(trees.NewNftLedgerAsset.Nodes[0]):
+		typ = ledgerNftAsset
+	case node.EqualType(trees.NewNftLedgerMultiAsset.Nodes[0]):
+		typ = ledgerMultiAsset
+	default:
+		return nil, nil
+	}
+
+	transfers := make([]*token.Transfer, 0)
+	for _, diff := range tx.BigMapDiffs {
+		if diff.Ptr != *bigMap.Ptr {
+			continue
+		}
+
+		prev, err := p.previousValue(ctx, tx, diff, store)
+		if err != nil {
+			return nil, err
+		}
+
+		var key base.Node
+		if err := json.Unmarshal(diff.KeyBytes(), &key); err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case ledgerSingleAsset, ledgerMultiAsset:
+			holderNode, tokenID := &key, decimal.Zero
+			if typ == ledgerMultiAsset {
+				args := flatPair(&key)
+				if len(args) != 2 {
+					continue
+				}
+				holderNode = args[0]
+				id, ok := intValue(args[1])
+				if !ok {
+					continue
+				}
+				tokenID = id
+			}
+			holder, ok := addressValue(holderNode)
+			if !ok {
+				continue
+			}
+
+			newBalance, err := ledgerBalance(diff.ValueBytes())
+			if err != nil {
+				return nil, err
+			}
+			oldBalance, err := ledgerBalance(prev)
+			if err != nil {
+				return nil, err
+			}
+
+			delta := newBalance.Sub(oldBalance)
+			switch delta.Sign() {
+			case 1:
+				transfers = appendTransfer(transfers, p.newTransfer(tx, "", holder, tokenID, delta))
+			case -1:
+				transfers = appendTransfer(transfers, p.newTransfer(tx, holder, "", tokenID, delta.Neg()))
+			}
+		case ledgerNftAsset:
+			tokenID, ok := intValue(&key)
+			if !ok {
+				continue
+			}
+			newOwner, err := ledgerOwner(diff.ValueBytes())
+			if err != nil {
+				return nil, err
+			}
+			oldOwner, err := ledgerOwner(prev)
+			if err != nil {
+				return nil, err
+			}
+			if newOwner != oldOwner {
+				transfers = appendTransfer(transfers, p.newTransfer(tx, oldOwner, newOwner, tokenID, decimal.NewFromInt(1)))
+			}
+		}
+	}
+
+	return mergeLedgerTransfers(transfers), nil
+}
+
+// previousValue - returns value of the big map key before the operation: it is looked up in the operations of current block at first and in the database then.
+func (p TokenTransferParser) previousValue(ctx context.Context, tx *operation.Operation, diff *bigmapdiff.BigMapDiff, store parsers.Store) ([]byte, error) {
+	operations := store.ListOperations()
+	for i := len(operations) - 1; i >= 0; i-- {
+		if operations[i] == tx {
+			continue
+		}
+		for j := len(operations[i].BigMapDiffs) - 1; j >= 0; j-- {
+			prev := operations[i].BigMapDiffs[j]
+			if prev.Ptr == diff.Ptr && prev.KeyHash == diff.KeyHash {
+				return prev.ValueBytes(), nil
+			}
+		}
+	}
+
+	state, err := p.ctx.BigMapDiffs.Current(ctx, diff.KeyHash, diff.Ptr)
+	if err != nil {
+		if p.ctx.Storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if state.Removed {
+		return nil, nil
+	}
+	return state.Value, nil
+}
+
+func (p TokenTransferParser) newTransfer(tx *operation.Operation, from, to string, tokenID, amount decimal.Decimal) *token.Transfer {
+	return &token.Transfer{
+		Timestamp: tx.Timestamp,
+		Level:     tx.Level,
+		Contract:  tx.Destination.Address,
+		From:      from,
+		To:        to,
+		TokenId:   tokenID,
+		Amount:    amount,
+	}
+}
+
+func appendTransfer(transfers []*token.Transfer, transfer *token.Transfer) []*token.Transfer {
+	if transfer.Amount.IsZero() || (transfer.From == "" && transfer.To == "") {
+		return transfers
+	}
+	return append(transfers, transfer)
+}
+
+// mergeLedgerTransfers - joins the single burn and the single mint of the same token and amount into one transfer
+func mergeLedgerTransfers(transfers []*token.Transfer) []*token.Transfer {
+	if len(transfers) != 2 {
+		return transfers
+	}
+	burn, mint := transfers[0], transfers[1]
+	if burn.To != "" {
+		burn, mint = mint, burn
+	}
+	if burn.To != "" || burn.From == "" || mint.From != "" || mint.To == "" {
+		return transfers
+	}
+	if !burn.TokenId.Equal(mint.TokenId) || !burn.Amount.Equal(mint.Amount) {
+		return transfers
+	}
+	burn.To = mint.To
+	return []*token.Transfer{burn}
+}
+
+func flatPair(node *base.Node) []*base.Node {
+	if node == nil || node.Prim != consts.Pair || len(node.Args) < 2 {
+		return []*base.Node{node}
+	}
+	args := make([]*base.Node, 0, len(node.Args)+1)
+	args = append(args, node.Args[:len(node.Args)-1]...)
+	return append(args, flatPair(node.Args[len(node.Args)-1])...)
+}
+
+func addressValue(node *base.Node) (string, bool) {
+	switch {
+	case node == nil:
+		return "", false
+	case node.StringValue != nil:
+		return *node.StringValue, true
+	case node.BytesValue != nil:
+		address, err := forge.UnforgeContract(*node.BytesValue)
+		if err != nil {
+			return "", false
+		}
+		return address, true
+	default:
+		return "", false
+	}
+}
+
+func intValue(node *base.Node) (decimal.Decimal, bool) {
+	if node == nil || node.IntValue == nil {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromBigInt(node.IntValue.Int, 0), true
+}
+
+func ledgerBalance(value []byte) (decimal.Decimal, error) {
+	if len(value) == 0 {
+		return decimal.Zero, nil
+	}
+	var node base.Node
+	if err := json.Unmarshal(value, &node); err != nil {
+		return decimal.Zero, err
+	}
+	balance, _ := intValue(&node)
+	return balance, nil
+}
+
+func ledgerOwner(value []byte) (string, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+	var node base.Node
+	if err := json.Unmarshal(value, &node); err != nil {
+		return "", err
+	}
+	owner, _ := addressValue(&node)
+	return owner, nil
+}
@@ -0,0 +1,142 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTransferParser_fa12Transfer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []token.Transfer
+	}{
+		{
+			name:  "nested pair",
+			value: `{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},{"prim":"Pair","args":[{"string":"tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA"},{"int":"100"}]}]}`,
+			want: []token.Transfer{
+				{From: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", To: "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA", TokenId: decimal.Zero, Amount: decimal.NewFromInt(100)},
+			},
+		}, {
+			name:  "comb pair with bytes address",
+			value: `{"prim":"Pair","args":[{"bytes":"0000a7848de3b1fce76a7ffce2c7ce40e46be33aed7c"},{"string":"tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA"},{"int":"5"}]}`,
+			want: []token.Transfer{
+				{From: "tz1aunLBk1S3irrizh2pVqFfwV6iqSiFNJtN", To: "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA", TokenId: decimal.Zero, Amount: decimal.NewFromInt(5)},
+			},
+		}, {
+			name:  "zero amount",
+			value: `{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},{"string":"tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA"},{"int":"0"}]}`,
+			want:  []token.Transfer{},
+		}, {
+			name:  "invalid",
+			value: `{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},{"int":"0"}]}`,
+			want:  []token.Transfer{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value base.Node
+			require.NoError(t, json.UnmarshalFromString(tt.value, &value))
+
+			tx := &operation.Operation{
+				Destination: account.Account{Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+			}
+			got := TokenTransferParser{}.fa12Transfer(tx, &value)
+			requireTransfers(t, tt.want, got)
+		})
+	}
+}
+
+func TestTokenTransferParser_fa2Transfers(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []token.Transfer
+	}{
+		{
+			name:  "batch",
+			value: `[{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},[{"prim":"Pair","args":[{"string":"tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA"},{"prim":"Pair","args":[{"int":"1"},{"int":"10"}]}]},{"prim":"Pair","args":[{"string":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},{"int":"2"},{"int":"20"}]}]]}]`,
+			want: []token.Transfer{
+				{From: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", To: "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA", TokenId: decimal.NewFromInt(1), Amount: decimal.NewFromInt(10)},
+				{From: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", To: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", TokenId: decimal.NewFromInt(2), Amount: decimal.NewFromInt(20)},
+			},
+		}, {
+			name:  "not a list",
+			value: `{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},[]]}`,
+			want:  []token.Transfer{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value base.Node
+			require.NoError(t, json.UnmarshalFromString(tt.value, &value))
+
+			tx := &operation.Operation{
+				Destination: account.Account{Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+			}
+			got := TokenTransferParser{}.fa2Transfers(tx, &value)
+			requireTransfers(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mergeLedgerTransfers(t *testing.T) {
+	tests := []struct {
+		name      string
+		transfers []*token.Transfer
+		want      []token.Transfer
+	}{
+		{
+			name: "burn and mint of the same amount",
+			transfers: []*token.Transfer{
+				{To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+				{From: "tz1", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+			},
+			want: []token.Transfer{
+				{From: "tz1", To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+			},
+		}, {
+			name: "different amounts",
+			transfers: []*token.Transfer{
+				{From: "tz1", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+				{To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(9)},
+			},
+			want: []token.Transfer{
+				{From: "tz1", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+				{To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(9)},
+			},
+		}, {
+			name: "two mints",
+			transfers: []*token.Transfer{
+				{To: "tz1", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+				{To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+			},
+			want: []token.Transfer{
+				{To: "tz1", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+				{To: "tz2", TokenId: decimal.Zero, Amount: decimal.NewFromInt(10)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeLedgerTransfers(tt.transfers)
+			requireTransfers(t, tt.want, got)
+		})
+	}
+}
+
+func requireTransfers(t *testing.T, want []token.Transfer, got []*token.Transfer) {
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.Equal(t, want[i].From, got[i].From)
+		require.Equal(t, want[i].To, got[i].To)
+		require.True(t, want[i].TokenId.Equal(got[i].TokenId), "token_id")
+		require.True(t, want[i].Amount.Equal(got[i].Amount), "amount")
+	}
+}
@@ -143,6 +143,10 @@ func (p Transaction) parseContractParams(ctx context.Context, data noderpc.Opera
 			}
 		}
 		if tx.Script == nil {
+			// contracts which aren't matched by the filter of selective indexing are not indexed, so their calls are saved without parameters parsing
+			if p.filter != nil && p.ctx.Storage.IsRecordNotFound(err) {
+				return nil
+			}
 			return err
 		}
 	} else {
@@ -190,6 +194,14 @@ func (p Transaction) appliedHandler(ctx context.Context, item noderpc.Operation,
 		return err
 	}
 
+	if err := NewTokenTransferParser(p.ctx).Parse(ctx, tx, store); err != nil {
+		return err
+	}
+
+	if err := p.parseMetadata(ctx, tx, store); err != nil {
+		return err
+	}
+
 	return NewMigration(p.ctx.Contracts).Parse(ctx, item, tx, p.protocol.Hash, store)
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
@@ -26,7 +27,10 @@ type Store interface {
 	AddTicketBalances(balances ...ticket.Balance)
 	ListContracts() []*contract.Contract
 	ListOperations() []*operation.Operation
+	ListSmartRollups() []*smartrollup.SmartRollup
 	AddAccounts(accounts ...account.Account)
+	AddContractMetadata(metadata ...*metadata.ContractMetadata)
+	AddTokenMetadata(metadata ...*metadata.TokenMetadata)
 	Save(ctx context.Context) error
 	SetBlock(block *block.Block)
 }
@@ -43,6 +47,8 @@ type TestStore struct {
 	Tickets         map[string]*ticket.Ticket
 	TicketBalances  map[string]*ticket.Balance
 	Accounts        map[string]*account.Account
+	ContractMeta    []*metadata.ContractMetadata
+	TokenMeta       []*metadata.TokenMetadata
 }
 
 // NewTestStore -
@@ -57,6 +63,8 @@ func NewTestStore() *TestStore {
 		Tickets:         make(map[string]*ticket.Ticket, 0),
 		TicketBalances:  make(map[string]*ticket.Balance, 0),
 		Accounts:        make(map[string]*account.Account),
+		ContractMeta:    make([]*metadata.ContractMetadata, 0),
+		TokenMeta:       make([]*metadata.TokenMetadata, 0),
 	}
 }
 
@@ -128,6 +136,16 @@ func (store *TestStore) AddTicketBalances(balance ...ticket.Balance) {
 	}
 }
 
+// AddContractMetadata -
+func (store *TestStore) AddContractMetadata(metadata ...*metadata.ContractMetadata) {
+	store.ContractMeta = append(store.ContractMeta, metadata...)
+}
+
+// AddTokenMetadata -
+func (store *TestStore) AddTokenMetadata(metadata ...*metadata.TokenMetadata) {
+	store.TokenMeta = append(store.TokenMeta, metadata...)
+}
+
 // ListContracts -
 func (store *TestStore) ListContracts() []*contract.Contract {
 	return store.Contracts
@@ -138,6 +156,11 @@ func (store *TestStore) ListOperations() []*operation.Operation {
 	return store.Operations
 }
 
+// ListSmartRollups -
+func (store *TestStore) ListSmartRollups() []*smartrollup.SmartRollup {
+	return store.SmartRollups
+}
+
 // Save -
 func (store *TestStore) Save(ctx context.Context) error {
 	return nil
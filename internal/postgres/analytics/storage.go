@@ -0,0 +1,139 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/analytics"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/uptrace/bun"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+func contractStatsView(period analytics.Period) string {
+	if period == analytics.PeriodHour {
+		return core.ViewContractStatsHourly
+	}
+	return core.ViewContractStatsDaily
+}
+
+func contractTotalStatsView(period analytics.Period) string {
+	if period == analytics.PeriodHour {
+		return core.ViewContractTotalHourly
+	}
+	return core.ViewContractTotalDaily
+}
+
+// Contract - stats of the whole contract are received from the separate aggregate because percentiles of entrypoints can't be merged
+func (storage *Storage) Contract(ctx context.Context, req analytics.ContractRequest) (result []analytics.ContractStats, err error) {
+	view := contractTotalStatsView(req.Period)
+	if req.ByEntrypoint || req.Entrypoint != "" {
+		view = contractStatsView(req.Period)
+	}
+
+	query := storage.DB.NewSelect().
+		TableExpr("?", bun.Ident(view)).
+		Where("destination_id = ?", req.AccountID)
+
+	if !req.From.IsZero() {
+		query.Where("bucket >= ?", req.From)
+	}
+	if !req.To.IsZero() {
+		query.Where("bucket < ?", req.To)
+	}
+	if req.Entrypoint != "" {
+		query.Where("entrypoint = ?", req.Entrypoint)
+	}
+
+	if req.ByEntrypoint {
+		query.
+			ColumnExpr("bucket, coalesce(entrypoint, '') AS entrypoint").
+			Order("bucket", "entrypoint")
+	} else {
+		query.
+			ColumnExpr("bucket").
+			Order("bucket")
+	}
+
+	err = query.
+		ColumnExpr("operations, calls, failed_calls, total_gas, avg_gas, p95_gas").
+		ColumnExpr("burned, fee, paid_storage_size_diff, storage_size").
+		Scan(ctx, &result)
+	return
+}
+
+func networkStatsView(period analytics.Period) string {
+	if period == analytics.PeriodHour {
+		return core.ViewNetworkStatsHourly
+	}
+	return core.ViewNetworkStatsDaily
+}
+
+// Network -
+func (storage *Storage) Network(ctx context.Context, req analytics.NetworkRequest) (result []analytics.NetworkStats, err error) {
+	query := storage.DB.NewSelect().
+		TableExpr("?", bun.Ident(networkStatsView(req.Period))).
+		Column("bucket", "operations", "calls", "unique_senders", "new_contracts", "events").
+		Column("ticket_transfers", "ticket_updates", "global_constants", "smart_rollups")
+
+	if !req.From.IsZero() {
+		query.Where("bucket >= ?", req.From)
+	}
+	if !req.To.IsZero() {
+		query.Where("bucket < ?", req.To)
+	}
+
+	err = query.Order("bucket").Scan(ctx, &result)
+	return
+}
+
+// topContractsHourlyWindow - maximum window which is ranked by hourly buckets. Wider windows are ranked by daily ones.
+const topContractsHourlyWindow = time.Hour * 24 * 7
+
+// TopContracts -
+func (storage *Storage) TopContracts(ctx context.Context, req analytics.TopRequest) (result []analytics.TopContract, err error) {
+	period := analytics.PeriodDay
+	if !req.From.IsZero() && req.To.Sub(req.From) <= topContractsHourlyWindow {
+		period = analytics.PeriodHour
+	}
+
+	query := storage.DB.NewSelect().
+		TableExpr("? AS stats", bun.Ident(contractTotalStatsView(period))).
+		Join("JOIN accounts ON accounts.id = stats.destination_id").
+		ColumnExpr("stats.destination_id AS account_id, accounts.address").
+		ColumnExpr("sum(stats.calls) AS calls, sum(stats.failed_calls) AS failed_calls").
+		ColumnExpr("sum(stats.total_gas) AS total_gas, sum(stats.burned) AS burned").
+		Where("accounts.type = ?", types.AccountTypeContract)
+
+	if !req.From.IsZero() {
+		query.Where("stats.bucket >= ?", req.From)
+	}
+	if !req.To.IsZero() {
+		query.Where("stats.bucket < ?", req.To)
+	}
+
+	query.Group("stats.destination_id", "accounts.address")
+
+	switch req.By {
+	case analytics.TopByGas:
+		query.OrderExpr("total_gas desc")
+	default:
+		query.OrderExpr("calls desc")
+	}
+
+	err = query.
+		OrderExpr("account_id").
+		Limit(storage.GetPageSize(req.Limit)).
+		Scan(ctx, &result)
+	return
+}
@@ -61,3 +61,11 @@ func (storage *Storage) buildGetContextForState(ctx bigmapdiff.GetContext) *bun.
 
 	return query.Order("id desc")
 }
+
+// pointCondition - returns condition selecting diffs made at or before the point
+func pointCondition(point bigmapdiff.Point) (string, []any) {
+	if point.OperationID > 0 {
+		return "(level < ? OR (level = ? AND operation_id <= ?))", []any{point.Level, point.Level, point.OperationID}
+	}
+	return "level <= ?", []any{point.Level}
+}
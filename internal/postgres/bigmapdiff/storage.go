@@ -42,6 +42,55 @@ func (storage *Storage) GetForAddress(ctx context.Context, address string) (resp
 	return
 }
 
+// StatesAtLevel -
+func (storage *Storage) StatesAtLevel(ctx context.Context, contract string, level int64) (response []bigmapdiff.BigMapDiff, err error) {
+	query := storage.DB.NewSelect().
+		Model((*bigmapdiff.BigMapDiff)(nil)).
+		DistinctOn("ptr, key_hash").
+		Where("level <= ?", level).
+		OrderExpr("ptr, key_hash, level desc, id desc")
+
+	err = core.Contract(query, contract).Scan(ctx, &response)
+	return
+}
+
+// Changes -
+func (storage *Storage) Changes(ctx context.Context, contract string, from, to bigmapdiff.Point) (before, after []bigmapdiff.BigMapDiff, err error) {
+	fromCond, fromArgs := pointCondition(from)
+	toCond, toArgs := pointCondition(to)
+
+	if err = storage.DB.NewSelect().
+		Model((*bigmapdiff.BigMapDiff)(nil)).
+		DistinctOn("ptr, key_hash").
+		Where("contract = ?", contract).
+		Where(toCond, toArgs...).
+		Where("NOT "+fromCond, fromArgs...).
+		OrderExpr("ptr, key_hash, level desc, id desc").
+		Scan(ctx, &after); err != nil {
+		return
+	}
+	if len(after) == 0 {
+		return
+	}
+
+	keys := storage.DB.NewSelect().
+		Model((*bigmapdiff.BigMapDiff)(nil)).
+		ColumnExpr("ptr, key_hash").
+		Where("contract = ?", contract).
+		Where(toCond, toArgs...).
+		Where("NOT "+fromCond, fromArgs...)
+
+	err = storage.DB.NewSelect().
+		Model((*bigmapdiff.BigMapDiff)(nil)).
+		DistinctOn("ptr, key_hash").
+		Where("contract = ?", contract).
+		Where(fromCond, fromArgs...).
+		Where("(ptr, key_hash) IN (?)", keys).
+		OrderExpr("ptr, key_hash, level desc, id desc").
+		Scan(ctx, &before)
+	return
+}
+
 // Count -
 func (storage *Storage) Count(ctx context.Context, ptr int64) (int, error) {
 	return storage.DB.NewSelect().
@@ -86,6 +135,19 @@ func (storage *Storage) GetForOperation(ctx context.Context, id int64) (response
 	return
 }
 
+// GetForOperations -
+func (storage *Storage) GetForOperations(ctx context.Context, ids []int64) (response []bigmapdiff.BigMapDiff, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	err = storage.DB.NewSelect().
+		Model(&response).
+		Where("operation_id IN (?)", bun.In(ids)).
+		Order("id asc").
+		Scan(ctx)
+	return
+}
+
 // GetByPtrAndKeyHash -
 func (storage *Storage) GetByPtrAndKeyHash(ctx context.Context, ptr int64, keyHash string, size, offset int64) ([]bigmapdiff.BigMapDiff, int64, error) {
 	if ptr < 0 {
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/uptrace/bun"
+)
+
+// continuous aggregates
+const (
+	ViewContractStatsHourly = "contract_stats_hourly"
+	ViewContractStatsDaily  = "contract_stats_daily"
+	ViewContractTotalHourly = "contract_total_stats_hourly"
+	ViewContractTotalDaily  = "contract_total_stats_daily"
+	ViewNetworkStatsHourly  = "network_stats_hourly"
+	ViewNetworkStatsDaily   = "network_stats_daily"
+)
+
+type continuousAggregate struct {
+	name        string
+	bucket      time.Duration
+	interval    string
+	startOffset string
+	schedule    string
+	query       string
+	args        []any
+
+	destinationIndex bool
+}
+
+// contractStatsQuery - aggregates transactions and paid storage increases by destination and entrypoint.
+const contractStatsQuery = `CREATE MATERIALIZED VIEW IF NOT EXISTS ?
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+SELECT
+	time_bucket(?::interval, timestamp) AS bucket,
+	destination_id,
+	entrypoint,
+	count(*) AS operations,
+	sum(CASE WHEN kind = ? THEN 1 ELSE 0 END) AS calls,
+	sum(CASE WHEN kind = ? AND status != ? THEN 1 ELSE 0 END) AS failed_calls,
+	sum(consumed_gas) AS total_gas,
+	avg(consumed_gas) AS avg_gas,
+	percentile_cont(0.95) WITHIN GROUP (ORDER BY consumed_gas) AS p95_gas,
+	sum(burned) AS burned,
+	sum(fee) AS fee,
+	sum(paid_storage_size_diff) AS paid_storage_size_diff,
+	max(storage_size) AS storage_size
+FROM operations
+WHERE kind IN (?, ?) AND destination_id > 0
+GROUP BY bucket, destination_id, entrypoint`
+
+// contractTotalStatsQuery - aggregates transactions and paid storage increases by destination. Percentiles of entrypoints can't be merged,
+// so stats of the contract are aggregated separately.
+const contractTotalStatsQuery = `CREATE MATERIALIZED VIEW IF NOT EXISTS ?
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+SELECT
+	time_bucket(?::interval, timestamp) AS bucket,
+	destination_id,
+	count(*) AS operations,
+	sum(CASE WHEN kind = ? THEN 1 ELSE 0 END) AS calls,
+	sum(CASE WHEN kind = ? AND status != ? THEN 1 ELSE 0 END) AS failed_calls,
+	sum(consumed_gas) AS total_gas,
+	avg(consumed_gas) AS avg_gas,
+	percentile_cont(0.95) WITHIN GROUP (ORDER BY consumed_gas) AS p95_gas,
+	sum(burned) AS burned,
+	sum(fee) AS fee,
+	sum(paid_storage_size_diff) AS paid_storage_size_diff,
+	max(storage_size) AS storage_size
+FROM operations
+WHERE kind IN (?, ?) AND destination_id > 0
+GROUP BY bucket, destination_id`
+
+var contractStatsArgs = []any{
+	types.OperationKindTransaction,
+	types.OperationKindTransaction, types.OperationStatusApplied,
+	types.OperationKindTransaction, types.OperationKindIncreasePaidStorage,
+}
+
+// networkStatsQuery - aggregates all operations of the network. Calls are transactions with entrypoint, i.e. calls of contracts and smart rollups.
+// Contracts, global constants and smart rollups are counted by applied operations which create them.
+const networkStatsQuery = `CREATE MATERIALIZED VIEW IF NOT EXISTS ?
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+SELECT
+	time_bucket(?::interval, timestamp) AS bucket,
+	count(*) AS operations,
+	sum(CASE WHEN kind = ? AND entrypoint IS NOT NULL THEN 1 ELSE 0 END) AS calls,
+	count(DISTINCT source_id) AS unique_senders,
+	sum(CASE WHEN kind IN (?, ?) AND status = ? THEN 1 ELSE 0 END) AS new_contracts,
+	sum(CASE WHEN kind = ? THEN 1 ELSE 0 END) AS events,
+	sum(CASE WHEN kind = ? THEN 1 ELSE 0 END) AS ticket_transfers,
+	sum(ticket_updates_count) AS ticket_updates,
+	sum(CASE WHEN kind = ? AND status = ? THEN 1 ELSE 0 END) AS global_constants,
+	sum(CASE WHEN kind = ? AND status = ? THEN 1 ELSE 0 END) AS smart_rollups
+FROM operations
+GROUP BY bucket`
+
+var networkStatsArgs = []any{
+	types.OperationKindTransaction,
+	types.OperationKindOrigination, types.OperationKindOriginationNew, types.OperationStatusApplied,
+	types.OperationKindEvent,
+	types.OperationKindTransferTicket,
+	types.OperationKindRegisterGlobalConstant, types.OperationStatusApplied,
+	types.OperationKindSrOrigination, types.OperationStatusApplied,
+}
+
+// Aggregates are real-time, so buckets which are not materialized yet are computed from operations on the fly.
+var continuousAggregates = []continuousAggregate{
+	{
+		name: ViewContractStatsHourly, bucket: time.Hour, interval: "1 hour", startOffset: "7 days", schedule: "30 minutes",
+		query: contractStatsQuery, args: contractStatsArgs, destinationIndex: true,
+	}, {
+		name: ViewContractStatsDaily, bucket: time.Hour * 24, interval: "1 day", startOffset: "30 days", schedule: "1 hour",
+		query: contractStatsQuery, args: contractStatsArgs, destinationIndex: true,
+	}, {
+		name: ViewContractTotalHourly, bucket: time.Hour, interval: "1 hour", startOffset: "7 days", schedule: "30 minutes",
+		query: contractTotalStatsQuery, args: contractStatsArgs, destinationIndex: true,
+	}, {
+		name: ViewContractTotalDaily, bucket: time.Hour * 24, interval: "1 day", startOffset: "30 days", schedule: "1 hour",
+		query: contractTotalStatsQuery, args: contractStatsArgs, destinationIndex: true,
+	}, {
+		name: ViewNetworkStatsHourly, bucket: time.Hour, interval: "1 hour", startOffset: "7 days", schedule: "30 minutes",
+		query: networkStatsQuery, args: networkStatsArgs,
+	}, {
+		name: ViewNetworkStatsDaily, bucket: time.Hour * 24, interval: "1 day", startOffset: "30 days", schedule: "1 hour",
+		query: networkStatsQuery, args: networkStatsArgs,
+	},
+}
+
+func createContinuousAggregates(ctx context.Context, db *bun.DB) error {
+	for _, agg := range continuousAggregates {
+		args := append([]any{bun.Ident(agg.name), agg.interval}, agg.args...)
+		if _, err := db.ExecContext(ctx, agg.query, args...); err != nil {
+			return err
+		}
+
+		if _, err := db.ExecContext(ctx,
+			`SELECT add_continuous_aggregate_policy(?, start_offset => ?::interval, end_offset => NULL, schedule_interval => ?::interval, if_not_exists => TRUE);`,
+			agg.name, agg.startOffset, agg.schedule,
+		); err != nil {
+			return err
+		}
+
+		if !agg.destinationIndex {
+			continue
+		}
+		if _, err := db.ExecContext(ctx,
+			`CREATE INDEX IF NOT EXISTS ? ON ? (destination_id, bucket)`,
+			bun.Ident(agg.name+"_destination_idx"), bun.Ident(agg.name),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshContinuousAggregates - rematerializes buckets of continuous aggregates starting from the bucket containing `from`. The whole history is refreshed if `from` is zero.
+// Refresh policies update only recent buckets by schedule, so it's used after rollback, synchronization and snapshot import. Only invalidated and not materialized
+// buckets are computed, so refresh of already materialized history is cheap. Refresh can't be executed inside a transaction.
+func RefreshContinuousAggregates(ctx context.Context, db bun.IDB, from time.Time) error {
+	for _, agg := range continuousAggregates {
+		var start any
+		if !from.IsZero() {
+			start = from.UTC().Truncate(agg.bucket)
+		}
+		if _, err := db.ExecContext(ctx,
+			`CALL refresh_continuous_aggregate(?, ?, NULL);`,
+			agg.name, start,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// addedColumn - column added to the table which may already exist. `CREATE TABLE IF NOT EXISTS` doesn't change existing tables,
+// so such columns are added on database initialization.
+type addedColumn struct {
+	table      string
+	column     string
+	definition string
+}
+
+var addedColumns = []addedColumn{
+	{table: "stats", column: "increase_paid_storage_count", definition: "bigint DEFAULT 0"},
+	{table: "stats", column: "staking_count", definition: "bigint DEFAULT 0"},
+	{table: "migrations", column: "deffated_storage", definition: "bytea"},
+}
+
+func addColumns(ctx context.Context, db bun.IDB) error {
+	for _, c := range addedColumns {
+		if _, err := db.ExecContext(ctx,
+			`ALTER TABLE ? ADD COLUMN IF NOT EXISTS ? ?`,
+			bun.Ident(c.table), bun.Ident(c.column), bun.Safe(c.definition),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
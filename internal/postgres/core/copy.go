@@ -0,0 +1,206 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/schema"
+)
+
+// NewCopyTransaction - creates transaction on the dedicated connection. Such transaction supports `COPY FROM` in addition to usual inserts.
+func NewCopyTransaction(ctx context.Context, db *bun.DB) (Transaction, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return Transaction{}, err
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		_ = conn.Close()
+		return Transaction{}, err
+	}
+	return Transaction{tx: tx, conn: &conn, start: time.Now()}, nil
+}
+
+// CopyFrom - inserts models by `COPY FROM STDIN`. `models` is a pointer to slice of model pointers. If `withID` is false autoincrement columns
+// are skipped and filled by their sequences. It's faster than insert but doesn't return values, so it has to be used only for models
+// whose identifiers are not needed or are set before the call.
+func (t Transaction) CopyFrom(ctx context.Context, models any, withID bool) error {
+	return t.copyFrom(ctx, "", models, withID)
+}
+
+// copyFrom - inserts models by `COPY FROM STDIN` into `tableName`. Table of the models is used if `tableName` is empty.
+func (t Transaction) copyFrom(ctx context.Context, tableName string, models any, withID bool) error {
+	if t.conn == nil {
+		return errors.New("copy is not supported by the transaction: use NewCopyTransaction")
+	}
+
+	slice := reflect.Indirect(reflect.ValueOf(models))
+	if slice.Kind() != reflect.Slice {
+		return errors.Errorf("invalid models type for copy: %T", models)
+	}
+	if slice.Len() == 0 {
+		return nil
+	}
+
+	typ := slice.Type().Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	table := t.tx.Dialect().Tables().Get(typ)
+
+	fields := make([]*schema.Field, 0, len(table.Fields))
+	for _, field := range table.Fields {
+		if field.AutoIncrement && !withID {
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	if tableName == "" {
+		tableName = string(table.SQLName)
+	}
+
+	var query bytes.Buffer
+	query.WriteString("COPY ")
+	query.WriteString(tableName)
+	query.WriteString(" (")
+	for i := range fields {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(string(fields[i].SQLName))
+	}
+	query.WriteString(") FROM STDIN")
+
+	data, err := copyData(schema.NewFormatter(t.tx.Dialect()), fields, slice)
+	if err != nil {
+		return errors.Wrap(err, table.Name)
+	}
+
+	_, err = pgdriver.CopyFrom(ctx, *t.conn, bytes.NewReader(data), query.String())
+	return errors.Wrap(err, table.Name)
+}
+
+// copyData - encodes rows in text format of `COPY`: columns are separated by tab, rows are separated by new line and NULL is `\N`
+func copyData(fmter schema.Formatter, fields []*schema.Field, slice reflect.Value) ([]byte, error) {
+	var (
+		data    = make([]byte, 0, slice.Len()*len(fields)*16)
+		literal []byte
+	)
+	for i := 0; i < slice.Len(); i++ {
+		strct := reflect.Indirect(slice.Index(i))
+		for j := range fields {
+			if j > 0 {
+				data = append(data, '\t')
+			}
+			literal = fields[j].AppendValue(fmter, literal[:0], strct)
+
+			var err error
+			data, err = appendCopyValue(data, literal)
+			if err != nil {
+				return nil, errors.Wrap(err, fields[j].Name)
+			}
+		}
+		data = append(data, '\n')
+	}
+	return data, nil
+}
+
+// appendCopyValue - converts SQL literal produced by the dialect to the value of `COPY` text format
+func appendCopyValue(b, literal []byte) ([]byte, error) {
+	switch {
+	case bytes.Equal(literal, []byte("NULL")):
+		return append(b, `\N`...), nil
+	case bytes.HasPrefix(literal, []byte("?!(")):
+		return nil, errors.Errorf("invalid value: %s", literal)
+	case len(literal) < 2 || literal[0] != '\'' || literal[len(literal)-1] != '\'':
+		return append(b, literal...), nil
+	}
+
+	literal = literal[1 : len(literal)-1]
+	for i := 0; i < len(literal); i++ {
+		switch c := literal[i]; c {
+		case '\'':
+			// quote is doubled in SQL literal
+			b = append(b, c)
+			i++
+		case '\\':
+			b = append(b, '\\', '\\')
+		case '\t':
+			b = append(b, '\\', 't')
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		default:
+			b = append(b, c)
+		}
+	}
+	return b, nil
+}
+
+// NextIDs - reserves `count` values of the `id` sequence of the model's table. It's used to set identifiers of models which are inserted by `CopyFrom`.
+func (t Transaction) NextIDs(ctx context.Context, model any, count int) ([]int64, error) {
+	if count == 0 {
+		return nil, nil
+	}
+	table := t.tx.Dialect().Tables().Get(reflect.Indirect(reflect.ValueOf(model)).Type())
+
+	ids := make([]int64, 0, count)
+	err := t.tx.NewRaw("SELECT nextval(pg_get_serial_sequence(?, 'id')) FROM generate_series(1, ?)", table.Name, count).Scan(ctx, &ids)
+	return ids, err
+}
+
+// CopyAccounts - upserts accounts in the same way as `Accounts` does. Accounts are passed by `COPY FROM` to the temporary table
+// and are moved to `accounts` by one query, so counters of the existing accounts are increased. Identifiers of the accounts are set after the call.
+func (t Transaction) CopyAccounts(ctx context.Context, accounts ...*account.Account) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	if t.conn == nil {
+		return errors.New("copy is not supported by the transaction: use NewCopyTransaction")
+	}
+
+	if _, err := t.tx.ExecContext(ctx, `CREATE TEMPORARY TABLE IF NOT EXISTS accounts_copy ON COMMIT DROP AS
+		SELECT type, address, level, last_action, operations_count, migrations_count, events_count, ticket_updates_count FROM accounts WITH NO DATA`); err != nil {
+		return errors.Wrap(err, "creating temporary table")
+	}
+	if _, err := t.tx.ExecContext(ctx, "TRUNCATE accounts_copy"); err != nil {
+		return errors.Wrap(err, "truncating temporary table")
+	}
+	if err := t.copyFrom(ctx, "accounts_copy", &accounts, false); err != nil {
+		return err
+	}
+
+	var saved []account.Account
+	if err := t.tx.NewRaw(`INSERT INTO accounts (type, address, level, last_action, operations_count, migrations_count, events_count, ticket_updates_count)
+		SELECT type, address, level, last_action, operations_count, migrations_count, events_count, ticket_updates_count FROM accounts_copy
+		ON CONFLICT ON CONSTRAINT address_hash DO UPDATE SET
+			operations_count = EXCLUDED.operations_count + accounts.operations_count,
+			events_count = EXCLUDED.events_count + accounts.events_count,
+			migrations_count = EXCLUDED.migrations_count + accounts.migrations_count,
+			ticket_updates_count = EXCLUDED.ticket_updates_count + accounts.ticket_updates_count,
+			last_action = EXCLUDED.last_action
+		RETURNING id, address`).Scan(ctx, &saved); err != nil {
+		return errors.Wrap(err, "moving accounts")
+	}
+
+	ids := make(map[string]int64, len(saved))
+	for i := range saved {
+		ids[saved[i].Address] = saved[i].ID
+	}
+	for i := range accounts {
+		id, ok := ids[accounts[i].Address]
+		if !ok {
+			return errors.Errorf("account is not saved: %s", accounts[i].Address)
+		}
+		accounts[i].ID = id
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendCopyValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		literal string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "null",
+			literal: "NULL",
+			want:    `\N`,
+		}, {
+			name:    "number",
+			literal: "12345",
+			want:    "12345",
+		}, {
+			name:    "boolean",
+			literal: "TRUE",
+			want:    "TRUE",
+		}, {
+			name:    "string",
+			literal: "'it''s'",
+			want:    "it's",
+		}, {
+			name:    "special characters",
+			literal: "'a\tb\nc\rd'",
+			want:    `a\tb\nc\rd`,
+		}, {
+			name:    "bytea",
+			literal: `'\x0102'`,
+			want:    `\\x0102`,
+		}, {
+			name:    "timestamp",
+			literal: "'2024-01-02 03:04:05+00:00'",
+			want:    "2024-01-02 03:04:05+00:00",
+		}, {
+			name:    "empty string",
+			literal: "''",
+			want:    "",
+		}, {
+			name:    "error",
+			literal: "?!(invalid)",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := appendCopyValue(nil, []byte(tt.literal))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
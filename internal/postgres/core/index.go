@@ -7,6 +7,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	"github.com/uptrace/bun"
 )
 
@@ -139,6 +140,17 @@ func createBaseIndices(ctx context.Context, db bun.IDB) error {
 			return err
 		}
 
+		// Search entries
+		if _, err := db.NewCreateIndex().
+			Model((*search.Entry)(nil)).
+			IfNotExists().
+			Index("search_entries_value_trgm_idx").
+			Using("GIN").
+			ColumnExpr("value public.gin_trgm_ops").
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
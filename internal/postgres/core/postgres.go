@@ -142,6 +142,10 @@ func (p *Postgres) InitDatabase(ctx context.Context) error {
 		return err
 	}
 
+	if err := addColumns(ctx, p.DB); err != nil {
+		return err
+	}
+
 	if err := createBaseIndices(ctx, p.DB); err != nil {
 		return err
 	}
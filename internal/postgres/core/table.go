@@ -11,7 +11,9 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/rs/zerolog/log"
 	"github.com/uptrace/bun"
 )
@@ -36,7 +38,10 @@ func createTables(ctx context.Context, db *bun.DB) error {
 			return err
 		}
 	}
-	return createHypertables(ctx, db)
+	if err := createHypertables(ctx, db); err != nil {
+		return err
+	}
+	return createContinuousAggregates(ctx, db)
 }
 
 func createHypertables(ctx context.Context, db *bun.DB) error {
@@ -47,7 +52,13 @@ func createHypertables(ctx context.Context, db *bun.DB) error {
 		&contract.Contract{},
 		&migration.Migration{},
 		&operation.Operation{},
+		&smartrollup.Commitment{},
+		&smartrollup.GameMove{},
+		&smartrollup.BondRecovery{},
+		&smartrollup.Message{},
+		&smartrollup.OutboxTransaction{},
 		&ticket.TicketUpdate{},
+		&token.Transfer{},
 	} {
 		if _, err := db.ExecContext(ctx,
 			`SELECT public.create_hypertable(?, 'timestamp', chunk_time_interval => INTERVAL '1 month', if_not_exists => TRUE);`,
@@ -74,6 +85,9 @@ func createSchema(ctx context.Context, db *bun.DB, schemaName string) error {
 		if _, err := db.NewRaw("CREATE EXTENSION IF NOT EXISTS timescaledb;").Exec(ctx); err != nil {
 			log.Err(err).Msg("create timescale extension")
 		}
+		if _, err := db.NewRaw("CREATE EXTENSION IF NOT EXISTS pg_trgm;").Exec(ctx); err != nil {
+			log.Err(err).Msg("create pg_trgm extension")
+		}
 	})
 
 	wg.Wait()
@@ -2,23 +2,31 @@ package core
 
 import (
 	"context"
+	"time"
 
+	"github.com/baking-bad/bcdhub/internal/metrics"
 	"github.com/baking-bad/bcdhub/internal/models/account"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/uptrace/bun"
 )
 
 type Transaction struct {
-	tx bun.Tx
+	tx    bun.Tx
+	conn  *bun.Conn
+	start time.Time
 }
 
 // NewTransaction -
@@ -27,17 +35,30 @@ func NewTransaction(ctx context.Context, db *bun.DB) (Transaction, error) {
 	if err != nil {
 		return Transaction{}, err
 	}
-	return Transaction{tx}, nil
+	return Transaction{tx: tx, start: time.Now()}, nil
 }
 
 func (t Transaction) Commit() error {
-	return t.tx.Commit()
+	defer t.close()
+	if err := t.tx.Commit(); err != nil {
+		return err
+	}
+	metrics.DBTransaction(metrics.StatusCommitted, time.Since(t.start))
+	return nil
 }
 
 func (t Transaction) Rollback() error {
+	defer t.close()
+	metrics.DBTransaction(metrics.StatusRolledBack, time.Since(t.start))
 	return t.tx.Rollback()
 }
 
+func (t Transaction) close() {
+	if t.conn != nil {
+		_ = t.conn.Close()
+	}
+}
+
 func (t Transaction) Save(ctx context.Context, data any) error {
 	_, err := t.tx.NewInsert().Model(data).Returning("id").Exec(ctx)
 	return err
@@ -113,6 +134,41 @@ func (t Transaction) SmartRollups(ctx context.Context, rollups ...*smartrollup.S
 	return t.Save(ctx, &rollups)
 }
 
+func (t Transaction) SmartRollupCommitments(ctx context.Context, commitments ...*smartrollup.Commitment) error {
+	if len(commitments) == 0 {
+		return nil
+	}
+	return t.Save(ctx, &commitments)
+}
+
+func (t Transaction) SmartRollupGameMoves(ctx context.Context, moves ...*smartrollup.GameMove) error {
+	if len(moves) == 0 {
+		return nil
+	}
+	return t.Save(ctx, &moves)
+}
+
+func (t Transaction) SmartRollupRecoveries(ctx context.Context, recoveries ...*smartrollup.BondRecovery) error {
+	if len(recoveries) == 0 {
+		return nil
+	}
+	return t.Save(ctx, &recoveries)
+}
+
+func (t Transaction) SmartRollupMessages(ctx context.Context, messages ...*smartrollup.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return t.Save(ctx, &messages)
+}
+
+func (t Transaction) SmartRollupOutbox(ctx context.Context, transactions ...*smartrollup.OutboxTransaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	return t.Save(ctx, &transactions)
+}
+
 func (t Transaction) Operations(ctx context.Context, operations ...*operation.Operation) error {
 	if len(operations) == 0 {
 		return nil
@@ -231,6 +287,8 @@ func (t Transaction) UpdateStats(ctx context.Context, stats stats.Stats) error {
 		Set("register_global_constants_count = EXCLUDED.register_global_constants_count + stats.register_global_constants_count").
 		Set("sr_executes_count = EXCLUDED.sr_executes_count + stats.sr_executes_count").
 		Set("transfer_tickets_count = EXCLUDED.transfer_tickets_count + stats.transfer_tickets_count").
+		Set("increase_paid_storage_count = EXCLUDED.increase_paid_storage_count + stats.increase_paid_storage_count").
+		Set("staking_count = EXCLUDED.staking_count + stats.staking_count").
 		Set("global_constants_count = EXCLUDED.global_constants_count + stats.global_constants_count").
 		Set("smart_rollups_count = EXCLUDED.smart_rollups_count + stats.smart_rollups_count").
 		Returning("id").
@@ -265,6 +323,96 @@ func (t Transaction) TicketBalances(ctx context.Context, balances ...*ticket.Bal
 	return err
 }
 
+func (t Transaction) TokenTransfers(ctx context.Context, transfers ...*token.Transfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	return t.Save(ctx, &transfers)
+}
+
+func (t Transaction) TokenBalances(ctx context.Context, balances ...*token.Balance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+
+	_, err := t.tx.NewInsert().Model(&balances).
+		Column("contract", "address", "token_id", "amount").
+		On("CONFLICT (contract, address, token_id) DO UPDATE").
+		Set("amount = balance.amount + EXCLUDED.amount").
+		Exec(ctx)
+	return err
+}
+
+func (t Transaction) ContractMetadata(ctx context.Context, metadata ...*metadata.ContractMetadata) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	_, err := t.tx.NewInsert().Model(&metadata).
+		Column("contract", "level", "timestamp", "uri", "name", "description", "version", "homepage", "authors", "interfaces", "license", "views", "raw", "pending", "attempts").
+		On("CONFLICT ON CONSTRAINT contract_metadata_key DO UPDATE").
+		Set("uri = EXCLUDED.uri").
+		Set("name = EXCLUDED.name").
+		Set("description = EXCLUDED.description").
+		Set("version = EXCLUDED.version").
+		Set("homepage = EXCLUDED.homepage").
+		Set("authors = EXCLUDED.authors").
+		Set("interfaces = EXCLUDED.interfaces").
+		Set("license = EXCLUDED.license").
+		Set("views = EXCLUDED.views").
+		Set("raw = EXCLUDED.raw").
+		Set("pending = EXCLUDED.pending").
+		Set("attempts = EXCLUDED.attempts").
+		Returning("id").
+		Exec(ctx)
+	return err
+}
+
+func (t Transaction) TokenMetadata(ctx context.Context, metadata ...*metadata.TokenMetadata) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	_, err := t.tx.NewInsert().Model(&metadata).
+		Column("contract", "token_id", "level", "timestamp", "symbol", "name", "decimals", "uri", "extras", "removed", "pending", "attempts").
+		On("CONFLICT ON CONSTRAINT token_metadata_key DO UPDATE").
+		Set("symbol = EXCLUDED.symbol").
+		Set("name = EXCLUDED.name").
+		Set("decimals = EXCLUDED.decimals").
+		Set("uri = EXCLUDED.uri").
+		Set("extras = EXCLUDED.extras").
+		Set("removed = EXCLUDED.removed").
+		Set("pending = EXCLUDED.pending").
+		Set("attempts = EXCLUDED.attempts").
+		Returning("id").
+		Exec(ctx)
+	return err
+}
+
+func (t Transaction) WebhookDeliveries(ctx context.Context, deliveries ...*webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	_, err := t.tx.NewInsert().Model(&deliveries).
+		Column("webhook_id", "event", "operation_id", "level", "payload", "attempts", "next_attempt_at", "created_at").
+		Returning("id").
+		Exec(ctx)
+	return err
+}
+
+func (t Transaction) SearchEntries(ctx context.Context, entries ...*search.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	_, err := t.tx.NewInsert().Model(&entries).
+		Column("kind", "value", "address", "level", "timestamp").
+		On("CONFLICT ON CONSTRAINT search_entries_key DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
 func (t Transaction) DeleteBigMapStatesByContract(ctx context.Context, contract string) (states []bigmapdiff.BigMapState, err error) {
 	_, err = t.tx.NewDelete().
 		Model((*bigmapdiff.BigMapState)(nil)).
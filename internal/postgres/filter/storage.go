@@ -0,0 +1,39 @@
+package filter
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/filter"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/uptrace/bun"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+// List -
+func (storage *Storage) List(ctx context.Context) (rules []filter.Rule, err error) {
+	err = storage.DB.NewSelect().Model(&rules).Order("id asc").Scan(ctx)
+	return
+}
+
+// Replace -
+func (storage *Storage) Replace(ctx context.Context, rules []filter.Rule) error {
+	return storage.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*filter.Rule)(nil)).Where("true").Exec(ctx); err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		_, err := tx.NewInsert().Model(&rules).Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,92 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+// ContractMetadata -
+func (storage *Storage) ContractMetadata(ctx context.Context, contract string) (result metadata.ContractMetadata, err error) {
+	err = storage.DB.
+		NewSelect().
+		Model(&result).
+		Where("contract = ?", contract).
+		Where("raw is not null").
+		Order("level desc").
+		Limit(1).
+		Scan(ctx)
+	return
+}
+
+// TokenMetadata -
+func (storage *Storage) TokenMetadata(ctx context.Context, contract string, req metadata.TokenMetadataRequest) (result []metadata.TokenMetadata, err error) {
+	actual := storage.DB.
+		NewSelect().
+		Model((*metadata.TokenMetadata)(nil)).
+		DistinctOn("token_id").
+		Where("contract = ?", contract).
+		OrderExpr("token_id asc, level desc")
+
+	query := storage.DB.
+		NewSelect().
+		TableExpr("(?) as token_metadata", actual).
+		ColumnExpr("token_metadata.*").
+		Where("removed = false").
+		Order("token_id asc").
+		Limit(storage.GetPageSize(req.Limit))
+
+	if req.Offset > 0 {
+		query.Offset(int(req.Offset))
+	}
+
+	err = query.Scan(ctx, &result)
+	return
+}
+
+// PendingContractMetadata -
+func (storage *Storage) PendingContractMetadata(ctx context.Context, limit int) (result []metadata.ContractMetadata, err error) {
+	err = storage.DB.
+		NewSelect().
+		Model(&result).
+		Where("pending = true").
+		Order("id asc").
+		Limit(limit).
+		Scan(ctx)
+	return
+}
+
+// PendingTokenMetadata -
+func (storage *Storage) PendingTokenMetadata(ctx context.Context, limit int) (result []metadata.TokenMetadata, err error) {
+	err = storage.DB.
+		NewSelect().
+		Model(&result).
+		Where("pending = true").
+		Order("id asc").
+		Limit(limit).
+		Scan(ctx)
+	return
+}
+
+// UpdateContractMetadata -
+func (storage *Storage) UpdateContractMetadata(ctx context.Context, m *metadata.ContractMetadata) error {
+	_, err := storage.DB.NewUpdate().Model(m).WherePK().Exec(ctx)
+	return err
+}
+
+// UpdateTokenMetadata -
+func (storage *Storage) UpdateTokenMetadata(ctx context.Context, m *metadata.TokenMetadata) error {
+	_, err := storage.DB.NewUpdate().Model(m).WherePK().Exec(ctx)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 )
 
@@ -27,3 +28,18 @@ func (storage *Storage) Get(ctx context.Context, contractID int64) (migrations [
 		Scan(ctx)
 	return
 }
+
+// LastStorage - returns the last protocol migration of the account's contract script at or before `level`. Storage of migrations indexed before it was stored is empty.
+func (storage *Storage) LastStorage(ctx context.Context, accountID, level int64) (result migration.Migration, err error) {
+	err = storage.DB.
+		NewSelect().
+		Model(&result).
+		Join("LEFT JOIN contracts ON contracts.id = migration.contract_id").
+		Where("contracts.account_id = ?", accountID).
+		Where("migration.level <= ?", level).
+		Where("migration.kind = ?", types.MigrationKindUpdate).
+		OrderExpr("migration.level desc").
+		Limit(1).
+		Scan(ctx)
+	return
+}
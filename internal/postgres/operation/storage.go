@@ -9,6 +9,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/models/account"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/uptrace/bun"
 )
@@ -98,6 +99,7 @@ func (storage *Storage) GetByID(ctx context.Context, id int64) (result operation
 	err = storage.DB.NewSelect().
 		Model(&result).
 		Relation("Destination").
+		Relation("Source").
 		Where("operation.id = ?", id).
 		Limit(1).
 		Scan(ctx)
@@ -248,6 +250,116 @@ func (storage *Storage) GetByHash(ctx context.Context, hash []byte) (operations
 	return operations, err
 }
 
+// ListByLevel - returns all operations of the block
+func (storage *Storage) ListByLevel(ctx context.Context, level int64) (operations []operation.Operation, err error) {
+	query := storage.DB.NewSelect().Model((*operation.Operation)(nil)).
+		Where("level = ?", level)
+
+	err = storage.DB.NewSelect().TableExpr("(?) as operation", query).
+		ColumnExpr("operation.*").
+		ColumnExpr("source.address as source__address, source.type as source__type,source.id as source__id").
+		ColumnExpr("destination.address as destination__address, destination.type as destination__type, destination.id as destination__id").
+		Join("LEFT JOIN accounts as source ON source.id = operation.source_id").
+		Join("LEFT JOIN accounts as destination ON destination.id = operation.destination_id").
+		OrderExpr("operation.id asc").
+		Scan(ctx, &operations)
+	return operations, err
+}
+
+// ListByAccount -
+func (storage *Storage) ListByAccount(ctx context.Context, accountID int64, lastID, size int64) (operations []operation.Operation, err error) {
+	query := storage.DB.NewSelect().Model((*operation.Operation)(nil)).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("source_id = ?", accountID).WhereOr("destination_id = ?", accountID)
+		})
+
+	if lastID > 0 {
+		query.Where("id < ?", lastID)
+	}
+	if size > 0 {
+		query.Limit(int(size))
+	} else {
+		query.Limit(10)
+	}
+	query.Order("id desc")
+
+	err = storage.DB.NewSelect().TableExpr("(?) as operation", query).
+		ColumnExpr("operation.*").
+		ColumnExpr("source.address as source__address, source.type as source__type,source.id as source__id").
+		ColumnExpr("destination.address as destination__address, destination.type as destination__type, destination.id as destination__id").
+		Join("LEFT JOIN accounts as source ON source.id = operation.source_id").
+		Join("LEFT JOIN accounts as destination ON destination.id = operation.destination_id").
+		OrderExpr("operation.id desc").
+		Scan(ctx, &operations)
+	return operations, err
+}
+
+// Search - returns operations matching filters ordered by timestamp and id descending. Pagination uses (timestamp, id) key of the last operation so time-partitioned chunks outside of the page are skipped.
+func (storage *Storage) Search(ctx context.Context, req operation.SearchRequest) (operations []operation.Operation, err error) {
+	query := storage.DB.NewSelect().Model((*operation.Operation)(nil))
+
+	if len(req.Kinds) > 0 {
+		query.Where("kind IN (?)", bun.In(req.Kinds))
+	}
+	if len(req.Statuses) > 0 {
+		query.Where("status IN (?)", bun.In(req.Statuses))
+	}
+	if req.Entrypoint != "" {
+		query.Where("entrypoint = ?", req.Entrypoint)
+	}
+	if req.Tag != "" {
+		query.Where("tag = ?", req.Tag)
+	}
+	if req.Source != "" {
+		query.Where("source_id = (?)", storage.accountID(req.Source))
+	}
+	if req.Destination != "" {
+		query.Where("destination_id = (?)", storage.accountID(req.Destination))
+	}
+	if req.MinLevel > 0 {
+		query.Where("level >= ?", req.MinLevel)
+	}
+	if req.MaxLevel > 0 {
+		query.Where("level <= ?", req.MaxLevel)
+	}
+	if !req.From.IsZero() {
+		query.Where("timestamp >= ?", req.From.UTC())
+	}
+	if !req.To.IsZero() {
+		query.Where("timestamp <= ?", req.To.UTC())
+	}
+	if req.MinAmount > 0 {
+		query.Where("amount >= ?", req.MinAmount)
+	}
+	if req.ErrorID != "" {
+		query.Where("errors IS NOT NULL").
+			Where("EXISTS (SELECT 1 FROM jsonb_array_elements(convert_from(errors, 'UTF8')::jsonb) AS err WHERE strpos(err->>'id', ?) > 0)", req.ErrorID)
+	}
+	if req.LastID > 0 && !req.LastTimestamp.IsZero() {
+		query.Where("timestamp <= ?", req.LastTimestamp.UTC()).
+			Where("(timestamp, id) < (?, ?)", req.LastTimestamp.UTC(), req.LastID)
+	}
+
+	query.OrderExpr("timestamp desc, id desc").Limit(storage.GetPageSize(req.Size))
+
+	err = storage.DB.NewSelect().TableExpr("(?) as operation", query).
+		ColumnExpr("operation.*").
+		ColumnExpr("source.address as source__address, source.type as source__type,source.id as source__id").
+		ColumnExpr("destination.address as destination__address, destination.type as destination__type, destination.id as destination__id").
+		Join("LEFT JOIN accounts as source ON source.id = operation.source_id").
+		Join("LEFT JOIN accounts as destination ON destination.id = operation.destination_id").
+		OrderExpr("operation.timestamp desc, operation.id desc").
+		Scan(ctx, &operations)
+	return operations, err
+}
+
+func (storage *Storage) accountID(address string) *bun.SelectQuery {
+	return storage.DB.NewSelect().
+		Model((*account.Account)(nil)).
+		Column("id").
+		Where("address = ?", address)
+}
+
 // GetByHashAndCounter -
 func (storage *Storage) GetByHashAndCounter(ctx context.Context, hash []byte, counter int64) (operations []operation.Operation, err error) {
 	query := storage.DB.NewSelect().Model(&operations)
@@ -296,6 +408,20 @@ func (storage *Storage) Origination(ctx context.Context, accountID int64) (resul
 	return result, err
 }
 
+// LastStorage -
+func (storage *Storage) LastStorage(ctx context.Context, accountID, level int64) (result operation.Operation, err error) {
+	err = storage.DB.NewSelect().
+		Model(&result).
+		Where("destination_id = ?", accountID).
+		Where("status = ?", types.OperationStatusApplied).
+		Where("level <= ?", level).
+		Where("deffated_storage is not null").
+		OrderExpr("timestamp desc, id desc").
+		Limit(1).
+		Scan(ctx)
+	return
+}
+
 func addOperationSorting(query *bun.SelectQuery) {
 	query.OrderExpr("operation.level desc, operation.counter desc, operation.id asc")
 }
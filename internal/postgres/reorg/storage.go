@@ -0,0 +1,36 @@
+package reorg
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/reorg"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+// Save -
+func (storage *Storage) Save(ctx context.Context, event *reorg.Reorg) error {
+	_, err := storage.DB.NewInsert().Model(event).Returning("id").Exec(ctx)
+	return err
+}
+
+// List -
+func (storage *Storage) List(ctx context.Context, limit, offset int64) (events []reorg.Reorg, err error) {
+	query := storage.DB.NewSelect().Model(&events).
+		Order("id desc").
+		Limit(storage.GetPageSize(limit))
+	if offset > 0 {
+		query.Offset(int(offset))
+	}
+	err = query.Scan(ctx)
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/account"
@@ -14,11 +15,21 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/uptrace/bun"
 )
 
+const dryRunLockTimeout = "5s"
+
 type Rollback struct {
-	tx bun.Tx
+	db     *bun.DB
+	tx     bun.Tx
+	report *RollbackReport
+
+	// external - transaction belongs to the caller which commits or discards it
+	external bool
 }
 
 func NewRollback(db *bun.DB) (Rollback, error) {
@@ -26,17 +37,57 @@ func NewRollback(db *bun.DB) (Rollback, error) {
 	if err != nil {
 		return Rollback{}, err
 	}
-	return Rollback{tx}, nil
+	return Rollback{db: db, tx: tx}, nil
+}
+
+// NewDryRunRollback - creates rollback saver which counts rows changed by rollback in the returned report. Changes are discarded on commit.
+// Changed rows are locked until the end of the transaction. Dry run fails instead of waiting for the rows locked by the running indexer,
+// so it doesn't hold already acquired locks for long.
+func NewDryRunRollback(db *bun.DB) (Rollback, *RollbackReport, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return Rollback{}, nil, err
+	}
+	if _, err := tx.Exec("SET LOCAL lock_timeout = ?", dryRunLockTimeout); err != nil {
+		_ = tx.Rollback()
+		return Rollback{}, nil, err
+	}
+	report := NewRollbackReport()
+	return Rollback{db: db, tx: tx, report: report}, report, nil
+}
+
+// NewTxRollback - creates rollback saver working in the transaction of the caller. Commit and rollback of the saver do nothing and
+// aggregates aren't refreshed: the transaction is finished by the caller. It's used to read the state at the past level without changing data.
+func NewTxRollback(tx bun.Tx) Rollback {
+	return Rollback{tx: tx, external: true}
 }
 
 func (r Rollback) Commit() error {
+	if r.external {
+		return nil
+	}
+	if r.report != nil {
+		return r.tx.Rollback()
+	}
 	return r.tx.Commit()
 }
 
 func (r Rollback) Rollback() error {
+	if r.external {
+		return nil
+	}
 	return r.tx.Rollback()
 }
 
+// RefreshAggregates - continuous aggregates can't be refreshed inside transaction, so it's executed on the database after commit.
+// Dry run discards changes, so aggregates aren't refreshed.
+func (r Rollback) RefreshAggregates(ctx context.Context, from time.Time) error {
+	if r.report != nil || r.external {
+		return nil
+	}
+	return core.RefreshContinuousAggregates(ctx, r.db, from)
+}
+
 func (r Rollback) DeleteAll(ctx context.Context, model any, level int64) (int, error) {
 	result, err := r.tx.NewDelete().
 		Model(model).
@@ -49,6 +100,7 @@ func (r Rollback) DeleteAll(ctx context.Context, model any, level int64) (int, e
 	if err != nil {
 		return 0, err
 	}
+	r.report.add(r.tableName(model), changeDeleted, count)
 	return int(count), nil
 }
 
@@ -60,8 +112,8 @@ func (r Rollback) StatesChangedAtLevel(ctx context.Context, level int64) (states
 }
 
 func (r Rollback) DeleteBigMapState(ctx context.Context, state bigmapdiff.BigMapState) error {
-	_, err := r.tx.NewDelete().Model(&state).WherePK().Exec(ctx)
-	return err
+	result, err := r.tx.NewDelete().Model(&state).WherePK().Exec(ctx)
+	return r.track(state.TableName(), changeDeleted, result, err)
 }
 
 func (r Rollback) LastDiff(ctx context.Context, ptr int64, keyHash string, skipRemoved bool) (diff bigmapdiff.BigMapDiff, err error) {
@@ -78,12 +130,12 @@ func (r Rollback) LastDiff(ctx context.Context, ptr int64, keyHash string, skipR
 }
 
 func (r Rollback) SaveBigMapState(ctx context.Context, state bigmapdiff.BigMapState) error {
-	_, err := r.tx.NewUpdate().
+	result, err := r.tx.NewUpdate().
 		Column("last_update_level", "last_update_time", "removed", "value").
 		Model(&state).
 		WherePK().
 		Exec(ctx)
-	return err
+	return r.track(state.TableName(), changeUpdated, result, err)
 }
 
 func (r Rollback) GetOperations(ctx context.Context, level int64) (ops []operation.Operation, err error) {
@@ -117,7 +169,7 @@ func (r Rollback) GetLastAction(ctx context.Context, addressIds ...int64) (actio
 }
 
 func (r Rollback) UpdateAccountStats(ctx context.Context, account account.Account) error {
-	_, err := r.tx.NewUpdate().Model(&account).
+	result, err := r.tx.NewUpdate().Model(&account).
 		Where("id = ?id").
 		Set("operations_count = operations_count - ?operations_count").
 		Set("migrations_count = migrations_count - ?migrations_count").
@@ -125,7 +177,7 @@ func (r Rollback) UpdateAccountStats(ctx context.Context, account account.Accoun
 		Set("ticket_updates_count = ticket_updates_count - ?ticket_updates_count").
 		Set("last_action = ?last_action").
 		Exec(ctx)
-	return err
+	return r.track(account.TableName(), changeUpdated, result, err)
 }
 
 func (r Rollback) GlobalConstants(ctx context.Context, level int64) (constants []contract.GlobalConstant, err error) {
@@ -152,8 +204,8 @@ func (r Rollback) DeleteScriptsConstants(ctx context.Context, scriptIds []int64,
 		Where("script_id IN (?)", bun.In(scriptIds)).
 		WhereOr("global_constant_id IN (?)", bun.In(constantsIds))
 
-	_, err := query.Exec(ctx)
-	return err
+	result, err := query.Exec(ctx)
+	return r.track(r.tableName((*contract.ScriptConstants)(nil)), changeDeleted, result, err)
 }
 
 func (r Rollback) Protocols(ctx context.Context, level int64) error {
@@ -168,17 +220,18 @@ func (r Rollback) Protocols(ctx context.Context, level int64) error {
 	if count == 0 {
 		return nil
 	}
+	r.report.add(models.DocProtocol, changeDeleted, count)
 
-	_, err = r.tx.NewUpdate().
+	result, err = r.tx.NewUpdate().
 		Model((*protocol.Protocol)(nil)).
 		Where("start_level < ?", level).
 		Set("end_level = 0").
 		Exec(ctx)
-	return err
+	return r.track(models.DocProtocol, changeUpdated, result, err)
 }
 
 func (r Rollback) UpdateStats(ctx context.Context, stats stats.Stats) error {
-	_, err := r.tx.NewUpdate().
+	result, err := r.tx.NewUpdate().
 		Model(&stats).
 		Where("id = ?id").
 		Set("contracts_count = ?contracts_count").
@@ -190,10 +243,12 @@ func (r Rollback) UpdateStats(ctx context.Context, stats stats.Stats) error {
 		Set("register_global_constants_count = ?register_global_constants_count").
 		Set("sr_executes_count = ?sr_executes_count").
 		Set("transfer_tickets_count = ?transfer_tickets_count").
+		Set("increase_paid_storage_count = ?increase_paid_storage_count").
+		Set("staking_count = ?staking_count").
 		Set("global_constants_count = ?global_constants_count").
 		Set("smart_rollups_count = ?smart_rollups_count").
 		Exec(ctx)
-	return err
+	return r.track(stats.TableName(), changeUpdated, result, err)
 }
 
 func (r Rollback) GetMigrations(ctx context.Context, level int64) (migrations []migration.Migration, err error) {
@@ -214,12 +269,12 @@ func (r Rollback) GetTicketUpdates(ctx context.Context, level int64) (updates []
 }
 
 func (r Rollback) UpdateTicket(ctx context.Context, ticket ticket.Ticket) error {
-	_, err := r.tx.NewUpdate().
+	result, err := r.tx.NewUpdate().
 		Model(&ticket).
 		Where("id = ?id").
 		Set("updates_count = updates_count - ?updates_count").
 		Exec(ctx)
-	return err
+	return r.track(ticket.TableName(), changeUpdated, result, err)
 }
 
 func (r Rollback) TicketBalances(ctx context.Context, balances ...*ticket.Balance) error {
@@ -227,12 +282,12 @@ func (r Rollback) TicketBalances(ctx context.Context, balances ...*ticket.Balanc
 		return nil
 	}
 
-	_, err := r.tx.NewInsert().Model(&balances).
+	result, err := r.tx.NewInsert().Model(&balances).
 		Column("ticket_id", "account_id", "amount").
 		On("CONFLICT (ticket_id, account_id) DO UPDATE").
 		Set("amount = balance.amount - EXCLUDED.amount").
 		Exec(ctx)
-	return err
+	return r.track(models.DocTicketBalances, changeUpdated, result, err)
 }
 
 func (r Rollback) DeleteTickets(ctx context.Context, level int64) (ids []int64, err error) {
@@ -241,13 +296,64 @@ func (r Rollback) DeleteTickets(ctx context.Context, level int64) (ids []int64,
 		Where("level = ?", level).
 		Returning("id").
 		Exec(ctx, &ids)
+	if err == nil {
+		r.report.add(models.DocTickets, changeDeleted, int64(len(ids)))
+	}
 	return
 }
 
 func (r Rollback) DeleteTicketBalances(ctx context.Context, ticketIds []int64) (err error) {
-	_, err = r.tx.NewDelete().
+	result, err := r.tx.NewDelete().
 		Model((*ticket.Balance)(nil)).
 		Where("ticket_id IN (?)", bun.In(ticketIds)).
 		Exec(ctx)
+	return r.track(models.DocTicketBalances, changeDeleted, result, err)
+}
+
+func (r Rollback) GetTokenTransfers(ctx context.Context, level int64) (transfers []token.Transfer, err error) {
+	err = r.tx.NewSelect().Model(&transfers).
+		Where("level = ?", level).
+		Scan(ctx)
 	return
 }
+
+func (r Rollback) TokenBalances(ctx context.Context, balances ...*token.Balance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+
+	result, err := r.tx.NewInsert().Model(&balances).
+		Column("contract", "address", "token_id", "amount").
+		On("CONFLICT (contract, address, token_id) DO UPDATE").
+		Set("amount = balance.amount - EXCLUDED.amount").
+		Exec(ctx)
+	return r.track(models.DocTokenBalances, changeUpdated, result, err)
+}
+
+// RevertWebhookDeliveries - creates `reverted` events for delivered `operation` events of the level and removes all `operation` events of the level. Undelivered events are removed without compensation.
+func (r Rollback) RevertWebhookDeliveries(ctx context.Context, level int64) (int, error) {
+	result, err := r.tx.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (webhook_id, event, operation_id, level, payload, attempts, next_attempt_at, created_at)
+		SELECT webhook_id, ?, operation_id, level, payload, 0, now(), now() FROM webhook_deliveries
+		WHERE level = ? AND event = ? AND delivered = true`,
+		webhook.EventReverted, level, webhook.EventOperation,
+	)
+	if err != nil {
+		return 0, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	r.report.add(models.DocWebhookQueue, changeInserted, count)
+
+	result, err = r.tx.NewDelete().
+		Model((*webhook.Delivery)(nil)).
+		Where("level = ?", level).
+		Where("event = ?", webhook.EventOperation).
+		Exec(ctx)
+	if err := r.track(models.DocWebhookQueue, changeDeleted, result, err); err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"database/sql"
+	"reflect"
+	"sort"
+)
+
+type changeKind int
+
+const (
+	changeDeleted changeKind = iota
+	changeUpdated
+	changeInserted
+)
+
+// TableChanges - count of rows changed by rollback in the table
+type TableChanges struct {
+	Table    string
+	Deleted  int64
+	Updated  int64
+	Inserted int64
+}
+
+// RollbackReport - counts rows changed by rollback per table
+type RollbackReport struct {
+	tables map[string]*TableChanges
+}
+
+// NewRollbackReport -
+func NewRollbackReport() *RollbackReport {
+	return &RollbackReport{
+		tables: make(map[string]*TableChanges),
+	}
+}
+
+// Tables - returns changes sorted by table name. Tables without changes are skipped.
+func (report *RollbackReport) Tables() []TableChanges {
+	result := make([]TableChanges, 0, len(report.tables))
+	for _, changes := range report.tables {
+		if changes.Deleted == 0 && changes.Updated == 0 && changes.Inserted == 0 {
+			continue
+		}
+		result = append(result, *changes)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Table < result[j].Table
+	})
+	return result
+}
+
+func (report *RollbackReport) add(table string, kind changeKind, count int64) {
+	if report == nil {
+		return
+	}
+	changes, ok := report.tables[table]
+	if !ok {
+		changes = &TableChanges{Table: table}
+		report.tables[table] = changes
+	}
+	switch kind {
+	case changeDeleted:
+		changes.Deleted += count
+	case changeUpdated:
+		changes.Updated += count
+	case changeInserted:
+		changes.Inserted += count
+	}
+}
+
+func (r Rollback) track(table string, kind changeKind, result sql.Result, err error) error {
+	if err != nil || r.report == nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	r.report.add(table, kind, count)
+	return nil
+}
+
+func (r Rollback) tableName(model any) string {
+	if r.report == nil {
+		return ""
+	}
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	return r.tx.Dialect().Tables().Get(typ).Name
+}
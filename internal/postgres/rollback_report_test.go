@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackReport(t *testing.T) {
+	report := NewRollbackReport()
+	report.add("operations", changeDeleted, 3)
+	report.add("accounts", changeUpdated, 2)
+	report.add("operations", changeDeleted, 1)
+	report.add("webhook_deliveries", changeInserted, 1)
+	report.add("webhook_deliveries", changeDeleted, 2)
+	report.add("tickets", changeDeleted, 0)
+
+	require.Equal(t, []TableChanges{
+		{Table: "accounts", Updated: 2},
+		{Table: "operations", Deleted: 4},
+		{Table: "webhook_deliveries", Deleted: 2, Inserted: 1},
+	}, report.Tables())
+
+	var empty *RollbackReport
+	empty.add("operations", changeDeleted, 1)
+}
@@ -0,0 +1,50 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/uptrace/bun"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search -
+func (storage *Storage) Search(ctx context.Context, req search.Request) (entries []search.Entry, err error) {
+	if req.Query == "" {
+		return nil, nil
+	}
+	escaped := likeEscaper.Replace(req.Query)
+
+	query := storage.DB.NewSelect().
+		Model(&entries).
+		Where("value ILIKE ?", "%"+escaped+"%")
+
+	if len(req.Kinds) > 0 {
+		query.Where("kind IN (?)", bun.In(req.Kinds))
+	}
+
+	query.
+		OrderExpr("(CASE WHEN lower(value) = lower(?) THEN 0 WHEN value ILIKE ? THEN 1 ELSE 2 END)", req.Query, escaped+"%").
+		OrderExpr("length(value) asc, id asc").
+		Limit(storage.GetPageSize(req.Limit))
+
+	if req.Offset > 0 {
+		query.Offset(int(req.Offset))
+	}
+
+	err = query.Scan(ctx)
+	return
+}
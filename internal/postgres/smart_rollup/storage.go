@@ -57,3 +57,108 @@ func (storage *Storage) List(ctx context.Context, limit, offset int64, sort stri
 	err = query.Relation("Address").Scan(ctx)
 	return
 }
+
+// Commitments - returns published and cemented commitments of the rollup from the latest one
+func (storage *Storage) Commitments(ctx context.Context, rollup string, limit, offset int64) (response []smartrollup.Commitment, err error) {
+	query := storage.DB.NewSelect().
+		Model(&response).
+		Where("rollup = ?", rollup).
+		Limit(storage.GetPageSize(limit)).
+		Order("id desc")
+
+	if offset > 0 {
+		query.Offset(int(offset))
+	}
+	err = query.Scan(ctx)
+	return
+}
+
+// Commitment - returns published commitment with the hash
+func (storage *Storage) Commitment(ctx context.Context, rollup, hash string) (response smartrollup.Commitment, err error) {
+	err = storage.DB.NewSelect().
+		Model(&response).
+		Where("rollup = ?", rollup).
+		Where("hash = ?", hash).
+		Where("action = ?", smartrollup.CommitmentPublish).
+		Limit(1).
+		Scan(ctx)
+	return
+}
+
+// Stakers -
+func (storage *Storage) Stakers(ctx context.Context, rollup string) ([]smartrollup.Staker, error) {
+	var publications []smartrollup.Publications
+	if err := storage.DB.NewSelect().
+		Model((*smartrollup.Commitment)(nil)).
+		ColumnExpr("staker, count(*) as count, min(level) as first_level, max(level) as last_level").
+		ColumnExpr("(array_agg(hash order by id desc))[1] as last_commitment").
+		Where("rollup = ?", rollup).
+		Where("action = ?", smartrollup.CommitmentPublish).
+		Group("staker").
+		Scan(ctx, &publications); err != nil {
+		return nil, err
+	}
+
+	var lost []smartrollup.GameMove
+	if err := storage.DB.NewSelect().
+		Model(&lost).
+		Where("rollup = ?", rollup).
+		Where("status = ?", smartrollup.GameLoser).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	var recoveries []smartrollup.BondRecovery
+	if err := storage.DB.NewSelect().
+		Model(&recoveries).
+		Where("rollup = ?", rollup).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return smartrollup.NewStakers(publications, lost, recoveries), nil
+}
+
+// Games -
+func (storage *Storage) Games(ctx context.Context, rollup string) ([]smartrollup.Game, error) {
+	var moves []smartrollup.GameMove
+	if err := storage.DB.NewSelect().
+		Model(&moves).
+		Column("level", "rollup", "player", "opponent", "player_commitment", "opponent_commitment", "status", "loser", "reason").
+		Where("rollup = ?", rollup).
+		Order("id asc").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return smartrollup.NewGames(moves), nil
+}
+
+// Messages - returns inbox messages targeted to the rollup from the latest one
+func (storage *Storage) Messages(ctx context.Context, rollup string, limit, offset int64) (response []smartrollup.Message, err error) {
+	query := storage.DB.NewSelect().
+		Model(&response).
+		Where("rollup = ?", rollup).
+		Limit(storage.GetPageSize(limit)).
+		Order("id desc")
+
+	if offset > 0 {
+		query.Offset(int(offset))
+	}
+	err = query.Scan(ctx)
+	return
+}
+
+// OutboxTransactions - returns transactions of outbox messages executed on L1 from the latest one
+func (storage *Storage) OutboxTransactions(ctx context.Context, rollup string, limit, offset int64) (response []smartrollup.OutboxTransaction, err error) {
+	query := storage.DB.NewSelect().
+		Model(&response).
+		Where("rollup = ?", rollup).
+		Limit(storage.GetPageSize(limit)).
+		Order("id desc")
+
+	if offset > 0 {
+		query.Offset(int(offset))
+	}
+	err = query.Scan(ctx)
+	return
+}
@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/schema"
+)
+
+// RollbackFunc - rolls back the state in the export transaction
+type RollbackFunc func(ctx context.Context, tx bun.Tx) error
+
+// Export - writes gzipped tar archive with chain tables of network database to `w`. Tables are read in one repeatable read
+// transaction, so the snapshot is consistent and corresponds to the last indexed block which is written to the manifest.
+// If `rollback` is set, it's called in the transaction before reading: the snapshot is made at the level the state is rolled back to
+// and changes are discarded at the end.
+func Export(ctx context.Context, db *bun.DB, network string, w io.Writer, rollback RollbackFunc) (Manifest, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  rollback == nil,
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if rollback != nil {
+		if err := rollback(ctx, tx); err != nil {
+			return Manifest{}, errors.Wrap(err, "rollback")
+		}
+	}
+
+	var state block.Block
+	if err := tx.NewSelect().Model(&state).Order("id desc").Limit(1).Relation("Protocol").Scan(ctx); err != nil {
+		return Manifest{}, errors.Wrap(err, "receiving last block")
+	}
+
+	manifest := Manifest{
+		Version:   FormatVersion,
+		Network:   network,
+		ChainID:   state.Protocol.ChainID,
+		Level:     state.Level,
+		Hash:      state.Hash,
+		Protocol:  state.Protocol.Hash,
+		CreatedAt: time.Now().UTC(),
+		Tables:    Schema(db.Dialect()),
+	}
+
+	// size of tar entry has to be known before its data, so tables are dumped to temporary files first
+	files := make([]*os.File, len(manifest.Tables))
+	defer func() {
+		for i := range files {
+			if files[i] != nil {
+				_ = files[i].Close()
+				_ = os.Remove(files[i].Name())
+			}
+		}
+	}()
+
+	for i, model := range chainModels() {
+		file, err := os.CreateTemp("", "bcd-snapshot-*")
+		if err != nil {
+			return Manifest{}, err
+		}
+		files[i] = file
+
+		table := db.Dialect().Tables().Get(reflect.TypeOf(model).Elem())
+		result, err := pgdriver.CopyTo(ctx, conn, file, copyToQuery(table))
+		if err != nil {
+			return Manifest{}, errors.Wrap(err, table.Name)
+		}
+		if manifest.Tables[i].Rows, err = result.RowsAffected(); err != nil {
+			return Manifest{}, errors.Wrap(err, table.Name)
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := writeEntry(archive, manifestName, bytes.NewReader(data), int64(len(data))); err != nil {
+		return Manifest{}, err
+	}
+
+	for i := range files {
+		info, err := files[i].Stat()
+		if err != nil {
+			return Manifest{}, err
+		}
+		if _, err := files[i].Seek(0, io.SeekStart); err != nil {
+			return Manifest{}, err
+		}
+		if err := writeEntry(archive, tablesDir+manifest.Tables[i].Name, files[i], info.Size()); err != nil {
+			return Manifest{}, errors.Wrap(err, manifest.Tables[i].Name)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return Manifest{}, err
+	}
+	if err := gz.Close(); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// copyToQuery - hypertables return only rows of the parent table on `COPY table TO`, so data is selected by query
+func copyToQuery(table *schema.Table) string {
+	var query bytes.Buffer
+	query.WriteString("COPY (SELECT ")
+	for i := range table.Fields {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(string(table.Fields[i].SQLName))
+	}
+	query.WriteString(" FROM ")
+	query.WriteString(string(table.SQLName))
+	query.WriteString(") TO STDOUT")
+	return query.String()
+}
+
+func writeEntry(archive *tar.Writer, name string, r io.Reader, size int64) error {
+	if err := archive.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := io.Copy(archive, r)
+	return err
+}
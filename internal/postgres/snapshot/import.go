@@ -0,0 +1,153 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/schema"
+)
+
+// Import - restores snapshot written by `Export` to the empty network database. Tables have to be created before.
+// `validate` is called with the manifest before data restoring, so the caller can check that the snapshot belongs to its chain.
+// All tables are restored in one transaction and sequences of identifiers are moved after the restored values.
+// Continuous aggregates are refreshed over the whole restored history after commit. Data is already imported then, so refresh failure is only logged:
+// the indexer refreshes aggregates when it reaches the head.
+func Import(ctx context.Context, db *bun.DB, r io.Reader, validate func(Manifest) error) (Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Manifest{}, errors.Wrap(err, "invalid snapshot archive")
+	}
+	defer gz.Close()
+	archive := tar.NewReader(gz)
+
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := manifest.Validate(Schema(db.Dialect())); err != nil {
+		return manifest, err
+	}
+	if validate != nil {
+		if err := validate(manifest); err != nil {
+			return manifest, err
+		}
+	}
+
+	count, err := db.NewSelect().Model((*block.Block)(nil)).Count(ctx)
+	if err != nil {
+		return manifest, err
+	}
+	if count > 0 {
+		return manifest, errors.New("database is not empty: snapshot can be imported only to the database without indexed blocks")
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return manifest, err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return manifest, err
+	}
+
+	if err := restore(ctx, conn, tx, archive, manifest); err != nil {
+		_ = tx.Rollback()
+		return manifest, err
+	}
+	if err := tx.Commit(); err != nil {
+		return manifest, err
+	}
+
+	if err := core.RefreshContinuousAggregates(ctx, db, time.Time{}); err != nil {
+		log.Err(err).Str("network", manifest.Network).Msg("refreshing aggregates")
+	}
+	return manifest, nil
+}
+
+func readManifest(archive *tar.Reader) (manifest Manifest, err error) {
+	header, err := archive.Next()
+	if err != nil {
+		return manifest, errors.Wrap(err, "reading manifest")
+	}
+	if header.Name != manifestName {
+		return manifest, errors.Errorf("invalid snapshot archive: the first entry is %s instead of %s", header.Name, manifestName)
+	}
+	err = json.NewDecoder(archive).Decode(&manifest)
+	return manifest, errors.Wrap(err, "decoding manifest")
+}
+
+func restore(ctx context.Context, conn bun.Conn, tx bun.Tx, archive *tar.Reader, manifest Manifest) error {
+	for i, model := range chainModels() {
+		table := tx.Dialect().Tables().Get(reflect.TypeOf(model).Elem())
+
+		header, err := archive.Next()
+		if err != nil {
+			return errors.Wrap(err, table.Name)
+		}
+		if header.Name != tablesDir+table.Name {
+			return errors.Errorf("unexpected archive entry %s: expected %s", header.Name, tablesDir+table.Name)
+		}
+
+		result, err := pgdriver.CopyFrom(ctx, conn, archive, copyFromQuery(table))
+		if err != nil {
+			return errors.Wrap(err, table.Name)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, table.Name)
+		}
+		if rows != manifest.Tables[i].Rows {
+			return errors.Errorf("%s: restored %d rows instead of %d", table.Name, rows, manifest.Tables[i].Rows)
+		}
+
+		if err := resetSequence(ctx, tx, table); err != nil {
+			return errors.Wrap(err, table.Name)
+		}
+	}
+	return nil
+}
+
+func copyFromQuery(table *schema.Table) string {
+	var query bytes.Buffer
+	query.WriteString("COPY ")
+	query.WriteString(string(table.SQLName))
+	query.WriteString(" (")
+	for i := range table.Fields {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(string(table.Fields[i].SQLName))
+	}
+	query.WriteString(") FROM STDIN")
+	return query.String()
+}
+
+// resetSequence - moves sequence of autoincrement identifier after the maximum restored value
+func resetSequence(ctx context.Context, tx bun.Tx, table *schema.Table) error {
+	for _, field := range table.Fields {
+		if !field.AutoIncrement {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`SELECT setval(pg_get_serial_sequence(?, ?), (SELECT COALESCE(MAX(?), 0) + 1 FROM ?), false)`,
+			table.Name, field.Name, field.SQLName, table.SQLName,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package snapshot
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
+	"github.com/baking-bad/bcdhub/internal/models/stats"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun/schema"
+)
+
+// FormatVersion - version of the archive layout. It has to be increased on every incompatible change of the layout.
+const FormatVersion = 2
+
+const (
+	manifestName = "manifest.json"
+	tablesDir    = "tables/"
+)
+
+// Manifest - description of the snapshot. It's the first entry of the archive.
+type Manifest struct {
+	Version   int       `json:"version"`
+	Network   string    `json:"network"`
+	ChainID   string    `json:"chain_id"`
+	Level     int64     `json:"level"`
+	Hash      string    `json:"hash"`
+	Protocol  string    `json:"protocol"`
+	CreatedAt time.Time `json:"created_at"`
+	Tables    []Table   `json:"tables"`
+}
+
+// Table - table of the snapshot
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    int64    `json:"rows"`
+}
+
+// chainModels - models of the tables written to the snapshot. Only data of the chain is exported: webhooks with their secrets
+// and deliveries belong to the instance, history of reorganizations and indexed rules of the filter are local to the instance too.
+func chainModels() []models.Model {
+	return []models.Model{
+		&protocol.Protocol{},
+		&block.Block{},
+		&account.Account{},
+		&bigmapaction.BigMapAction{},
+		&bigmapdiff.BigMapDiff{},
+		&bigmapdiff.BigMapState{},
+		&ticket.Ticket{},
+		&ticket.TicketUpdate{},
+		&ticket.Balance{},
+		&operation.Operation{},
+		&contract.GlobalConstant{},
+		&contract.Script{},
+		&contract.ScriptConstants{},
+		&contract.Contract{},
+		&migration.Migration{},
+		&smartrollup.SmartRollup{},
+		&smartrollup.Commitment{},
+		&smartrollup.GameMove{},
+		&smartrollup.BondRecovery{},
+		&smartrollup.Message{},
+		&smartrollup.OutboxTransaction{},
+		&stats.Stats{},
+		&token.Balance{},
+		&token.Transfer{},
+		&metadata.ContractMetadata{},
+		&metadata.TokenMetadata{},
+		&search.Entry{},
+	}
+}
+
+// Schema - returns tables of the current schema of network database written to the snapshot with their columns in order of models.
+func Schema(dialect schema.Dialect) []Table {
+	all := chainModels()
+	tables := make([]Table, 0, len(all))
+	for _, model := range all {
+		table := dialect.Tables().Get(reflect.TypeOf(model).Elem())
+		columns := make([]string, len(table.Fields))
+		for i := range table.Fields {
+			columns[i] = table.Fields[i].Name
+		}
+		tables = append(tables, Table{
+			Name:    table.Name,
+			Columns: columns,
+		})
+	}
+	return tables
+}
+
+// Validate - checks that the snapshot can be imported to the database with `tables` schema.
+func (m Manifest) Validate(tables []Table) error {
+	if m.Version != FormatVersion {
+		return errors.Errorf("unsupported snapshot version: %d (expected %d)", m.Version, FormatVersion)
+	}
+	if len(m.Tables) != len(tables) {
+		return errors.Errorf("snapshot contains %d tables instead of %d", len(m.Tables), len(tables))
+	}
+	for i := range tables {
+		if m.Tables[i].Name != tables[i].Name {
+			return errors.Errorf("unexpected table in snapshot: %s (expected %s)", m.Tables[i].Name, tables[i].Name)
+		}
+		if !slices.Equal(m.Tables[i].Columns, tables[i].Columns) {
+			return errors.Errorf("columns of table %s differ: %v (expected %v)", tables[i].Name, m.Tables[i].Columns, tables[i].Columns)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+func newDialect() *pgdialect.Dialect {
+	dialect := pgdialect.New()
+	dialect.Tables().Register(models.ManyToMany()...)
+	return dialect
+}
+
+func TestManifest_Validate(t *testing.T) {
+	tables := Schema(newDialect())
+	require.NotEmpty(t, tables)
+	require.Equal(t, "protocols", tables[0].Name)
+	for i := range tables {
+		require.NotContains(t, []string{"webhooks", "webhook_deliveries", "webhook_dead_letters", "reorgs", "filter_rules"}, tables[i].Name)
+	}
+
+	valid := Manifest{Version: FormatVersion, Tables: tables}
+	require.NoError(t, valid.Validate(tables))
+
+	tests := []struct {
+		name   string
+		modify func(m *Manifest)
+	}{
+		{
+			name:   "version",
+			modify: func(m *Manifest) { m.Version = FormatVersion + 1 },
+		}, {
+			name:   "missing table",
+			modify: func(m *Manifest) { m.Tables = m.Tables[1:] },
+		}, {
+			name: "table name",
+			modify: func(m *Manifest) {
+				m.Tables[0].Name = "unknown"
+			},
+		}, {
+			name: "columns",
+			modify: func(m *Manifest) {
+				m.Tables[0].Columns = m.Tables[0].Columns[1:]
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Manifest{Version: FormatVersion, Tables: Schema(newDialect())}
+			tt.modify(&m)
+			require.Error(t, m.Validate(tables))
+		})
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	manifest := Manifest{
+		Version: FormatVersion,
+		Network: "mainnet",
+		ChainID: "NetXdQprcVkpaWU",
+		Level:   100,
+		Hash:    "BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2",
+	}
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	require.NoError(t, writeEntry(archive, manifestName, bytes.NewReader(data), int64(len(data))))
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+
+	r, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	got, err := readManifest(tar.NewReader(r))
+	require.NoError(t, err)
+	require.Equal(t, manifest.Network, got.Network)
+	require.Equal(t, manifest.ChainID, got.ChainID)
+	require.Equal(t, manifest.Level, got.Level)
+	require.Equal(t, manifest.Hash, got.Hash)
+}
+
+func TestImport_InvalidArchive(t *testing.T) {
+	_, err := Import(context.Background(), nil, bytes.NewReader([]byte("not an archive")), nil)
+	require.Error(t, err)
+}
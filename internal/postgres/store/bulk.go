@@ -0,0 +1,345 @@
+package store
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/stats"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+// BulkStore - accumulates stores of consecutive blocks and saves them in one transaction. Operations, big map diffs, big map actions,
+// ticket updates, token transfers and smart rollup entities are inserted by `COPY FROM`. Accounts, tickets and ticket balances of all blocks
+// are merged in the same way as `AddAccounts`, `AddTickets` and `AddTicketBalances` do it within a block and are upserted once per batch:
+// accounts are passed by `COPY FROM` too.
+type BulkStore struct {
+	stores []*Store
+	size   int
+
+	stats stats.Repository
+	db    *bun.DB
+}
+
+// NewBulkStore - creates bulk store which is full after `size` blocks
+func NewBulkStore(db *bun.DB, statsRepo stats.Repository, size int) *BulkStore {
+	if size < 1 {
+		size = 1
+	}
+	return &BulkStore{
+		stores: make([]*Store, 0, size),
+		size:   size,
+		stats:  statsRepo,
+		db:     db,
+	}
+}
+
+// Add - adds store of the next block to the batch. Returns true if the batch is full and has to be flushed.
+func (bs *BulkStore) Add(store *Store) bool {
+	bs.stores = append(bs.stores, store)
+	return len(bs.stores) >= bs.size
+}
+
+// Len - count of blocks in the batch
+func (bs *BulkStore) Len() int {
+	return len(bs.stores)
+}
+
+// Flush - saves all blocks of the batch and resets it. Returns saved blocks in order of levels.
+func (bs *BulkStore) Flush(ctx context.Context) ([]*block.Block, error) {
+	if len(bs.stores) == 0 {
+		return nil, nil
+	}
+
+	stats, err := bs.stats.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := core.NewCopyTransaction(ctx, bs.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bs.save(ctx, tx, stats.ID); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*block.Block, len(bs.stores))
+	for i := range bs.stores {
+		blocks[i] = bs.stores[i].Block
+	}
+	bs.stores = make([]*Store, 0, bs.size)
+	return blocks, nil
+}
+
+func (bs *BulkStore) save(ctx context.Context, tx core.Transaction, statsID int64) error {
+	blocks := make([]*block.Block, 0, len(bs.stores))
+	for i := range bs.stores {
+		if bs.stores[i].Block != nil {
+			blocks = append(blocks, bs.stores[i].Block)
+		}
+	}
+	if len(blocks) > 0 {
+		if err := tx.Save(ctx, &blocks); err != nil {
+			return errors.Wrap(err, "saving blocks")
+		}
+	}
+
+	// accounts, tickets and balances of all blocks are saved by the merged store
+	merged := NewStore(bs.db, bs.stats)
+	merged.Accounts = mergeAccounts(bs.stores)
+	merged.Tickets = mergeTickets(bs.stores)
+	merged.TicketBalances = mergeTicketBalances(bs.stores)
+
+	if err := merged.copyAccounts(ctx, tx); err != nil {
+		return errors.Wrap(err, "saving accounts")
+	}
+
+	if err := merged.saveTickets(ctx, tx); err != nil {
+		return errors.Wrap(err, "saving tickets")
+	}
+
+	if err := merged.saveTicketBalances(ctx, tx); err != nil {
+		return errors.Wrap(err, "saving ticket balances")
+	}
+
+	for i := range bs.stores {
+		bs.stores[i].accIds = merged.accIds
+		bs.stores[i].ticketIds = merged.ticketIds
+	}
+
+	if err := bs.saveOperations(ctx, tx); err != nil {
+		return errors.Wrap(err, "saving operations")
+	}
+
+	blockStats := stats.Stats{ID: statsID}
+	for _, store := range bs.stores {
+		if err := store.saveWebhookDeliveries(ctx, tx); err != nil {
+			return errors.Wrap(err, "saving webhook deliveries")
+		}
+
+		if err := store.saveContracts(ctx, tx); err != nil {
+			return errors.Wrap(err, "saving contracts")
+		}
+
+		if err := store.saveMigrations(ctx, tx); err != nil {
+			return errors.Wrap(err, "saving migrations")
+		}
+
+		if err := tx.BigMapStates(ctx, store.bigMapStates()...); err != nil {
+			return errors.Wrap(err, "saving bigmap states")
+		}
+
+		if err := tx.GlobalConstants(ctx, store.GlobalConstants...); err != nil {
+			return errors.Wrap(err, "saving global constants")
+		}
+
+		if err := tx.ContractMetadata(ctx, store.contractMetadata()...); err != nil {
+			return errors.Wrap(err, "saving contract metadata")
+		}
+
+		if err := tx.TokenMetadata(ctx, store.tokenMetadata()...); err != nil {
+			return errors.Wrap(err, "saving token metadata")
+		}
+
+		if err := store.saveSmartRollups(ctx, tx); err != nil {
+			return errors.Wrap(err, "saving smart rollups")
+		}
+
+		if err := tx.SearchEntries(ctx, store.searchEntries()...); err != nil {
+			return errors.Wrap(err, "saving search entries")
+		}
+
+		addStats(&blockStats, store.Stats)
+	}
+
+	if err := tx.UpdateStats(ctx, blockStats); err != nil {
+		return errors.Wrap(err, "saving stats")
+	}
+
+	return nil
+}
+
+func (bs *BulkStore) saveOperations(ctx context.Context, tx core.Transaction) error {
+	operations := make([]*operation.Operation, 0)
+	for _, store := range bs.stores {
+		for i := range store.Operations {
+			if err := store.setOperationAccountsId(store.Operations[i]); err != nil {
+				return err
+			}
+		}
+		operations = append(operations, store.Operations...)
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	ids, err := tx.NextIDs(ctx, &operation.Operation{}, len(operations))
+	if err != nil {
+		return errors.Wrap(err, "reserving operation identifiers")
+	}
+	if len(ids) != len(operations) {
+		return errors.Errorf("reserved %d operation identifiers instead of %d", len(ids), len(operations))
+	}
+	for i := range operations {
+		operations[i].ID = ids[i]
+	}
+
+	if err := tx.CopyFrom(ctx, &operations, true); err != nil {
+		return err
+	}
+
+	var children operationEntities
+	for _, store := range bs.stores {
+		entities, err := store.operationChildren()
+		if err != nil {
+			return err
+		}
+		children.bigMapDiffs = append(children.bigMapDiffs, entities.bigMapDiffs...)
+		children.bigMapActions = append(children.bigMapActions, entities.bigMapActions...)
+		children.ticketUpdates = append(children.ticketUpdates, entities.ticketUpdates...)
+		children.transfers = append(children.transfers, entities.transfers...)
+		children.commitments = append(children.commitments, entities.commitments...)
+		children.gameMoves = append(children.gameMoves, entities.gameMoves...)
+		children.recoveries = append(children.recoveries, entities.recoveries...)
+		children.messages = append(children.messages, entities.messages...)
+		children.outbox = append(children.outbox, entities.outbox...)
+	}
+
+	if err := tx.CopyFrom(ctx, &children.bigMapDiffs, false); err != nil {
+		return errors.Wrap(err, "saving bigmap diffs")
+	}
+	if err := tx.CopyFrom(ctx, &children.bigMapActions, false); err != nil {
+		return errors.Wrap(err, "saving bigmap actions")
+	}
+	if err := tx.CopyFrom(ctx, &children.ticketUpdates, false); err != nil {
+		return errors.Wrap(err, "saving ticket updates")
+	}
+	if err := tx.CopyFrom(ctx, &children.transfers, false); err != nil {
+		return errors.Wrap(err, "saving token transfers")
+	}
+	if err := saveTokenBalances(ctx, tx, children.transfers); err != nil {
+		return errors.Wrap(err, "saving token balances")
+	}
+	if err := tx.CopyFrom(ctx, &children.commitments, false); err != nil {
+		return errors.Wrap(err, "saving smart rollup commitments")
+	}
+	if err := tx.CopyFrom(ctx, &children.gameMoves, false); err != nil {
+		return errors.Wrap(err, "saving smart rollup game moves")
+	}
+	if err := tx.CopyFrom(ctx, &children.recoveries, false); err != nil {
+		return errors.Wrap(err, "saving smart rollup bond recoveries")
+	}
+	if err := tx.CopyFrom(ctx, &children.messages, false); err != nil {
+		return errors.Wrap(err, "saving smart rollup messages")
+	}
+	if err := tx.CopyFrom(ctx, &children.outbox, false); err != nil {
+		return errors.Wrap(err, "saving smart rollup outbox transactions")
+	}
+	return nil
+}
+
+// copyAccounts - upserts accounts through `COPY FROM` and remembers their identifiers
+func (store *Store) copyAccounts(ctx context.Context, tx core.Transaction) error {
+	arr := make([]*account.Account, 0, len(store.Accounts))
+	for _, acc := range store.Accounts {
+		if acc.IsEmpty() {
+			continue
+		}
+		arr = append(arr, acc)
+	}
+
+	if err := tx.CopyAccounts(ctx, arr...); err != nil {
+		return err
+	}
+
+	for i := range arr {
+		store.accIds[arr[i].Address] = arr[i].ID
+	}
+	return nil
+}
+
+// mergeAccounts - merges accounts of the blocks: counters are summed up, the first level and the last action are kept
+func mergeAccounts(stores []*Store) map[string]*account.Account {
+	merged := make(map[string]*account.Account)
+	for _, store := range stores {
+		for address, acc := range store.Accounts {
+			if acc.IsEmpty() {
+				continue
+			}
+			m, ok := merged[address]
+			if !ok {
+				copied := *acc
+				merged[address] = &copied
+				continue
+			}
+			m.OperationsCount += acc.OperationsCount
+			m.EventsCount += acc.EventsCount
+			m.MigrationsCount += acc.MigrationsCount
+			m.TicketUpdatesCount += acc.TicketUpdatesCount
+			if acc.LastAction.After(m.LastAction) {
+				m.LastAction = acc.LastAction
+			}
+		}
+	}
+	return merged
+}
+
+// mergeTickets - merges tickets of the blocks: updates counters are summed up, the first level is kept
+func mergeTickets(stores []*Store) map[string]*ticket.Ticket {
+	merged := make(map[string]*ticket.Ticket)
+	for _, store := range stores {
+		for hash, t := range store.Tickets {
+			m, ok := merged[hash]
+			if !ok {
+				copied := *t
+				merged[hash] = &copied
+				continue
+			}
+			m.UpdatesCount += t.UpdatesCount
+		}
+	}
+	return merged
+}
+
+// mergeTicketBalances - merges ticket balances of the blocks: amounts are summed up
+func mergeTicketBalances(stores []*Store) map[string]*ticket.Balance {
+	merged := make(map[string]*ticket.Balance)
+	for _, store := range stores {
+		for key, balance := range store.TicketBalances {
+			if m, ok := merged[key]; ok {
+				m.Amount = m.Amount.Add(balance.Amount)
+			} else {
+				copied := *balance
+				merged[key] = &copied
+			}
+		}
+	}
+	return merged
+}
+
+func addStats(dst *stats.Stats, src stats.Stats) {
+	dst.ContractsCount += src.ContractsCount
+	dst.SmartRollupsCount += src.SmartRollupsCount
+	dst.GlobalConstantsCount += src.GlobalConstantsCount
+	dst.OperationsCount += src.OperationsCount
+	dst.EventsCount += src.EventsCount
+	dst.TransactionsCount += src.TransactionsCount
+	dst.OriginationsCount += src.OriginationsCount
+	dst.SrOriginationsCount += src.SrOriginationsCount
+	dst.SrExecutesCount += src.SrExecutesCount
+	dst.RegisterGlobalConstantCount += src.RegisterGlobalConstantCount
+	dst.TransferTicketsCount += src.TransferTicketsCount
+	dst.IncreasePaidStorageCount += src.IncreasePaidStorageCount
+	dst.StakingCount += src.StakingCount
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeAccounts(t *testing.T) {
+	first := NewStore(nil, nil)
+	first.AddAccounts(
+		account.Account{Address: "tz1", Type: types.AccountTypeTz, Level: 1, OperationsCount: 2, LastAction: time.Unix(100, 0)},
+		account.Account{Address: "KT1", Type: types.AccountTypeContract, Level: 1, EventsCount: 1, LastAction: time.Unix(100, 0)},
+		account.Account{},
+	)
+	second := NewStore(nil, nil)
+	second.AddAccounts(
+		account.Account{Address: "tz1", Type: types.AccountTypeTz, Level: 2, OperationsCount: 3, TicketUpdatesCount: 1, LastAction: time.Unix(200, 0)},
+	)
+
+	merged := mergeAccounts([]*Store{first, second})
+	require.Len(t, merged, 2)
+	require.EqualValues(t, 1, merged["tz1"].Level)
+	require.EqualValues(t, 5, merged["tz1"].OperationsCount)
+	require.EqualValues(t, 1, merged["tz1"].TicketUpdatesCount)
+	require.Equal(t, time.Unix(200, 0), merged["tz1"].LastAction)
+	require.EqualValues(t, 1, merged["KT1"].EventsCount)
+
+	// stores of the blocks are not changed
+	require.EqualValues(t, 2, first.Accounts["tz1"].OperationsCount)
+}
+
+func TestMergeTicketsAndBalances(t *testing.T) {
+	ticketer := account.Account{Address: "KT1", Type: types.AccountTypeContract}
+	tkt := ticket.Ticket{Ticketer: ticketer, ContentType: []byte(`{"prim":"unit"}`), Content: []byte(`{"prim":"Unit"}`), Level: 1, UpdatesCount: 1}
+	owner := account.Account{Address: "tz1", Type: types.AccountTypeTz}
+
+	first := NewStore(nil, nil)
+	first.AddTickets(tkt)
+	first.AddTicketBalances(ticket.Balance{Ticket: tkt, Account: owner, Amount: decimal.NewFromInt(10)})
+
+	tkt.Level = 2
+	tkt.UpdatesCount = 2
+	second := NewStore(nil, nil)
+	second.AddTickets(tkt)
+	second.AddTicketBalances(ticket.Balance{Ticket: tkt, Account: owner, Amount: decimal.NewFromInt(-3)})
+
+	tickets := mergeTickets([]*Store{first, second})
+	require.Len(t, tickets, 1)
+	merged := tickets[tkt.GetHash()]
+	require.NotNil(t, merged)
+	require.EqualValues(t, 1, merged.Level)
+	require.EqualValues(t, 3, merged.UpdatesCount)
+
+	balances := mergeTicketBalances([]*Store{first, second})
+	require.Len(t, balances, 1)
+	for _, balance := range balances {
+		require.True(t, decimal.NewFromInt(7).Equal(balance.Amount))
+	}
+}
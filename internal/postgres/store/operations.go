@@ -24,6 +24,10 @@ func (store *Store) AddOperations(operations ...*operation.Operation) {
 			store.Stats.RegisterGlobalConstantCount += 1
 		case types.OperationKindSrExecuteOutboxMessage:
 			store.Stats.SrExecutesCount += 1
+		case types.OperationKindIncreasePaidStorage:
+			store.Stats.IncreasePaidStorageCount += 1
+		case types.OperationKindStake, types.OperationKindUnstake, types.OperationKindFinalizeUnstake, types.OperationKindSetDelegateParameters:
+			store.Stats.StakingCount += 1
 		}
 	}
 }
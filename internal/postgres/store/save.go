@@ -1,7 +1,9 @@
 package store
 
 import (
+	"bytes"
 	"context"
+	"time"
 
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/account"
@@ -9,7 +11,10 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/pkg/errors"
 )
@@ -27,8 +32,10 @@ func (store *Store) Save(ctx context.Context) error {
 		return err
 	}
 
-	if err := tx.Block(ctx, store.Block); err != nil {
-		return errors.Wrap(err, "saving block")
+	if !store.blockSaved {
+		if err := tx.Block(ctx, store.Block); err != nil {
+			return errors.Wrap(err, "saving block")
+		}
 	}
 
 	if err := store.saveAccounts(ctx, tx); err != nil {
@@ -47,6 +54,10 @@ func (store *Store) Save(ctx context.Context) error {
 		return errors.Wrap(err, "saving operations")
 	}
 
+	if err := store.saveWebhookDeliveries(ctx, tx); err != nil {
+		return errors.Wrap(err, "saving webhook deliveries")
+	}
+
 	if err := store.saveContracts(ctx, tx); err != nil {
 		return errors.Wrap(err, "saving contracts")
 	}
@@ -63,10 +74,22 @@ func (store *Store) Save(ctx context.Context) error {
 		return errors.Wrap(err, "saving bigmap states")
 	}
 
+	if err := tx.ContractMetadata(ctx, store.contractMetadata()...); err != nil {
+		return errors.Wrap(err, "saving contract metadata")
+	}
+
+	if err := tx.TokenMetadata(ctx, store.tokenMetadata()...); err != nil {
+		return errors.Wrap(err, "saving token metadata")
+	}
+
 	if err := store.saveSmartRollups(ctx, tx); err != nil {
 		return errors.Wrap(err, "saving smart rollups")
 	}
 
+	if err := tx.SearchEntries(ctx, store.searchEntries()...); err != nil {
+		return errors.Wrap(err, "saving search entries")
+	}
+
 	if err := tx.UpdateStats(ctx, store.Stats); err != nil {
 		return errors.Wrap(err, "saving stats")
 	}
@@ -198,34 +221,97 @@ func (store *Store) saveOperations(ctx context.Context, tx models.Transaction) e
 		return errors.Wrap(err, "saving operations")
 	}
 
-	var (
-		bigMapDiffs   = make([]*bigmapdiff.BigMapDiff, 0)
-		bigMapActions = make([]*bigmapaction.BigMapAction, 0)
-		ticketUpdates = make([]*ticket.TicketUpdate, 0)
-	)
+	children, err := store.operationChildren()
+	if err != nil {
+		return err
+	}
+	return saveOperationEntities(ctx, tx, children)
+}
+
+// saveOperationEntities - saves entities of the saved operations
+func saveOperationEntities(ctx context.Context, tx models.Transaction, children operationEntities) error {
+	if err := tx.BigMapDiffs(ctx, children.bigMapDiffs...); err != nil {
+		return errors.Wrap(err, "saving bigmap diffs")
+	}
+	if err := tx.BigMapActions(ctx, children.bigMapActions...); err != nil {
+		return errors.Wrap(err, "saving bigmap actions")
+	}
+	if err := tx.TickerUpdates(ctx, children.ticketUpdates...); err != nil {
+		return errors.Wrap(err, "saving ticket updates")
+	}
+	if err := tx.TokenTransfers(ctx, children.transfers...); err != nil {
+		return errors.Wrap(err, "saving token transfers")
+	}
+	if err := saveTokenBalances(ctx, tx, children.transfers); err != nil {
+		return errors.Wrap(err, "saving token balances")
+	}
+	if err := tx.SmartRollupCommitments(ctx, children.commitments...); err != nil {
+		return errors.Wrap(err, "saving smart rollup commitments")
+	}
+	if err := tx.SmartRollupGameMoves(ctx, children.gameMoves...); err != nil {
+		return errors.Wrap(err, "saving smart rollup game moves")
+	}
+	if err := tx.SmartRollupRecoveries(ctx, children.recoveries...); err != nil {
+		return errors.Wrap(err, "saving smart rollup bond recoveries")
+	}
+	if err := tx.SmartRollupMessages(ctx, children.messages...); err != nil {
+		return errors.Wrap(err, "saving smart rollup messages")
+	}
+	if err := tx.SmartRollupOutbox(ctx, children.outbox...); err != nil {
+		return errors.Wrap(err, "saving smart rollup outbox transactions")
+	}
+	return nil
+}
+
+// operationEntities - entities of the block's operations which are inserted after operations
+type operationEntities struct {
+	bigMapDiffs   []*bigmapdiff.BigMapDiff
+	bigMapActions []*bigmapaction.BigMapAction
+	ticketUpdates []*ticket.TicketUpdate
+	transfers     []*token.Transfer
+	commitments   []*smartrollup.Commitment
+	gameMoves     []*smartrollup.GameMove
+	recoveries    []*smartrollup.BondRecovery
+	messages      []*smartrollup.Message
+	outbox        []*smartrollup.OutboxTransaction
+}
+
+// operationChildren - sets identifiers of saved operations and accounts to the operations' entities and collects them
+func (store *Store) operationChildren() (operationEntities, error) {
+	children := operationEntities{
+		bigMapDiffs:   make([]*bigmapdiff.BigMapDiff, 0),
+		bigMapActions: make([]*bigmapaction.BigMapAction, 0),
+		ticketUpdates: make([]*ticket.TicketUpdate, 0),
+		transfers:     make([]*token.Transfer, 0),
+		commitments:   make([]*smartrollup.Commitment, 0),
+		gameMoves:     make([]*smartrollup.GameMove, 0),
+		recoveries:    make([]*smartrollup.BondRecovery, 0),
+		messages:      make([]*smartrollup.Message, 0),
+		outbox:        make([]*smartrollup.OutboxTransaction, 0),
+	}
 
 	for _, operation := range store.Operations {
 		for j := range operation.BigMapDiffs {
 			operation.BigMapDiffs[j].OperationID = operation.ID
 		}
-		bigMapDiffs = append(bigMapDiffs, operation.BigMapDiffs...)
+		children.bigMapDiffs = append(children.bigMapDiffs, operation.BigMapDiffs...)
 
 		for j := range operation.BigMapActions {
 			operation.BigMapActions[j].OperationID = operation.ID
 		}
-		bigMapActions = append(bigMapActions, operation.BigMapActions...)
+		children.bigMapActions = append(children.bigMapActions, operation.BigMapActions...)
 
 		for j, update := range operation.TicketUpdates {
 			if id, ok := store.getAccountId(update.Account); ok {
 				operation.TicketUpdates[j].AccountId = id
 			} else {
-				return errors.Errorf("unknown ticket update account: %s", update.Account.Address)
+				return children, errors.Errorf("unknown ticket update account: %s", update.Account.Address)
 			}
 
 			if id, ok := store.getAccountId(update.Ticket.Ticketer); ok {
 				operation.TicketUpdates[j].Ticket.TicketerID = id
 			} else {
-				return errors.Errorf("unknown ticket update ticketer account: %s", update.Ticket.Ticketer.Address)
+				return children, errors.Errorf("unknown ticket update ticketer account: %s", update.Ticket.Ticketer.Address)
 			}
 
 			operation.TicketUpdates[j].OperationId = operation.ID
@@ -234,7 +320,7 @@ func (store *Store) saveOperations(ctx context.Context, tx models.Transaction) e
 			if id, ok := store.ticketIds[hash]; ok {
 				operation.TicketUpdates[j].TicketId = id
 			} else {
-				return errors.Errorf("unknown ticket: ticketer_id=%d content_type=%s content=%s",
+				return children, errors.Errorf("unknown ticket: ticketer_id=%d content_type=%s content=%s",
 					operation.TicketUpdates[j].Ticket.TicketerID,
 					operation.TicketUpdates[j].Ticket.ContentType,
 					operation.TicketUpdates[j].Ticket.Content,
@@ -242,19 +328,83 @@ func (store *Store) saveOperations(ctx context.Context, tx models.Transaction) e
 			}
 		}
 
-		ticketUpdates = append(ticketUpdates, operation.TicketUpdates...)
+		children.ticketUpdates = append(children.ticketUpdates, operation.TicketUpdates...)
+
+		for j := range operation.TokenTransfers {
+			operation.TokenTransfers[j].OperationId = operation.ID
+		}
+		children.transfers = append(children.transfers, operation.TokenTransfers...)
+
+		for j := range operation.SmartRollupCommitments {
+			operation.SmartRollupCommitments[j].OperationId = operation.ID
+		}
+		children.commitments = append(children.commitments, operation.SmartRollupCommitments...)
+
+		for j := range operation.SmartRollupGameMoves {
+			operation.SmartRollupGameMoves[j].OperationId = operation.ID
+		}
+		children.gameMoves = append(children.gameMoves, operation.SmartRollupGameMoves...)
+
+		for j := range operation.SmartRollupRecoveries {
+			operation.SmartRollupRecoveries[j].OperationId = operation.ID
+		}
+		children.recoveries = append(children.recoveries, operation.SmartRollupRecoveries...)
+
+		for j := range operation.SmartRollupMessages {
+			operation.SmartRollupMessages[j].OperationId = operation.ID
+		}
+		children.messages = append(children.messages, operation.SmartRollupMessages...)
+
+		for j, tx := range operation.SmartRollupOutbox {
+			operation.SmartRollupOutbox[j].OperationId = operation.ID
+			if tx.Nonce != nil {
+				operation.SmartRollupOutbox[j].InternalOperationId = store.internalOperationId(operation, *tx.Nonce)
+			}
+		}
+		children.outbox = append(children.outbox, operation.SmartRollupOutbox...)
 	}
 
-	if err := tx.BigMapDiffs(ctx, bigMapDiffs...); err != nil {
-		return errors.Wrap(err, "saving bigmap diffs")
+	return children, nil
+}
+
+// internalOperationId - returns identifier of the internal operation with `nonce` made by `main` operation. Returns 0 if it's not found.
+func (store *Store) internalOperationId(main *operation.Operation, nonce int64) int64 {
+	for _, op := range store.Operations {
+		if op.Internal && op.Nonce != nil && *op.Nonce == nonce && op.Counter == main.Counter && bytes.Equal(op.Hash, main.Hash) {
+			return op.ID
+		}
 	}
-	if err := tx.BigMapActions(ctx, bigMapActions...); err != nil {
-		return errors.Wrap(err, "saving bigmap actions")
+	return 0
+}
+
+func saveTokenBalances(ctx context.Context, tx models.Transaction, transfers []*token.Transfer) error {
+	if len(transfers) == 0 {
+		return nil
 	}
-	if err := tx.TickerUpdates(ctx, ticketUpdates...); err != nil {
-		return errors.Wrap(err, "saving ticket updates")
+
+	return tx.TokenBalances(ctx, token.AggregateBalances(transfers)...)
+}
+
+func (store *Store) saveWebhookDeliveries(ctx context.Context, tx models.Transaction) error {
+	if len(store.Webhooks) == 0 || len(store.Operations) == 0 {
+		return nil
 	}
-	return nil
+
+	now := time.Now().UTC()
+	deliveries := make([]*webhook.Delivery, 0)
+	for i := range store.Operations {
+		for j := range store.Webhooks {
+			if !store.Webhooks[j].Match(store.Operations[i]) {
+				continue
+			}
+			delivery, err := webhook.NewDelivery(store.Webhooks[j], store.Operations[i], now)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return tx.WebhookDeliveries(ctx, deliveries...)
 }
 
 func (store *Store) setOperationAccountsId(operation *operation.Operation) error {
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/search"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+)
+
+// searchEntries - collects searchable values of the block: addresses, entrypoints and annotations of originated contracts, global constant hashes, event tags and metadata names.
+// Entries which are already indexed are skipped on insert, so every entry keeps the level where the value appeared first.
+func (store *Store) searchEntries() []*search.Entry {
+	if store.Block == nil {
+		return nil
+	}
+
+	entries := make(map[string]*search.Entry)
+	add := func(kind search.Kind, value, address string) {
+		if value == "" || address == "" {
+			return
+		}
+		entry := &search.Entry{
+			Kind:      kind,
+			Value:     value,
+			Address:   address,
+			Level:     store.Block.Level,
+			Timestamp: store.Block.Timestamp,
+		}
+		entries[entry.String()] = entry
+	}
+
+	for _, acc := range store.Accounts {
+		if acc.IsEmpty() {
+			continue
+		}
+		add(search.KindAccount, acc.Address, acc.Address)
+	}
+
+	for _, c := range store.Contracts {
+		script := latestScript(c)
+		if script == nil {
+			continue
+		}
+		for _, entrypoint := range script.Entrypoints {
+			add(search.KindEntrypoint, entrypoint, c.Account.Address)
+		}
+		for _, annotation := range script.Annotations {
+			add(search.KindAnnotation, annotation, c.Account.Address)
+		}
+	}
+
+	for _, constant := range store.GlobalConstants {
+		add(search.KindGlobalConstant, constant.Address, constant.Address)
+	}
+
+	for _, op := range store.Operations {
+		if op.Kind != types.OperationKindEvent || !op.Tag.Valid {
+			continue
+		}
+		add(search.KindEvent, op.Tag.String(), op.Source.Address)
+	}
+
+	for _, m := range store.ContractMeta {
+		add(search.KindContractMetadata, m.Name, m.Contract)
+	}
+
+	for _, m := range store.TokenMeta {
+		if m.Removed {
+			continue
+		}
+		add(search.KindTokenMetadata, m.Name, m.Contract)
+		add(search.KindTokenMetadata, m.Symbol, m.Contract)
+	}
+
+	arr := make([]*search.Entry, 0, len(entries))
+	for _, entry := range entries {
+		arr = append(arr, entry)
+	}
+	return arr
+}
+
+func latestScript(c *contract.Contract) *contract.Script {
+	switch {
+	case c.Jakarta.Hash != "":
+		return &c.Jakarta
+	case c.Babylon.Hash != "":
+		return &c.Babylon
+	case c.Alpha.Hash != "":
+		return &c.Alpha
+	default:
+		return nil
+	}
+}
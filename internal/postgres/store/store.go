@@ -7,11 +7,13 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/uptrace/bun"
 )
 
@@ -27,12 +29,18 @@ type Store struct {
 	Tickets         map[string]*ticket.Ticket
 	TicketBalances  map[string]*ticket.Balance
 	Accounts        map[string]*account.Account
+	ContractMeta    map[string]*metadata.ContractMetadata
+	TokenMeta       map[string]*metadata.TokenMetadata
 	Stats           stats.Stats
+	Webhooks        []webhook.Webhook
 
 	stats     stats.Repository
 	db        *bun.DB
 	accIds    map[string]int64
 	ticketIds map[string]int64
+
+	// blockSaved - block is indexed already, so it's not inserted on save
+	blockSaved bool
 }
 
 // NewStore -
@@ -47,6 +55,8 @@ func NewStore(db *bun.DB, statsRepo stats.Repository) *Store {
 		Tickets:         make(map[string]*ticket.Ticket),
 		TicketBalances:  make(map[string]*ticket.Balance),
 		Accounts:        make(map[string]*account.Account),
+		ContractMeta:    make(map[string]*metadata.ContractMetadata),
+		TokenMeta:       make(map[string]*metadata.TokenMetadata),
 		Stats:           stats.Stats{},
 		stats:           statsRepo,
 		db:              db,
@@ -55,10 +65,21 @@ func NewStore(db *bun.DB, statsRepo stats.Repository) *Store {
 	}
 }
 
+// SetWebhooks - sets webhooks which receive events for matched operations of the block
+func (store *Store) SetWebhooks(hooks []webhook.Webhook) {
+	store.Webhooks = hooks
+}
+
 func (store *Store) SetBlock(block *block.Block) {
 	store.Block = block
 }
 
+// SetSavedBlock - sets the block which is indexed already. Entities of the store are bound to it, but the block isn't inserted again.
+func (store *Store) SetSavedBlock(block *block.Block) {
+	store.Block = block
+	store.blockSaved = true
+}
+
 // AddBigMapStates -
 func (store *Store) AddBigMapStates(states ...*bigmapdiff.BigMapState) {
 	for i := range states {
@@ -155,6 +176,36 @@ func (store *Store) AddTicketBalances(balance ...ticket.Balance) {
 	}
 }
 
+// AddContractMetadata - the last metadata of the contract in the block wins
+func (store *Store) AddContractMetadata(metadata ...*metadata.ContractMetadata) {
+	for i := range metadata {
+		store.ContractMeta[metadata[i].Contract] = metadata[i]
+	}
+}
+
+func (store *Store) contractMetadata() []*metadata.ContractMetadata {
+	arr := make([]*metadata.ContractMetadata, 0, len(store.ContractMeta))
+	for _, m := range store.ContractMeta {
+		arr = append(arr, m)
+	}
+	return arr
+}
+
+// AddTokenMetadata - the last metadata of the token in the block wins
+func (store *Store) AddTokenMetadata(metadata ...*metadata.TokenMetadata) {
+	for i := range metadata {
+		store.TokenMeta[metadata[i].String()] = metadata[i]
+	}
+}
+
+func (store *Store) tokenMetadata() []*metadata.TokenMetadata {
+	arr := make([]*metadata.TokenMetadata, 0, len(store.TokenMeta))
+	for _, m := range store.TokenMeta {
+		arr = append(arr, m)
+	}
+	return arr
+}
+
 // ListContracts -
 func (store *Store) ListContracts() []*contract.Contract {
 	return store.Contracts
@@ -164,3 +215,8 @@ func (store *Store) ListContracts() []*contract.Contract {
 func (store *Store) ListOperations() []*operation.Operation {
 	return store.Operations
 }
+
+// ListSmartRollups -
+func (store *Store) ListSmartRollups() []*smartrollup.SmartRollup {
+	return store.SmartRollups
+}
@@ -138,3 +138,42 @@ func (s *StorageTestSuite) TestBigMapDiffsKeys() {
 	s.Require().NoError(err)
 	s.Require().Len(states, 2)
 }
+
+func (s *StorageTestSuite) TestBigMapDiffsStatesAtLevel() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	diffs, err := s.bigMapDiffs.StatesAtLevel(ctx, "KT1Pz65ssbPF7Zv9Dh7ggqUkgAYNSuJ9iia7", 33)
+	s.Require().NoError(err)
+	s.Require().Len(diffs, 9)
+
+	for i := range diffs {
+		s.Require().EqualValues("KT1Pz65ssbPF7Zv9Dh7ggqUkgAYNSuJ9iia7", diffs[i].Contract)
+		s.Require().LessOrEqual(diffs[i].Level, int64(33))
+	}
+	s.Require().EqualValues(6, diffs[0].Ptr)
+
+	diffs, err = s.bigMapDiffs.StatesAtLevel(ctx, "KT1Pz65ssbPF7Zv9Dh7ggqUkgAYNSuJ9iia7", 32)
+	s.Require().NoError(err)
+	s.Require().Empty(diffs)
+}
+
+func (s *StorageTestSuite) TestBigMapDiffsChanges() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	before, after, err := s.bigMapDiffs.Changes(ctx, "KT1Pz65ssbPF7Zv9Dh7ggqUkgAYNSuJ9iia7", bigmapdiff.Point{Level: 32}, bigmapdiff.Point{Level: 33})
+	s.Require().NoError(err)
+	s.Require().Empty(before)
+	s.Require().Len(after, 9)
+
+	for i := range after {
+		s.Require().EqualValues("KT1Pz65ssbPF7Zv9Dh7ggqUkgAYNSuJ9iia7", after[i].Contract)
+		s.Require().EqualValues(33, after[i].Level)
+	}
+
+	before, after, err = s.bigMapDiffs.Changes(ctx, "KT1Pz65ssbPF7Zv9Dh7ggqUkgAYNSuJ9iia7", bigmapdiff.Point{Level: 33}, bigmapdiff.Point{Level: 40})
+	s.Require().NoError(err)
+	s.Require().Empty(before)
+	s.Require().Empty(after)
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/account"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/postgres/store"
+	"github.com/shopspring/decimal"
+)
+
+func (s *StorageTestSuite) TestBulkStoreFlush() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	contract := account.Account{Address: "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", Type: types.AccountTypeContract, Level: 100, OperationsCount: 1}
+	sender := account.Account{Address: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", Type: types.AccountTypeTz, Level: 100, OperationsCount: 1}
+
+	tkt := ticket.Ticket{Ticketer: contract, ContentType: []byte(`{"prim":"unit"}`), Content: []byte(`{"prim":"Unit"}`), Level: 100, UpdatesCount: 1}
+	tkt.Hash = tkt.GetHash()
+
+	batch := store.NewBulkStore(s.storage.DB, s.stats, 10)
+	for level := int64(100000); level < 100002; level++ {
+		timestamp := time.Date(2024, 1, 1, 0, 0, int(level-100000), 0, time.UTC)
+		contract.LastAction = timestamp
+		sender.LastAction = timestamp
+
+		blockStore := store.NewStore(s.storage.DB, s.stats)
+		blockStore.SetBlock(&block.Block{Level: level, Hash: "block", Timestamp: timestamp, ProtocolID: 1})
+		blockStore.AddAccounts(contract, sender)
+		blockStore.AddTickets(tkt)
+		blockStore.Operations = append(blockStore.Operations, &operation.Operation{
+			Level:       level,
+			Timestamp:   timestamp,
+			Kind:        types.OperationKindTransaction,
+			Status:      types.OperationStatusApplied,
+			Hash:        []byte{0, 1, 2, byte(level)},
+			Source:      sender,
+			Destination: contract,
+			Entrypoint:  types.NewNullString(nil),
+			BigMapDiffs: []*bigmapdiff.BigMapDiff{
+				{Ptr: 100000, Key: types.Bytes(`{"string":"key"}`), KeyHash: "expru", Contract: contract.Address, Level: level, Timestamp: timestamp},
+			},
+			TicketUpdates: []*ticket.TicketUpdate{
+				{Ticket: tkt, Account: sender, Level: level, Timestamp: timestamp, Amount: decimal.NewFromInt(1)},
+			},
+		})
+
+		s.Require().False(batch.Add(blockStore))
+	}
+
+	saved, err := batch.Flush(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(saved, 2)
+	s.Require().Zero(batch.Len())
+
+	// counters of the existing account are increased, the new account is created
+	acc, err := s.accounts.Get(ctx, contract.Address)
+	s.Require().NoError(err)
+	s.Require().EqualValues(2, acc.ID)
+	s.Require().EqualValues(41, acc.OperationsCount)
+
+	acc, err = s.accounts.Get(ctx, sender.Address)
+	s.Require().NoError(err)
+	s.Require().EqualValues(2, acc.OperationsCount)
+	s.Require().EqualValues(100, acc.Level)
+
+	var diffs []bigmapdiff.BigMapDiff
+	err = s.storage.DB.NewSelect().Model(&diffs).Where("ptr = 100000").Order("level asc").Scan(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(diffs, 2)
+	s.Require().Positive(diffs[0].OperationID)
+
+	updates, err := s.ticketUpdates.UpdatesForOperation(ctx, diffs[1].OperationID)
+	s.Require().NoError(err)
+	s.Require().Len(updates, 1)
+	s.Require().Equal(acc.ID, updates[0].AccountId)
+	s.Require().Positive(updates[0].TicketId)
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/filter"
+)
+
+func (s *StorageTestSuite) TestFilterRules() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	storage := s.filterRules
+
+	rules, err := storage.List(ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(rules)
+
+	err = storage.Replace(ctx, []filter.Rule{
+		{Kind: filter.RuleKindScriptHash, Value: "hash"},
+		{Kind: filter.RuleKindTag, Value: "fa2"},
+	})
+	s.Require().NoError(err)
+
+	err = storage.Replace(ctx, []filter.Rule{
+		{Kind: filter.RuleKindTag, Value: "fa2"},
+	})
+	s.Require().NoError(err)
+
+	rules, err = storage.List(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(rules, 1)
+	s.Require().Equal(filter.RuleKindTag, rules[0].Kind)
+	s.Require().Equal("fa2", rules[0].Value)
+
+	err = storage.Replace(ctx, nil)
+	s.Require().NoError(err)
+
+	rules, err = storage.List(ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(rules)
+}
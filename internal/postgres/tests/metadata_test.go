@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
+)
+
+func (s *StorageTestSuite) TestContractMetadata() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	meta, err := s.metadata.ContractMetadata(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn")
+	s.Require().NoError(err)
+	s.Require().EqualValues(2, meta.ID)
+	s.Require().EqualValues(40, meta.Level)
+	s.Require().EqualValues("Test token", meta.Name)
+	s.Require().EqualValues("Test FA2 token", meta.Description)
+	s.Require().Equal([]string{"TZIP-012", "TZIP-016"}, meta.Interfaces)
+	s.Require().NotEmpty(meta.Views)
+}
+
+func (s *StorageTestSuite) TestContractMetadataNotFound() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := s.metadata.ContractMetadata(ctx, "KT1Ap287P1NzsnToSJdA4aqSNjPomRaHBZSr")
+	s.Require().Error(err)
+	s.Require().True(s.storage.IsRecordNotFound(err))
+}
+
+func (s *StorageTestSuite) TestTokenMetadata() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tokens, err := s.metadata.TokenMetadata(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", metadata.TokenMetadataRequest{
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(tokens, 2)
+
+	s.Require().EqualValues(2, tokens[0].ID)
+	s.Require().EqualValues("0", tokens[0].TokenId.String())
+	s.Require().EqualValues("TST", tokens[0].Symbol)
+	s.Require().EqualValues("Test token", tokens[0].Name)
+	s.Require().NotNil(tokens[0].Decimals)
+	s.Require().EqualValues(6, *tokens[0].Decimals)
+
+	s.Require().EqualValues("1", tokens[1].TokenId.String())
+	s.Require().EqualValues("NFT", tokens[1].Symbol)
+}
+
+func (s *StorageTestSuite) TestTokenMetadataOffset() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tokens, err := s.metadata.TokenMetadata(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", metadata.TokenMetadataRequest{
+		Limit:  10,
+		Offset: 1,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(tokens, 1)
+	s.Require().EqualValues("1", tokens[0].TokenId.String())
+}
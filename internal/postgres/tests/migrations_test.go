@@ -23,3 +23,24 @@ func (s *StorageTestSuite) TestMigrationGet() {
 	s.Require().EqualValues(2, m.Level)
 	s.Require().EqualValues(types.MigrationKindUpdate, m.Kind)
 }
+
+func (s *StorageTestSuite) TestMigrationLastStorage() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// table created before the column was added to the model
+	_, err := s.storage.DB.ExecContext(ctx, `ALTER TABLE migrations DROP COLUMN deffated_storage`)
+	s.Require().NoError(err)
+	s.Require().NoError(s.storage.InitDatabase(ctx))
+
+	// protocol migration indexed without storage
+	m, err := s.migrations.LastStorage(ctx, 1, 100)
+	s.Require().NoError(err)
+	s.Require().EqualValues(4, m.ID)
+	s.Require().Empty(m.DeffatedStorage)
+
+	// bootstrap migration doesn't change storage
+	_, err = s.migrations.LastStorage(ctx, 1, 1)
+	s.Require().Error(err)
+	s.Require().True(s.storage.IsRecordNotFound(err))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/testsuite"
 )
@@ -209,3 +210,94 @@ func (s *StorageTestSuite) TestOperationListEvents() {
 	s.Require().NotEmpty(operation.PayloadType)
 	s.Require().Equal(testsuite.MustHexDecode("3006fe3748e23bee8499ddd4ef69c3f910b1de0aa04080cc5be242b5123c1207"), operation.Hash)
 }
+
+func (s *StorageTestSuite) TestOperationListByAccount() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	operations, err := s.operations.ListByAccount(ctx, 125, 0, 3)
+	s.Require().NoError(err)
+	s.Require().Len(operations, 3)
+	s.Require().EqualValues(135, operations[0].ID)
+	s.Require().EqualValues(115, operations[1].ID)
+	s.Require().EqualValues(104, operations[2].ID)
+
+	for i := range operations {
+		s.Require().True(operations[i].SourceID == 125 || operations[i].DestinationID == 125)
+		s.Require().NotEmpty(operations[i].Source.Address)
+	}
+
+	operations, err = s.operations.ListByAccount(ctx, 125, 104, 10)
+	s.Require().NoError(err)
+	s.Require().Len(operations, 2)
+	s.Require().EqualValues(92, operations[0].ID)
+	s.Require().EqualValues(87, operations[1].ID)
+}
+
+func (s *StorageTestSuite) TestOperationSearch() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := operation.SearchRequest{
+		Kinds:      []types.OperationKind{types.OperationKindTransaction},
+		Statuses:   []types.OperationStatus{types.OperationStatusApplied},
+		Entrypoint: "do",
+		MinLevel:   40,
+		Size:       3,
+	}
+	operations, err := s.operations.Search(ctx, req)
+	s.Require().NoError(err)
+	s.Require().Len(operations, 3)
+	s.Require().EqualValues(117, operations[0].ID)
+	s.Require().EqualValues(116, operations[1].ID)
+	s.Require().EqualValues(114, operations[2].ID)
+	s.Require().Equal("KT1TYdptHAspE8JSB2XmzVrNze4gbCA2AuDN", operations[0].Destination.Address)
+
+	req.LastTimestamp = operations[2].Timestamp
+	req.LastID = operations[2].ID
+	operations, err = s.operations.Search(ctx, req)
+	s.Require().NoError(err)
+	s.Require().Len(operations, 3)
+	s.Require().EqualValues(106, operations[0].ID)
+	s.Require().EqualValues(105, operations[1].ID)
+	s.Require().EqualValues(103, operations[2].ID)
+}
+
+func (s *StorageTestSuite) TestOperationSearchByAccountAndAmount() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	operations, err := s.operations.Search(ctx, operation.SearchRequest{
+		Destination: "tz1eY5Aqa1kXDFoiebL28emyXFoneAoVg1zh",
+		MinAmount:   100,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(operations, 1)
+	s.Require().EqualValues(135, operations[0].ID)
+
+	operations, err = s.operations.Search(ctx, operation.SearchRequest{
+		Source:  "KT1TYdptHAspE8JSB2XmzVrNze4gbCA2AuDN",
+		ErrorID: "script_rejected",
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(operations)
+}
+
+func (s *StorageTestSuite) TestOperationLastStorage() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	op, err := s.operations.LastStorage(ctx, 2, 40)
+	s.Require().NoError(err)
+	s.Require().EqualValues(97, op.ID)
+	s.Require().EqualValues(40, op.Level)
+	s.Require().NotEmpty(op.DeffatedStorage)
+
+	op, err = s.operations.LastStorage(ctx, 145, 100)
+	s.Require().NoError(err)
+	s.Require().EqualValues(41, op.Level)
+
+	_, err = s.operations.LastStorage(ctx, 2, 1)
+	s.Require().Error(err)
+	s.Require().True(s.storage.IsRecordNotFound(err))
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/reorg"
+)
+
+func (s *StorageTestSuite) TestReorgsList() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, err := s.reorgs.List(ctx, 10, 0)
+	s.Require().NoError(err)
+	s.Require().Len(events, 2)
+
+	s.Require().EqualValues(2, events[0].ID)
+	s.Require().EqualValues(50, events[0].Level)
+	s.Require().EqualValues(2, events[0].Depth)
+	s.Require().EqualValues(52, events[0].OldHead)
+	s.Require().EqualValues(1, events[1].ID)
+
+	events, err = s.reorgs.List(ctx, 10, 1)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Require().EqualValues(1, events[0].ID)
+}
+
+func (s *StorageTestSuite) TestReorgsSave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event := reorg.Reorg{
+		DetectedAt: time.Now().UTC(),
+		Level:      60,
+		Depth:      3,
+		OldHash:    "old",
+		NewHash:    "new",
+		OldHead:    63,
+	}
+	err := s.reorgs.Save(ctx, &event)
+	s.Require().NoError(err)
+	s.Require().Positive(event.ID)
+
+	events, err := s.reorgs.List(ctx, 1, 0)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Require().Equal(event.ID, events[0].ID)
+	s.Require().Equal("new", events[0].NewHash)
+}
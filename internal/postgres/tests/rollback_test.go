@@ -10,7 +10,9 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/baking-bad/bcdhub/internal/postgres"
 	"github.com/baking-bad/bcdhub/internal/testsuite"
 	"github.com/shopspring/decimal"
@@ -340,6 +342,45 @@ func (s *StorageTestSuite) TestTicketBalancesRollback() {
 	s.Require().Len(balances, 1)
 }
 
+func (s *StorageTestSuite) TestGetTokenTransfers() {
+	saver, err := postgres.NewRollback(s.storage.DB)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	transfers, err := saver.GetTokenTransfers(ctx, 40)
+	s.Require().NoError(err)
+
+	err = saver.Commit()
+	s.Require().NoError(err)
+	s.Require().Len(transfers, 2)
+}
+
+func (s *StorageTestSuite) TestTokenBalancesRollback() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	saver, err := postgres.NewRollback(s.storage.DB)
+	s.Require().NoError(err)
+
+	err = saver.TokenBalances(ctx, &token.Balance{
+		Contract: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		Address:  "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA",
+		TokenId:  decimal.Zero,
+		Amount:   decimal.RequireFromString("100"),
+	})
+	s.Require().NoError(err)
+
+	err = saver.Commit()
+	s.Require().NoError(err)
+
+	var balances []token.Balance
+	err = s.storage.DB.NewSelect().Model(&balances).Where("amount > 0").Scan(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(balances, 1)
+}
+
 func (s *StorageTestSuite) TestDeleteTickets() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -375,3 +416,63 @@ func (s *StorageTestSuite) TestDeleteTicketBalances() {
 	s.Require().NoError(err)
 	s.Require().Len(balances, 0)
 }
+
+func (s *StorageTestSuite) TestRevertWebhookDeliveries() {
+	saver, err := postgres.NewRollback(s.storage.DB)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	count, err := saver.RevertWebhookDeliveries(ctx, 40)
+	s.Require().NoError(err)
+	s.Require().Equal(1, count)
+
+	err = saver.Commit()
+	s.Require().NoError(err)
+
+	var deliveries []webhook.Delivery
+	err = s.storage.DB.NewSelect().Model(&deliveries).Where("level = 40").Scan(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+
+	reverted := deliveries[0]
+	s.Require().Equal(webhook.EventReverted, reverted.Event)
+	s.Require().EqualValues(1, reverted.WebhookID)
+	s.Require().EqualValues(10, reverted.OperationID)
+	s.Require().False(reverted.Delivered)
+	s.Require().Zero(reverted.Attempts)
+	s.Require().JSONEq(`{"id":10}`, string(reverted.Payload))
+}
+
+func (s *StorageTestSuite) TestDryRunRollback() {
+	saver, report, err := postgres.NewDryRunRollback(s.storage.DB)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	count, err := saver.DeleteAll(ctx, (*block.Block)(nil), 47)
+	s.Require().NoError(err)
+	s.Require().EqualValues(1, count)
+
+	err = saver.DeleteBigMapState(ctx, bigmapdiff.BigMapState{ID: 54})
+	s.Require().NoError(err)
+
+	err = saver.Commit()
+	s.Require().NoError(err)
+
+	s.Require().Equal([]postgres.TableChanges{
+		{Table: "big_map_states", Deleted: 1},
+		{Table: "blocks", Deleted: 1},
+	}, report.Tables())
+
+	var block block.Block
+	err = s.storage.DB.NewSelect().Model(&block).Order("id desc").Limit(1).Scan(ctx)
+	s.Require().NoError(err)
+	s.Require().EqualValues(47, block.Level)
+
+	var state bigmapdiff.BigMapState
+	err = s.storage.DB.NewSelect().Model(&state).Where("id = 54").Scan(ctx)
+	s.Require().NoError(err)
+}
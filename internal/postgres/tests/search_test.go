@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/search"
+)
+
+func (s *StorageTestSuite) TestSearch() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	entries, err := s.search.Search(ctx, search.Request{
+		Query: "transfer",
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 4)
+	s.Require().EqualValues(2, entries[0].ID)
+	s.Require().EqualValues(6, entries[1].ID)
+	s.Require().EqualValues(3, entries[2].ID)
+	s.Require().EqualValues(4, entries[3].ID)
+}
+
+func (s *StorageTestSuite) TestSearchByKinds() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	entries, err := s.search.Search(ctx, search.Request{
+		Query:  "TRANSFER",
+		Kinds:  []search.Kind{search.KindEntrypoint},
+		Limit:  1,
+		Offset: 1,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().EqualValues(3, entries[0].ID)
+	s.Require().Equal(search.KindEntrypoint, entries[0].Kind)
+	s.Require().Equal("KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9", entries[0].Address)
+}
+
+func (s *StorageTestSuite) TestSearchEscapesPattern() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	entries, err := s.search.Search(ctx, search.Request{
+		Query: "do_",
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().EqualValues(3, entries[0].ID)
+
+	entries, err = s.search.Search(ctx, search.Request{
+		Query: "%",
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 2)
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/postgres/snapshot"
+	"github.com/uptrace/bun"
+)
+
+func (s *StorageTestSuite) TestSnapshotExport() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var buf bytes.Buffer
+	manifest, err := snapshot.Export(ctx, s.storage.DB, "mainnet", &buf, nil)
+	s.Require().NoError(err)
+	s.Require().NotZero(buf.Len())
+
+	s.Require().EqualValues(snapshot.FormatVersion, manifest.Version)
+	s.Require().EqualValues(47, manifest.Level)
+	s.Require().Equal("BLwSEbi7iNcW8Cu6wMzN93aHasWudPFL3An62k52SzfH4gHaXf4", manifest.Hash)
+	s.Require().Equal("NetXnHfVqm9iesp", manifest.ChainID)
+
+	for _, table := range manifest.Tables {
+		s.Require().NotEqual("webhooks", table.Name)
+		if table.Name == "blocks" {
+			s.Require().EqualValues(47, table.Rows)
+		}
+	}
+
+	var validated bool
+	_, err = snapshot.Import(ctx, s.storage.DB, &buf, func(m snapshot.Manifest) error {
+		validated = true
+		s.Require().Equal(manifest.Hash, m.Hash)
+		return nil
+	})
+	s.Require().Error(err)
+	s.Require().True(validated)
+}
+
+func (s *StorageTestSuite) TestSnapshotExportAtLevel() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var buf bytes.Buffer
+	manifest, err := snapshot.Export(ctx, s.storage.DB, "mainnet", &buf, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().Model((*block.Block)(nil)).Where("level > 40").Exec(ctx)
+		return err
+	})
+	s.Require().NoError(err)
+	s.Require().EqualValues(40, manifest.Level)
+
+	for _, table := range manifest.Tables {
+		if table.Name == "blocks" {
+			s.Require().EqualValues(40, table.Rows)
+		}
+	}
+
+	// changes of the rollback are discarded
+	count, err := s.storage.DB.NewSelect().Model((*block.Block)(nil)).Count(ctx)
+	s.Require().NoError(err)
+	s.Require().EqualValues(47, count)
+}
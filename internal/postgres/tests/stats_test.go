@@ -19,3 +19,19 @@ func (s *StorageTestSuite) TestRollbackStats() {
 	s.Require().EqualValues(2, stats.EventsCount)
 	s.Require().EqualValues(0, stats.SrOriginationsCount)
 }
+
+func (s *StorageTestSuite) TestStatsAddedColumns() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// table created before the columns were added to the model
+	_, err := s.storage.DB.ExecContext(ctx, `ALTER TABLE stats DROP COLUMN increase_paid_storage_count, DROP COLUMN staking_count`)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.storage.InitDatabase(ctx))
+
+	stats, err := s.stats.Get(ctx)
+	s.Require().NoError(err)
+	s.Require().EqualValues(120, stats.ContractsCount)
+	s.Require().EqualValues(0, stats.StakingCount)
+}
@@ -13,13 +13,19 @@ import (
 	"github.com/baking-bad/bcdhub/internal/postgres/contract"
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/baking-bad/bcdhub/internal/postgres/domains"
+	"github.com/baking-bad/bcdhub/internal/postgres/filter"
 	"github.com/baking-bad/bcdhub/internal/postgres/global_constant"
+	"github.com/baking-bad/bcdhub/internal/postgres/metadata"
 	"github.com/baking-bad/bcdhub/internal/postgres/migration"
 	"github.com/baking-bad/bcdhub/internal/postgres/operation"
 	"github.com/baking-bad/bcdhub/internal/postgres/protocol"
+	"github.com/baking-bad/bcdhub/internal/postgres/reorg"
+	"github.com/baking-bad/bcdhub/internal/postgres/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/postgres/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/postgres/stats"
 	"github.com/baking-bad/bcdhub/internal/postgres/ticket"
+	"github.com/baking-bad/bcdhub/internal/postgres/token"
+	"github.com/baking-bad/bcdhub/internal/postgres/webhook"
 	"github.com/dipdup-net/go-lib/database"
 	"github.com/go-testfixtures/testfixtures/v3"
 	"github.com/stretchr/testify/suite"
@@ -37,13 +43,19 @@ type StorageTestSuite struct {
 	blocks          *block.Storage
 	contracts       *contract.Storage
 	domains         *domains.Storage
+	filterRules     *filter.Storage
 	globalConstants *global_constant.Storage
+	metadata        *metadata.Storage
 	migrations      *migration.Storage
 	operations      *operation.Storage
 	protocols       *protocol.Storage
+	reorgs          *reorg.Storage
+	search          *search.Storage
 	smartRollups    *smartrollup.Storage
 	ticketUpdates   *ticket.Storage
 	stats           *stats.Storage
+	tokens          *token.Storage
+	webhooks        *webhook.Storage
 }
 
 // SetupSuite -
@@ -81,13 +93,19 @@ func (s *StorageTestSuite) SetupSuite() {
 	s.blocks = block.NewStorage(strg)
 	s.contracts = contract.NewStorage(strg)
 	s.domains = domains.NewStorage(strg)
+	s.filterRules = filter.NewStorage(strg)
 	s.globalConstants = global_constant.NewStorage(strg)
+	s.metadata = metadata.NewStorage(strg)
 	s.migrations = migration.NewStorage(strg)
 	s.operations = operation.NewStorage(strg)
 	s.protocols = protocol.NewStorage(strg)
+	s.reorgs = reorg.NewStorage(strg)
+	s.search = search.NewStorage(strg)
 	s.smartRollups = smartrollup.NewStorage(strg)
 	s.ticketUpdates = ticket.NewStorage(strg)
 	s.stats = stats.NewStorage(strg)
+	s.tokens = token.NewStorage(strg)
+	s.webhooks = webhook.NewStorage(strg)
 }
 
 // TearDownSuite -
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/testsuite"
+)
+
+func (s *StorageTestSuite) TestTokenBalances() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	balances, err := s.tokens.Balances(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", token.BalanceRequest{
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(balances, 3)
+
+	balance := balances[0]
+	s.Require().EqualValues("tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", balance.Address)
+	s.Require().EqualValues("0", balance.TokenId.String())
+	s.Require().EqualValues("900", balance.Amount.String())
+}
+
+func (s *StorageTestSuite) TestTokenBalancesWithFilters() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	balances, err := s.tokens.Balances(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", token.BalanceRequest{
+		Holder:              "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA",
+		TokenId:             testsuite.Ptr(uint64(0)),
+		WithoutZeroBalances: true,
+		Limit:               10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(balances, 1)
+	s.Require().EqualValues("100", balances[0].Amount.String())
+}
+
+func (s *StorageTestSuite) TestTokenTransfers() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	transfers, err := s.tokens.Transfers(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", token.TransfersRequest{
+		Account: "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA",
+		Limit:   10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(transfers, 1)
+
+	transfer := transfers[0]
+	s.Require().EqualValues(2, transfer.ID)
+	s.Require().EqualValues(102, transfer.OperationId)
+	s.Require().EqualValues("tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", transfer.From)
+	s.Require().EqualValues("tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA", transfer.To)
+	s.Require().EqualValues("100", transfer.Amount.String())
+}
+
+func (s *StorageTestSuite) TestTokenTransfersForOperation() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	transfers, err := s.tokens.TransfersForOperation(ctx, 104)
+	s.Require().NoError(err)
+	s.Require().Len(transfers, 1)
+	s.Require().Empty(transfers[0].From)
+	s.Require().EqualValues("1000", transfers[0].Amount.String())
+}
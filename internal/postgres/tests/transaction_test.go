@@ -7,11 +7,16 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/account"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
 	"github.com/baking-bad/bcdhub/internal/postgres/core"
 	"github.com/baking-bad/bcdhub/internal/testsuite"
 	"github.com/shopspring/decimal"
@@ -436,6 +441,224 @@ func (s *StorageTestSuite) TestTicketBalances() {
 	s.Require().Len(balances, 3)
 }
 
+func (s *StorageTestSuite) TestTokenBalancesSave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	err = tx.TokenBalances(ctx, &token.Balance{
+		Contract: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		Address:  "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA",
+		TokenId:  decimal.Zero,
+		Amount:   decimal.RequireFromString("17"),
+	}, &token.Balance{
+		Contract: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		Address:  "KT1SM849krq9FFxGWCZyc7X5GvAz8XnRmXnf",
+		TokenId:  decimal.Zero,
+		Amount:   decimal.RequireFromString("1"),
+	})
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+
+	var balances []token.Balance
+	err = s.storage.DB.NewSelect().Model(&balances).Where("token_id = 0").Order("amount desc").Scan(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(balances, 3)
+	s.Require().EqualValues("117", balances[1].Amount.String())
+}
+
+func (s *StorageTestSuite) TestContractMetadataSave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	err = tx.ContractMetadata(ctx, &metadata.ContractMetadata{
+		Contract:   "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		Level:      50,
+		Timestamp:  time.Now().UTC(),
+		URI:        "tezos-storage:content",
+		Name:       "New name",
+		Interfaces: []string{"TZIP-016"},
+		Raw:        []byte(`{"name":"New name","interfaces":["TZIP-016"]}`),
+	})
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+
+	meta, err := s.metadata.ContractMetadata(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn")
+	s.Require().NoError(err)
+	s.Require().EqualValues(50, meta.Level)
+	s.Require().EqualValues("New name", meta.Name)
+}
+
+func (s *StorageTestSuite) TestTokenMetadataSave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	err = tx.TokenMetadata(ctx, &metadata.TokenMetadata{
+		Contract:  "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		TokenId:   decimal.NewFromInt(1),
+		Level:     50,
+		Timestamp: time.Now().UTC(),
+		Removed:   true,
+	}, &metadata.TokenMetadata{
+		Contract:  "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		TokenId:   decimal.NewFromInt(3),
+		Level:     50,
+		Timestamp: time.Now().UTC(),
+		Symbol:    "NEW",
+		Name:      "New token",
+	})
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+
+	tokens, err := s.metadata.TokenMetadata(ctx, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", metadata.TokenMetadataRequest{
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(tokens, 2)
+	s.Require().EqualValues("0", tokens[0].TokenId.String())
+	s.Require().EqualValues("3", tokens[1].TokenId.String())
+	s.Require().EqualValues("NEW", tokens[1].Symbol)
+}
+
+func (s *StorageTestSuite) TestPendingMetadataSave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	err = tx.ContractMetadata(ctx, &metadata.ContractMetadata{
+		Contract:  "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		Level:     60,
+		Timestamp: time.Now().UTC(),
+		URI:       "https://example.com/metadata.json",
+		Pending:   true,
+		Attempts:  1,
+	})
+	s.Require().NoError(err)
+
+	err = tx.TokenMetadata(ctx, &metadata.TokenMetadata{
+		Contract:  "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		TokenId:   decimal.NewFromInt(5),
+		Level:     60,
+		Timestamp: time.Now().UTC(),
+		URI:       "ipfs://QmTokenMetadata",
+		Pending:   true,
+	})
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+
+	// records are received by the queries of the metadata resolver
+	contracts, err := s.metadata.PendingContractMetadata(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(contracts, 1)
+	s.Require().EqualValues(60, contracts[0].Level)
+	s.Require().EqualValues(1, contracts[0].Attempts)
+	s.Require().True(contracts[0].Pending)
+
+	tokens, err := s.metadata.PendingTokenMetadata(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(tokens, 1)
+	s.Require().EqualValues("5", tokens[0].TokenId.String())
+	s.Require().Equal("ipfs://QmTokenMetadata", tokens[0].URI)
+
+	// resolved record isn't pending anymore
+	contracts[0].Pending = false
+	contracts[0].Attempts = 2
+	s.Require().NoError(s.metadata.UpdateContractMetadata(ctx, &contracts[0]))
+
+	contracts, err = s.metadata.PendingContractMetadata(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Empty(contracts)
+}
+
+func (s *StorageTestSuite) TestWebhookDeliveriesSave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	delivery := &webhook.Delivery{
+		WebhookID:     2,
+		Event:         webhook.EventOperation,
+		OperationID:   12,
+		Level:         41,
+		Payload:       []byte(`{"id":12}`),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	err = tx.WebhookDeliveries(ctx, delivery)
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+	s.Require().Positive(delivery.ID)
+
+	deliveries, err := s.webhooks.Pending(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 2)
+	s.Require().EqualValues(delivery.ID, deliveries[1].ID)
+	s.Require().Equal(0, deliveries[1].Attempts)
+	s.Require().False(deliveries[1].Delivered)
+	s.Require().Equal("https://example.com/failed", deliveries[1].Webhook.URL)
+}
+
+func (s *StorageTestSuite) TestSearchEntries() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	err = tx.SearchEntries(ctx,
+		&search.Entry{
+			Kind:    search.KindEntrypoint,
+			Value:   "transfer",
+			Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			Level:   100,
+		},
+		&search.Entry{
+			Kind:    search.KindEvent,
+			Value:   "minted",
+			Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			Level:   100,
+		},
+	)
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+
+	entries, err := s.search.Search(ctx, search.Request{Query: "transfer", Kinds: []search.Kind{search.KindEntrypoint}, Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 2)
+	s.Require().EqualValues(40, entries[0].Level)
+
+	entries, err = s.search.Search(ctx, search.Request{Query: "mint", Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().EqualValues(100, entries[0].Level)
+	s.Require().Equal(search.KindEvent, entries[0].Kind)
+}
+
 func (s *StorageTestSuite) TestBabylonUpdateBigMapDiffs() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -456,3 +679,72 @@ func (s *StorageTestSuite) TestBabylonUpdateBigMapDiffs() {
 	s.Require().NoError(err)
 	s.Require().Len(diffs, 4)
 }
+
+func (s *StorageTestSuite) TestCopyFrom() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := core.NewCopyTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+
+	operations := []*operation.Operation{
+		{
+			Level:      100,
+			Timestamp:  time.Now().UTC(),
+			Kind:       types.OperationKindTransaction,
+			Status:     types.OperationStatusApplied,
+			Hash:       []byte{0, 1, 2, 3},
+			Parameters: []byte("{\"string\":\"tab\\t'quote'\\n\"}"),
+			Entrypoint: types.NewNullString(nil),
+		},
+	}
+	ids, err := tx.NextIDs(ctx, &operation.Operation{}, len(operations))
+	s.Require().NoError(err)
+	s.Require().Len(ids, 1)
+	operations[0].ID = ids[0]
+
+	err = tx.CopyFrom(ctx, &operations, true)
+	s.Require().NoError(err)
+
+	diffs := []*bigmapdiff.BigMapDiff{
+		{
+			Ptr:         10000,
+			Key:         types.Bytes(`{"string":"key"}`),
+			KeyHash:     "expru",
+			Contract:    "KT1",
+			Level:       100,
+			Timestamp:   operations[0].Timestamp,
+			OperationID: ids[0],
+		},
+	}
+	err = tx.CopyFrom(ctx, &diffs, false)
+	s.Require().NoError(err)
+
+	err = tx.Commit()
+	s.Require().NoError(err)
+
+	op, err := s.operations.GetByID(ctx, ids[0])
+	s.Require().NoError(err)
+	s.Require().Equal(operations[0].Hash, op.Hash)
+	s.Require().Equal(operations[0].Parameters, op.Parameters)
+	s.Require().False(op.Entrypoint.Valid)
+
+	saved, err := s.bigMapDiffs.GetForOperation(ctx, ids[0])
+	s.Require().NoError(err)
+	s.Require().Len(saved, 1)
+	s.Require().Positive(saved[0].ID)
+	s.Require().Nil(saved[0].Value)
+}
+
+func (s *StorageTestSuite) TestCopyFromWithoutCopyTransaction() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tx, err := core.NewTransaction(ctx, s.storage.DB)
+	s.Require().NoError(err)
+	defer func() { _ = tx.Rollback() }()
+
+	diffs := []*bigmapdiff.BigMapDiff{{Ptr: 1}}
+	err = tx.CopyFrom(ctx, &diffs, false)
+	s.Require().Error(err)
+}
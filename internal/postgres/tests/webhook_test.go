@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+)
+
+func (s *StorageTestSuite) TestWebhooksList() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	hooks, err := s.webhooks.List(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(hooks, 2)
+
+	s.Require().EqualValues(1, hooks[0].ID)
+	s.Require().Equal("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", hooks[0].Address)
+	s.Require().EqualValues(2, hooks[1].ID)
+	s.Require().Equal(types.OperationStatusFailed, hooks[1].Status)
+}
+
+func (s *StorageTestSuite) TestWebhookCreate() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	hook := webhook.Webhook{
+		URL:        "https://example.com/new",
+		Secret:     "secret3",
+		Entrypoint: "transfer",
+		CreatedAt:  time.Now().UTC(),
+	}
+	err := s.webhooks.Create(ctx, &hook)
+	s.Require().NoError(err)
+	s.Require().Positive(hook.ID)
+
+	saved, err := s.webhooks.Get(ctx, hook.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("https://example.com/new", saved.URL)
+	s.Require().Equal("transfer", saved.Entrypoint)
+}
+
+func (s *StorageTestSuite) TestWebhookDelete() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := s.webhooks.Delete(ctx, 2)
+	s.Require().NoError(err)
+
+	_, err = s.webhooks.Get(ctx, 2)
+	s.Require().Error(err)
+	s.Require().True(s.storage.IsRecordNotFound(err))
+
+	count, err := s.storage.DB.NewSelect().Model((*webhook.Delivery)(nil)).Where("webhook_id = 2").Count(ctx)
+	s.Require().NoError(err)
+	s.Require().Zero(count)
+
+	letters, err := s.webhooks.DeadLetters(ctx, 2, 10, 0)
+	s.Require().NoError(err)
+	s.Require().Empty(letters)
+}
+
+func (s *StorageTestSuite) TestWebhookPending() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deliveries, err := s.webhooks.Pending(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+
+	delivery := deliveries[0]
+	s.Require().EqualValues(2, delivery.ID)
+	s.Require().Equal(webhook.EventOperation, delivery.Event)
+	s.Require().Equal(2, delivery.Attempts)
+	s.Require().Equal("https://example.com/hook", delivery.Webhook.URL)
+	s.Require().Equal("secret1", delivery.Webhook.Secret)
+}
+
+func (s *StorageTestSuite) TestWebhookRetryAndDelivered() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deliveries, err := s.webhooks.Pending(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+
+	delivery := deliveries[0]
+	delivery.Attempts = 3
+	delivery.LastError = "timeout"
+	delivery.NextAttemptAt = now.Add(time.Minute)
+	err = s.webhooks.Retry(ctx, &delivery)
+	s.Require().NoError(err)
+
+	deliveries, err = s.webhooks.Pending(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Empty(deliveries)
+
+	deliveries, err = s.webhooks.Pending(ctx, now.Add(time.Minute), 10)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	s.Require().Equal(3, deliveries[0].Attempts)
+	s.Require().Equal("timeout", deliveries[0].LastError)
+
+	delivery = deliveries[0]
+	delivery.Attempts = 4
+	err = s.webhooks.Delivered(ctx, &delivery)
+	s.Require().NoError(err)
+
+	var saved webhook.Delivery
+	err = s.storage.DB.NewSelect().Model(&saved).Where("id = 2").Scan(ctx)
+	s.Require().NoError(err)
+	s.Require().True(saved.Delivered)
+	s.Require().Equal(4, saved.Attempts)
+	s.Require().Empty(saved.LastError)
+}
+
+func (s *StorageTestSuite) TestWebhookDeadLetter() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deliveries, err := s.webhooks.Pending(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+
+	delivery := deliveries[0]
+	delivery.Attempts = 8
+	delivery.LastError = "connection refused"
+	err = s.webhooks.DeadLetter(ctx, &delivery, now)
+	s.Require().NoError(err)
+
+	deliveries, err = s.webhooks.Pending(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Empty(deliveries)
+
+	letters, err := s.webhooks.DeadLetters(ctx, 1, 10, 0)
+	s.Require().NoError(err)
+	s.Require().Len(letters, 1)
+
+	letter := letters[0]
+	s.Require().EqualValues(2, letter.DeliveryID)
+	s.Require().EqualValues(11, letter.OperationID)
+	s.Require().Equal(8, letter.Attempts)
+	s.Require().Equal("connection refused", letter.LastError)
+	s.Require().JSONEq(`{"id":11}`, string(letter.Payload))
+}
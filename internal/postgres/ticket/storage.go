@@ -82,6 +82,23 @@ func (storage *Storage) UpdatesForOperation(ctx context.Context, operationId int
 	return
 }
 
+// UpdatesForOperations -
+func (storage *Storage) UpdatesForOperations(ctx context.Context, ids []int64) (response []ticket.TicketUpdate, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	err = storage.DB.
+		NewSelect().
+		Model(&response).
+		Relation("Ticket").
+		Relation("Ticket.Ticketer").
+		Relation("Account").
+		Where("operation_id IN (?)", bun.In(ids)).
+		Order("ticket_update.id asc").
+		Scan(ctx)
+	return
+}
+
 func (storage *Storage) BalancesForAccount(ctx context.Context, accountId int64, req ticket.BalanceRequest) (balances []ticket.Balance, err error) {
 	query := storage.DB.
 		NewSelect().
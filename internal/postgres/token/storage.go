@@ -0,0 +1,84 @@
+package token
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/uptrace/bun"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+// Balances -
+func (storage *Storage) Balances(ctx context.Context, contract string, req token.BalanceRequest) (balances []token.Balance, err error) {
+	query := storage.DB.
+		NewSelect().
+		Model(&balances).
+		Where("contract = ?", contract).
+		Limit(storage.GetPageSize(req.Limit))
+
+	if req.Holder != "" {
+		query.Where("address = ?", req.Holder)
+	}
+
+	if req.TokenId != nil {
+		query.Where("token_id = ?", *req.TokenId)
+	}
+
+	if req.WithoutZeroBalances {
+		query.Where("amount > 0")
+	}
+
+	if req.Offset > 0 {
+		query.Offset(int(req.Offset))
+	}
+
+	err = query.Order("amount desc", "address asc").Scan(ctx)
+	return
+}
+
+// Transfers -
+func (storage *Storage) Transfers(ctx context.Context, contract string, req token.TransfersRequest) (transfers []token.Transfer, err error) {
+	query := storage.DB.
+		NewSelect().
+		Model(&transfers).
+		Where("contract = ?", contract).
+		Limit(storage.GetPageSize(req.Limit))
+
+	if req.Account != "" {
+		query.WhereGroup(" AND ", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			return sq.Where(`"from" = ?`, req.Account).WhereOr(`"to" = ?`, req.Account)
+		})
+	}
+
+	if req.TokenId != nil {
+		query.Where("token_id = ?", *req.TokenId)
+	}
+
+	if req.Offset > 0 {
+		query.Offset(int(req.Offset))
+	}
+
+	err = query.Order("id desc").Scan(ctx)
+	return
+}
+
+// TransfersForOperation -
+func (storage *Storage) TransfersForOperation(ctx context.Context, operationId int64) (transfers []token.Transfer, err error) {
+	err = storage.DB.
+		NewSelect().
+		Model(&transfers).
+		Where("operation_id = ?", operationId).
+		Order("id asc").
+		Scan(ctx)
+	return
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/uptrace/bun"
+)
+
+// Storage -
+type Storage struct {
+	*core.Postgres
+}
+
+// NewStorage -
+func NewStorage(pg *core.Postgres) *Storage {
+	return &Storage{pg}
+}
+
+// Create -
+func (storage *Storage) Create(ctx context.Context, hook *webhook.Webhook) error {
+	_, err := storage.DB.NewInsert().Model(hook).Returning("id").Exec(ctx)
+	return err
+}
+
+// Get -
+func (storage *Storage) Get(ctx context.Context, id int64) (hook webhook.Webhook, err error) {
+	err = storage.DB.NewSelect().Model(&hook).Where("id = ?", id).Scan(ctx)
+	return
+}
+
+// List -
+func (storage *Storage) List(ctx context.Context) (hooks []webhook.Webhook, err error) {
+	err = storage.DB.NewSelect().Model(&hooks).Order("id asc").Scan(ctx)
+	return
+}
+
+// ListByOwner -
+func (storage *Storage) ListByOwner(ctx context.Context, owner string) (hooks []webhook.Webhook, err error) {
+	err = storage.DB.NewSelect().Model(&hooks).Where("owner = ?", owner).Order("id asc").Scan(ctx)
+	return
+}
+
+// Delete -
+func (storage *Storage) Delete(ctx context.Context, id int64) error {
+	return storage.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*webhook.Delivery)(nil)).Where("webhook_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.NewDelete().Model((*webhook.DeadLetter)(nil)).Where("webhook_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		_, err := tx.NewDelete().Model((*webhook.Webhook)(nil)).Where("id = ?", id).Exec(ctx)
+		return err
+	})
+}
+
+// Pending -
+func (storage *Storage) Pending(ctx context.Context, now time.Time, limit int) (deliveries []webhook.Delivery, err error) {
+	err = storage.DB.NewSelect().Model(&deliveries).
+		Relation("Webhook").
+		Where("delivery.delivered = false").
+		Where("delivery.next_attempt_at <= ?", now).
+		Order("delivery.id asc").
+		Limit(limit).
+		Scan(ctx)
+	return
+}
+
+// Delivered -
+func (storage *Storage) Delivered(ctx context.Context, delivery *webhook.Delivery) error {
+	_, err := storage.DB.NewUpdate().Model(delivery).
+		Set("delivered = true").
+		Set("attempts = ?", delivery.Attempts).
+		Set("last_error = ''").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// Retry -
+func (storage *Storage) Retry(ctx context.Context, delivery *webhook.Delivery) error {
+	_, err := storage.DB.NewUpdate().Model(delivery).
+		Column("attempts", "last_error", "next_attempt_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// DeadLetter -
+func (storage *Storage) DeadLetter(ctx context.Context, delivery *webhook.Delivery, failedAt time.Time) error {
+	return storage.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(webhook.NewDeadLetter(*delivery, failedAt)).Exec(ctx); err != nil {
+			return err
+		}
+		_, err := tx.NewDelete().Model(delivery).WherePK().Exec(ctx)
+		return err
+	})
+}
+
+// DeadLetters -
+func (storage *Storage) DeadLetters(ctx context.Context, webhookID int64, limit, offset int64) (letters []webhook.DeadLetter, err error) {
+	query := storage.DB.NewSelect().Model(&letters).
+		Where("webhook_id = ?", webhookID).
+		Order("id desc").
+		Limit(storage.GetPageSize(limit))
+	if offset > 0 {
+		query.Offset(int(offset))
+	}
+	err = query.Scan(ctx)
+	return
+}
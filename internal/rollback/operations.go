@@ -52,6 +52,12 @@ func (rm Manager) rollbackOperations(ctx context.Context, level int64, rCtx *rol
 
 		case types.OperationKindTransferTicket:
 			rCtx.generalStats.TransferTicketsCount -= 1
+
+		case types.OperationKindIncreasePaidStorage:
+			rCtx.generalStats.IncreasePaidStorageCount -= 1
+
+		case types.OperationKindStake, types.OperationKindUnstake, types.OperationKindFinalizeUnstake, types.OperationKindSetDelegateParameters:
+			rCtx.generalStats.StakingCount -= 1
 		}
 	}
 
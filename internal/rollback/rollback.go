@@ -2,6 +2,7 @@ package rollback
 
 import (
 	"context"
+	"time"
 
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/account"
@@ -9,7 +10,9 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/metadata"
 	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/search"
 	smartrollup "github.com/baking-bad/bcdhub/internal/models/smart_rollup"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/types"
@@ -23,6 +26,18 @@ type Manager struct {
 	blockRepo block.Repository
 	rollback  models.Rollback
 	statsRepo stats.Repository
+
+	scheduleRefresh func(from time.Time)
+}
+
+// ManagerOption -
+type ManagerOption func(*Manager)
+
+// WithRefreshScheduler - sets function which schedules refresh of aggregates if it fails after the rollback is committed
+func WithRefreshScheduler(schedule func(from time.Time)) ManagerOption {
+	return func(m *Manager) {
+		m.scheduleRefresh = schedule
+	}
 }
 
 // NewManager -
@@ -31,13 +46,18 @@ func NewManager(
 	blockRepo block.Repository,
 	rollback models.Rollback,
 	statsRepo stats.Repository,
+	opts ...ManagerOption,
 ) Manager {
-	return Manager{
+	m := Manager{
 		storage:   storage,
 		blockRepo: blockRepo,
 		rollback:  rollback,
 		statsRepo: statsRepo,
 	}
+	for i := range opts {
+		opts[i](&m)
+	}
+	return m
 }
 
 // Rollback - rollback indexer state to level
@@ -46,25 +66,49 @@ func (rm Manager) Rollback(ctx context.Context, network types.Network, fromState
 		return errors.Errorf("To level must be less than from level: %d >= %d", toLevel, fromState.Level)
 	}
 
+	var from time.Time
 	for level := fromState.Level; level > toLevel; level-- {
 		log.Info().Str("network", network.String()).Msgf("start rollback to %d", level)
 
-		if _, err := rm.blockRepo.Get(ctx, level); err != nil {
+		b, err := rm.blockRepo.Get(ctx, level)
+		if err != nil {
 			if rm.storage.IsRecordNotFound(err) {
 				continue
 			}
 			return err
 		}
+		from = b.Timestamp
 
 		if err := rm.rollbackBlock(ctx, level); err != nil {
 			log.Err(err).Str("network", network.String()).Msg("rollback error")
-			return rm.rollback.Rollback()
+			if rbErr := rm.rollback.Rollback(); rbErr != nil {
+				log.Err(rbErr).Str("network", network.String()).Msg("rollback transaction")
+			}
+			return errors.Wrapf(err, "rollback of block %d", level)
 		}
 
 		log.Info().Str("network", network.String()).Msgf("rolled back to %d", level)
 	}
 
-	return rm.rollback.Commit()
+	if err := rm.rollback.Commit(); err != nil {
+		return err
+	}
+	if !from.IsZero() {
+		rm.refreshAggregates(ctx, network, from)
+	}
+	return nil
+}
+
+// refreshAggregates - time-series aggregates are built from deleted rows, so they are recomputed to decrement rolled back activity.
+// Rollback is already committed, so refresh failure doesn't fail it: refresh is scheduled to be retried.
+// Without scheduler invalidated buckets are refreshed by the indexer when it reaches the head.
+func (rm Manager) refreshAggregates(ctx context.Context, network types.Network, from time.Time) {
+	if err := rm.rollback.RefreshAggregates(ctx, from); err != nil {
+		log.Err(err).Str("network", network.String()).Time("from", from).Msg("refreshing aggregates")
+		if rm.scheduleRefresh != nil {
+			rm.scheduleRefresh(from)
+		}
+	}
 }
 
 func (rm Manager) rollbackBlock(ctx context.Context, level int64) error {
@@ -76,6 +120,9 @@ func (rm Manager) rollbackBlock(ctx context.Context, level int64) error {
 	if err := rm.rollbackOperations(ctx, level, &rollbackCtx); err != nil {
 		return err
 	}
+	if err := rm.rollbackWebhooks(ctx, level); err != nil {
+		return err
+	}
 	if err := rm.rollbackBigMapState(ctx, level); err != nil {
 		return err
 	}
@@ -88,6 +135,9 @@ func (rm Manager) rollbackBlock(ctx context.Context, level int64) error {
 	if err := rm.rollbackTickets(ctx, level); err != nil {
 		return err
 	}
+	if err := rm.rollbackTokens(ctx, level); err != nil {
+		return err
+	}
 	if err := rm.rollbackAll(ctx, level, &rollbackCtx); err != nil {
 		return err
 	}
@@ -127,7 +177,15 @@ func (rm Manager) rollbackAll(ctx context.Context, level int64, rCtx *rollbackCo
 		(*bigmapdiff.BigMapDiff)(nil),
 		(*bigmapaction.BigMapAction)(nil),
 		(*smartrollup.SmartRollup)(nil),
+		(*smartrollup.Commitment)(nil),
+		(*smartrollup.GameMove)(nil),
+		(*smartrollup.BondRecovery)(nil),
+		(*smartrollup.Message)(nil),
+		(*smartrollup.OutboxTransaction)(nil),
+		(*metadata.ContractMetadata)(nil),
+		(*metadata.TokenMetadata)(nil),
 		(*account.Account)(nil),
+		(*search.Entry)(nil),
 	} {
 		if _, err := rm.rollback.DeleteAll(ctx, model, level); err != nil {
 			return err
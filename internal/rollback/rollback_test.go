@@ -18,8 +18,10 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/stats"
 	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/token"
 	"github.com/baking-bad/bcdhub/internal/models/types"
 	"github.com/baking-bad/bcdhub/internal/testsuite"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -35,10 +37,12 @@ func TestManager_Rollback(t *testing.T) {
 	blockRepo := mock_block.NewMockRepository(ctrl)
 	statsRepo := mock_stats.NewMockRepository(ctrl)
 
+	blockTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 	blockRepo.EXPECT().
 		Get(gomock.Any(), level).
 		Return(block.Block{
-			Level: 11,
+			Level:     11,
+			Timestamp: blockTime,
 		}, nil).
 		Times(1)
 
@@ -230,6 +234,42 @@ func TestManager_Rollback(t *testing.T) {
 		Return(nil).
 		Times(1)
 
+	rb.EXPECT().
+		GetTokenTransfers(gomock.Any(), level).
+		Return([]token.Transfer{
+			{
+				Contract: "address_1",
+				From:     "address_2",
+				To:       "address_3",
+				TokenId:  decimal.Zero,
+				Amount:   decimal.RequireFromString("100"),
+			}, {
+				Contract: "address_1",
+				To:       "address_2",
+				TokenId:  decimal.Zero,
+				Amount:   decimal.RequireFromString("30"),
+			},
+		}, nil).
+		Times(1)
+
+	rb.EXPECT().
+		TokenBalances(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, balances ...*token.Balance) error {
+			require.Len(t, balances, 2)
+			for i := range balances {
+				switch balances[i].Address {
+				case "address_2":
+					require.Equal(t, "-70", balances[i].Amount.String())
+				case "address_3":
+					require.Equal(t, "100", balances[i].Amount.String())
+				default:
+					t.Errorf("unexpected token balance: %s", balances[i].Address)
+				}
+			}
+			return nil
+		}).
+		Times(1)
+
 	rb.EXPECT().
 		UpdateAccountStats(gomock.Any(), account.Account{
 			ID:              4,
@@ -378,7 +418,12 @@ func TestManager_Rollback(t *testing.T) {
 	rb.EXPECT().
 		DeleteAll(gomock.Any(), nil, level).
 		Return(0, nil).
-		Times(8)
+		Times(17)
+
+	rb.EXPECT().
+		RevertWebhookDeliveries(gomock.Any(), level).
+		Return(2, nil).
+		Times(1)
 
 	rb.EXPECT().
 		Protocols(gomock.Any(), level).
@@ -404,6 +449,11 @@ func TestManager_Rollback(t *testing.T) {
 		Return(nil).
 		Times(1)
 
+	rb.EXPECT().
+		RefreshAggregates(gomock.Any(), blockTime).
+		Return(nil).
+		Times(1)
+
 	t.Run("Rollback", func(t *testing.T) {
 		state := block.Block{
 			Level: 11,
@@ -418,3 +468,25 @@ func TestManager_Rollback(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestManager_refreshAggregates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rb := mock.NewMockRollback(ctrl)
+	rb.EXPECT().
+		RefreshAggregates(gomock.Any(), from).
+		Return(errors.New("refresh failed")).
+		Times(2)
+
+	var scheduled []time.Time
+	manager := NewManager(nil, nil, rb, nil, WithRefreshScheduler(func(from time.Time) {
+		scheduled = append(scheduled, from)
+	}))
+	manager.refreshAggregates(context.Background(), types.Mainnet, from)
+	require.Equal(t, []time.Time{from}, scheduled)
+
+	// refresh failure without scheduler is only logged
+	NewManager(nil, nil, rb, nil).refreshAggregates(context.Background(), types.Mainnet, from)
+}
@@ -0,0 +1,31 @@
+package rollback
+
+import (
+	"context"
+
+	"github.com/baking-bad/bcdhub/internal/models/token"
+	"github.com/rs/zerolog/log"
+)
+
+func (rm Manager) rollbackTokens(ctx context.Context, level int64) error {
+	transfers, err := rm.rollback.GetTokenTransfers(ctx, level)
+	if err != nil {
+		return err
+	}
+
+	if len(transfers) > 0 {
+		ptrs := make([]*token.Transfer, len(transfers))
+		for i := range transfers {
+			ptrs[i] = &transfers[i]
+		}
+		if err := rm.rollback.TokenBalances(ctx, token.AggregateBalances(ptrs)...); err != nil {
+			return err
+		}
+	}
+
+	if _, err := rm.rollback.DeleteAll(ctx, (*token.Transfer)(nil), level); err != nil {
+		return err
+	}
+	log.Info().Msg("rollback token transfers")
+	return nil
+}
@@ -0,0 +1,18 @@
+package rollback
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (rm Manager) rollbackWebhooks(ctx context.Context, level int64) error {
+	count, err := rm.rollback.RevertWebhookDeliveries(ctx, level)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		log.Info().Int("count", count).Msg("webhook deliveries are reverted")
+	}
+	return nil
+}
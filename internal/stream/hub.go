@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"context"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const subscriptionBufferSize = 64
+
+// Subscription -
+type Subscription struct {
+	ch chan Message
+}
+
+// Messages - returns channel of received messages. It is closed when subscription is cancelled or hub is closed.
+func (s *Subscription) Messages() <-chan Message {
+	return s.ch
+}
+
+// Hub - receives indexer notifications from Postgres and fans them out to subscribers
+type Hub struct {
+	listener *pgdriver.Listener
+
+	mx            sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+
+	wg sync.WaitGroup
+}
+
+// NewHub -
+func NewHub(db *bun.DB) *Hub {
+	hub := newHub()
+	if db != nil {
+		hub.listener = pgdriver.NewListener(db)
+	}
+	return hub
+}
+
+func newHub() *Hub {
+	return &Hub{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Start -
+func (h *Hub) Start(ctx context.Context) error {
+	if h.listener == nil {
+		return nil
+	}
+	if err := h.listener.Listen(ctx, Channel); err != nil {
+		return err
+	}
+
+	h.wg.Add(1)
+	go h.listen(ctx)
+	return nil
+}
+
+func (h *Hub) listen(ctx context.Context) {
+	defer h.wg.Done()
+
+	notifications := h.listener.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+
+			var msg Message
+			if err := json.UnmarshalFromString(notification.Payload, &msg); err != nil {
+				log.Err(err).Str("payload", notification.Payload).Msg("invalid indexer notification")
+				continue
+			}
+			h.dispatch(msg)
+		}
+	}
+}
+
+// dispatch - sends message to every subscriber. Slow subscribers which buffer is full are dropped to not block others.
+func (h *Hub) dispatch(msg Message) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	for sub := range h.subscriptions {
+		select {
+		case sub.ch <- msg:
+		default:
+			log.Warn().Msg("stream subscriber is too slow, dropping it")
+			delete(h.subscriptions, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe -
+func (h *Hub) Subscribe() *Subscription {
+	sub := &Subscription{
+		ch: make(chan Message, subscriptionBufferSize),
+	}
+
+	h.mx.Lock()
+	h.subscriptions[sub] = struct{}{}
+	h.mx.Unlock()
+
+	return sub
+}
+
+// Unsubscribe -
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if _, ok := h.subscriptions[sub]; ok {
+		delete(h.subscriptions, sub)
+		close(sub.ch)
+	}
+}
+
+// Close -
+func (h *Hub) Close() error {
+	if h.listener != nil {
+		if err := h.listener.Close(); err != nil {
+			return err
+		}
+	}
+	h.wg.Wait()
+
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	for sub := range h.subscriptions {
+		delete(h.subscriptions, sub)
+		close(sub.ch)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_dispatch(t *testing.T) {
+	hub := newHub()
+
+	first := hub.Subscribe()
+	second := hub.Subscribe()
+
+	hub.dispatch(NewBlockMessage("mainnet", 100))
+
+	require.Equal(t, NewBlockMessage("mainnet", 100), <-first.Messages())
+	require.Equal(t, NewBlockMessage("mainnet", 100), <-second.Messages())
+
+	hub.Unsubscribe(second)
+	_, ok := <-second.Messages()
+	require.False(t, ok)
+
+	hub.dispatch(NewRollbackMessage("mainnet", 99))
+	require.Equal(t, NewRollbackMessage("mainnet", 99), <-first.Messages())
+
+	require.NoError(t, hub.Close())
+	_, ok = <-first.Messages()
+	require.False(t, ok)
+}
+
+func TestHub_dispatchSlowSubscriber(t *testing.T) {
+	hub := newHub()
+	slow := hub.Subscribe()
+
+	for i := 0; i <= subscriptionBufferSize; i++ {
+		hub.dispatch(NewBlockMessage("mainnet", int64(i)))
+	}
+
+	var count int
+	for range slow.Messages() {
+		count++
+	}
+	require.Equal(t, subscriptionBufferSize, count)
+
+	// unsubscribe of dropped subscriber must not panic
+	hub.Unsubscribe(slow)
+}
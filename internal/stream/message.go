@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// Channel - name of Postgres channel which is used for indexer notifications
+const Channel = "bcd_indexer"
+
+// MessageType -
+type MessageType string
+
+// message types
+const (
+	MessageTypeBlock    MessageType = "block"
+	MessageTypeRollback MessageType = "rollback"
+)
+
+// Message - notification about indexer state changes. Postgres limits payload size, so it contains only level and receivers have to load the data by themselves.
+type Message struct {
+	Type    MessageType `json:"type"`
+	Network string      `json:"network"`
+	Level   int64       `json:"level"`
+}
+
+// NewBlockMessage - indexer committed block at `level`
+func NewBlockMessage(network string, level int64) Message {
+	return Message{
+		Type:    MessageTypeBlock,
+		Network: network,
+		Level:   level,
+	}
+}
+
+// NewRollbackMessage - indexer rolled back its state to `level`
+func NewRollbackMessage(network string, level int64) Message {
+	return Message{
+		Type:    MessageTypeRollback,
+		Network: network,
+		Level:   level,
+	}
+}
+
+// Notify - sends message to all listeners of the database
+func Notify(ctx context.Context, db *bun.DB, msg Message) error {
+	payload, err := json.MarshalToString(msg)
+	if err != nil {
+		return err
+	}
+	return pgdriver.Notify(ctx, db, Channel, payload)
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"time"
+)
+
+// WorkerOption -
+type WorkerOption func(*Worker)
+
+// WithMaxAttempts - sets count of attempts after which delivery is moved to dead-letter table
+func WithMaxAttempts(attempts int) WorkerOption {
+	return func(w *Worker) {
+		if attempts > 0 {
+			w.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBackoff - sets delay after the first failed attempt and maximum delay between attempts. Delay is doubled after every failed attempt.
+func WithBackoff(base, max time.Duration) WorkerOption {
+	return func(w *Worker) {
+		if base > 0 {
+			w.backoff = base
+		}
+		if max > 0 {
+			w.maxBackoff = max
+		}
+	}
+}
+
+// WithTimeout - sets timeout of the request to receiver
+func WithTimeout(timeout time.Duration) WorkerOption {
+	return func(w *Worker) {
+		if timeout > 0 {
+			w.client = newClient(timeout)
+		}
+	}
+}
+
+// WithInterval - sets interval of polling queued deliveries
+func WithInterval(interval time.Duration) WorkerOption {
+	return func(w *Worker) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrForbiddenTarget - webhook URL is not https or points to private network
+var ErrForbiddenTarget = errors.New("forbidden webhook target")
+
+// shared address space (RFC 6598) is not covered by `netip.Addr.IsPrivate`
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// ValidateURL - checks webhook URL is `https` and its host isn't resolved to loopback, private or link-local address
+func ValidateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(ErrForbiddenTarget, err.Error())
+	}
+	if u.Scheme != "https" {
+		return errors.Wrap(ErrForbiddenTarget, "only https is allowed")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.Wrap(ErrForbiddenTarget, "empty host")
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(addr)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return errors.Wrap(ErrForbiddenTarget, err.Error())
+	}
+	for i := range addrs {
+		if err := checkAddr(addrs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddressSpace.Contains(addr) {
+		return errors.Wrap(ErrForbiddenTarget, addr.String())
+	}
+	return nil
+}
+
+// NewDialer - returns dialer which refuses connections to forbidden addresses. It's checked on every connection, so host resolved to private address after validation of the URL is refused too.
+func NewDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return errors.Wrap(ErrForbiddenTarget, err.Error())
+			}
+			return checkAddr(addrPort.Addr())
+		},
+	}
+}
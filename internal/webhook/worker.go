@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stdJSON "encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+	"github.com/dipdup-io/workerpool"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// headers of the webhook request
+const (
+	HeaderSignature = "X-BCD-Signature"
+	HeaderEvent     = "X-BCD-Event"
+	HeaderDelivery  = "X-BCD-Delivery"
+)
+
+const (
+	defaultMaxAttempts = 8
+	defaultBackoff     = 10 * time.Second
+	defaultMaxBackoff  = time.Hour
+	defaultTimeout     = 10 * time.Second
+	defaultInterval    = 5 * time.Second
+	batchSize          = 100
+	maxErrorLength     = 512
+)
+
+// Message - body of the webhook request
+type Message struct {
+	ID        int64              `json:"id"`
+	WebhookID int64              `json:"webhook_id"`
+	Network   string             `json:"network"`
+	Event     webhook.EventType  `json:"event"`
+	Attempt   int                `json:"attempt"`
+	Data      stdJSON.RawMessage `json:"data"`
+}
+
+// Worker - delivers queued webhook events. Every request is signed by HMAC-SHA256 of the body with webhook secret. Failed deliveries are retried with exponential backoff and moved to dead-letter table after maximum count of attempts. Target URL is validated before every delivery and connections to private networks are refused.
+type Worker struct {
+	repo     webhook.Repository
+	network  string
+	client   *http.Client
+	now      func() time.Time
+	validate func(ctx context.Context, rawURL string) error
+
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	interval    time.Duration
+
+	g workerpool.Group
+}
+
+// NewWorker -
+func NewWorker(network string, repo webhook.Repository, opts ...WorkerOption) *Worker {
+	w := &Worker{
+		repo:        repo,
+		network:     network,
+		client:      newClient(defaultTimeout),
+		now:         func() time.Time { return time.Now().UTC() },
+		validate:    ValidateURL,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		interval:    defaultInterval,
+		g:           workerpool.NewGroup(),
+	}
+	for i := range opts {
+		opts[i](w)
+	}
+	return w
+}
+
+// Start -
+func (w *Worker) Start(ctx context.Context) {
+	w.g.GoCtx(ctx, w.run)
+}
+
+// Close -
+func (w *Worker) Close() error {
+	w.g.Wait()
+	return nil
+}
+
+func (w *Worker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.process(ctx); err != nil {
+				log.Err(err).Str("network", w.network).Msg("webhook deliveries")
+			}
+		}
+	}
+}
+
+// process - delivers all due events
+func (w *Worker) process(ctx context.Context) error {
+	for {
+		deliveries, err := w.repo.Pending(ctx, w.now(), batchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range deliveries {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+
+			if err := w.handle(ctx, &deliveries[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return nil
+		}
+	}
+}
+
+// handle - sends event and saves result of the attempt. Returned error is an error of the storage, failed request is not an error.
+func (w *Worker) handle(ctx context.Context, delivery *webhook.Delivery) error {
+	delivery.Attempts += 1
+
+	sendErr := w.send(ctx, delivery)
+	if sendErr == nil {
+		return w.repo.Delivered(ctx, delivery)
+	}
+
+	delivery.LastError = sendErr.Error()
+	if len(delivery.LastError) > maxErrorLength {
+		delivery.LastError = delivery.LastError[:maxErrorLength]
+	}
+
+	now := w.now()
+	if delivery.Attempts >= w.maxAttempts {
+		log.Warn().
+			Str("network", w.network).
+			Int64("webhook", delivery.WebhookID).
+			Int64("delivery", delivery.ID).
+			Err(sendErr).
+			Msg("webhook delivery is moved to dead letters")
+		return w.repo.DeadLetter(ctx, delivery, now)
+	}
+
+	delivery.NextAttemptAt = now.Add(w.delay(delivery.Attempts))
+	return w.repo.Retry(ctx, delivery)
+}
+
+func (w *Worker) send(ctx context.Context, delivery *webhook.Delivery) error {
+	if err := w.validate(ctx, delivery.Webhook.URL); err != nil {
+		return err
+	}
+
+	body, err := stdJSON.Marshal(Message{
+		ID:        delivery.ID,
+		WebhookID: delivery.WebhookID,
+		Network:   w.network,
+		Event:     delivery.Event,
+		Attempt:   delivery.Attempts,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Webhook.Secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
+
+// newClient - returns HTTP client which doesn't use proxy, doesn't follow redirects and refuses connections to private networks
+func newClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         NewDialer(timeout).DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// delay - returns delay before next attempt: base backoff doubled after every failed attempt
+func (w *Worker) delay(attempts int) time.Duration {
+	delay := min(w.backoff, w.maxBackoff)
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= w.maxBackoff {
+			return w.maxBackoff
+		}
+	}
+	return delay
+}
+
+// Sign - returns value of signature header: hex-encoded HMAC-SHA256 of the body with webhook secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
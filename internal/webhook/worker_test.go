@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mock_webhook "github.com/baking-bad/bcdhub/internal/models/mock/webhook"
+	"github.com/baking-bad/bcdhub/internal/models/webhook"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testSecret = "secret"
+
+func newTestWorker(t *testing.T, repo webhook.Repository, now time.Time) *Worker {
+	t.Helper()
+	w := NewWorker("mainnet", repo, WithMaxAttempts(3), WithBackoff(time.Second, 3*time.Second), WithTimeout(time.Second))
+	w.now = func() time.Time { return now }
+	// test servers listen on loopback
+	w.validate = func(context.Context, string) error { return nil }
+	w.client = &http.Client{Timeout: time.Second}
+	return w
+}
+
+func newTestDelivery(url string, attempts int) webhook.Delivery {
+	return webhook.Delivery{
+		ID:        10,
+		WebhookID: 1,
+		Webhook: webhook.Webhook{
+			ID:     1,
+			URL:    url,
+			Secret: testSecret,
+		},
+		Event:    webhook.EventOperation,
+		Level:    100,
+		Payload:  json.RawMessage(`{"id":5}`),
+		Attempts: attempts,
+	}
+}
+
+func TestWorker_Delivered(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, Sign(testSecret, body), r.Header.Get(HeaderSignature))
+		require.Equal(t, "operation", r.Header.Get(HeaderEvent))
+		require.Equal(t, "10", r.Header.Get(HeaderDelivery))
+
+		var msg Message
+		require.NoError(t, json.Unmarshal(body, &msg))
+		require.EqualValues(t, 10, msg.ID)
+		require.Equal(t, "mainnet", msg.Network)
+		require.Equal(t, 1, msg.Attempt)
+		require.JSONEq(t, `{"id":5}`, string(msg.Data))
+
+		received.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_webhook.NewMockRepository(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().Pending(gomock.Any(), now, batchSize).Return([]webhook.Delivery{newTestDelivery(server.URL, 0)}, nil).Times(1)
+	repo.EXPECT().Delivered(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *webhook.Delivery) error {
+			require.Equal(t, 1, d.Attempts)
+			return nil
+		}).Times(1)
+
+	err := newTestWorker(t, repo, now).process(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, received.Load())
+}
+
+func TestWorker_Retry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_webhook.NewMockRepository(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().Pending(gomock.Any(), now, batchSize).Return([]webhook.Delivery{newTestDelivery(server.URL, 1)}, nil).Times(1)
+	repo.EXPECT().Retry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *webhook.Delivery) error {
+			require.Equal(t, 2, d.Attempts)
+			require.Equal(t, now.Add(2*time.Second), d.NextAttemptAt)
+			require.Contains(t, d.LastError, "500")
+			return nil
+		}).Times(1)
+
+	err := newTestWorker(t, repo, now).process(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWorker_DeadLetter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_webhook.NewMockRepository(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().Pending(gomock.Any(), now, batchSize).Return([]webhook.Delivery{newTestDelivery(server.URL, 2)}, nil).Times(1)
+	repo.EXPECT().DeadLetter(gomock.Any(), gomock.Any(), now).
+		DoAndReturn(func(_ context.Context, d *webhook.Delivery, _ time.Time) error {
+			require.Equal(t, 3, d.Attempts)
+			require.Contains(t, d.LastError, "502")
+			return nil
+		}).Times(1)
+
+	err := newTestWorker(t, repo, now).process(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWorker_delay(t *testing.T) {
+	w := NewWorker("mainnet", nil, WithBackoff(time.Second, 5*time.Second))
+	for attempts, want := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		require.Equal(t, want, w.delay(attempts), "attempts %d", attempts)
+	}
+}
+
+func TestWorker_ForbiddenTarget(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_webhook.NewMockRepository(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().Pending(gomock.Any(), now, batchSize).Return([]webhook.Delivery{newTestDelivery(server.URL, 0)}, nil).Times(1)
+	repo.EXPECT().Retry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *webhook.Delivery) error {
+			require.Contains(t, d.LastError, ErrForbiddenTarget.Error())
+			return nil
+		}).Times(1)
+
+	w := NewWorker("mainnet", repo, WithMaxAttempts(3))
+	w.now = func() time.Time { return now }
+
+	err := w.process(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 0, received.Load())
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://1.1.1.1/hook"},
+		{url: "https://[2606:4700:4700::1111]/hook"},
+		{url: "http://1.1.1.1/hook", wantErr: true},
+		{url: "ftp://1.1.1.1/hook", wantErr: true},
+		{url: "https://127.0.0.1/hook", wantErr: true},
+		{url: "https://localhost:8080/hook", wantErr: true},
+		{url: "https://10.1.2.3/hook", wantErr: true},
+		{url: "https://192.168.0.1/hook", wantErr: true},
+		{url: "https://172.16.0.1/hook", wantErr: true},
+		{url: "https://100.64.0.1/hook", wantErr: true},
+		{url: "https://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "https://0.0.0.0/hook", wantErr: true},
+		{url: "https://[::1]/hook", wantErr: true},
+		{url: "https://[fe80::1]/hook", wantErr: true},
+		{url: "https://[fd00::1]/hook", wantErr: true},
+		{url: "https://[::ffff:127.0.0.1]/hook", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateURL(context.Background(), tt.url)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrForbiddenTarget)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
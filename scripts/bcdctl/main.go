@@ -10,10 +10,14 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-var ctxs config.Contexts
+var (
+	ctxs config.Contexts
+	cfg  config.Config
+)
 
 func main() {
-	cfg, err := config.LoadDefaultConfig()
+	var err error
+	cfg, err = config.LoadDefaultConfig()
 	if err != nil {
 		log.Err(err).Msg("load config")
 		return
@@ -36,6 +40,37 @@ func main() {
 		return
 	}
 
+	if _, err := parser.AddCommand("verify",
+		"Verify rollback",
+		"Rollback network state to certain level, re-index rolled back blocks and compare derived state with the state before rollback",
+		&verifyCmd); err != nil {
+		log.Err(err).Msg("add verify command")
+		return
+	}
+
+	snapshotParser, err := parser.AddCommand("snapshot",
+		"Network snapshot",
+		"Export or import indexed state of network",
+		&snapshotCmd)
+	if err != nil {
+		log.Err(err).Msg("add snapshot command")
+		return
+	}
+	if _, err := snapshotParser.AddCommand("export",
+		"Export snapshot",
+		"Write chain tables of network to the archive at the last indexed block or at the given level",
+		&snapshotExportCmd); err != nil {
+		log.Err(err).Msg("add snapshot export command")
+		return
+	}
+	if _, err := snapshotParser.AddCommand("import",
+		"Import snapshot",
+		"Restore network from the archive to the empty database. Indexing is resumed from the level of the snapshot",
+		&snapshotImportCmd); err != nil {
+		log.Err(err).Msg("add snapshot import command")
+		return
+	}
+
 	if _, err := parser.Parse(); err != nil {
 		panic(err)
 	}
@@ -2,8 +2,14 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
 
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/postgres"
 	"github.com/baking-bad/bcdhub/internal/rollback"
 	"github.com/rs/zerolog/log"
@@ -12,6 +18,7 @@ import (
 type rollbackCommand struct {
 	Level   int64  `description:"Level to rollback" long:"level"   short:"l"`
 	Network string `description:"Network"           long:"network" short:"n"`
+	DryRun  bool   `description:"Print count of rows which would be changed without rollback" long:"dry-run"`
 }
 
 var rollbackCmd rollbackCommand
@@ -29,6 +36,10 @@ func (x *rollbackCommand) Execute(_ []string) error {
 		panic(err)
 	}
 
+	if x.DryRun {
+		return x.dryRun(ctx, state)
+	}
+
 	log.Warn().Msgf("Do you want to rollback '%s' from %d to %d? (yes - continue. no - cancel)", network.String(), state.Level, x.Level)
 	if !yes() {
 		log.Info().Msg("Cancelled")
@@ -47,7 +58,30 @@ func (x *rollbackCommand) Execute(_ []string) error {
 	if err = manager.Rollback(context.Background(), network, state, x.Level); err != nil {
 		return err
 	}
+	if cached, ok := ctx.RPC.(*noderpc.CachedNode); ok {
+		if err := cached.Rollback(x.Level); err != nil {
+			return err
+		}
+	}
 	log.Info().Msg("Done")
 
 	return nil
 }
+
+func (x *rollbackCommand) dryRun(ctx *config.Context, state block.Block) error {
+	saver, report, err := postgres.NewDryRunRollback(ctx.StorageDB.DB)
+	if err != nil {
+		return err
+	}
+	manager := rollback.NewManager(ctx.Storage, ctx.Blocks, saver, ctx.Stats)
+	if err = manager.Rollback(context.Background(), ctx.Network, state, x.Level); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tDELETED\tUPDATED\tINSERTED")
+	for _, changes := range report.Tables() {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", changes.Table, changes.Deleted, changes.Updated, changes.Inserted)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/postgres"
+	"github.com/baking-bad/bcdhub/internal/postgres/snapshot"
+	"github.com/baking-bad/bcdhub/internal/rollback"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+)
+
+type snapshotCommand struct{}
+
+var snapshotCmd snapshotCommand
+
+type snapshotExportCommand struct {
+	Network string `description:"Network"                                          long:"network" short:"n"`
+	Output  string `description:"Path to the archive"                              long:"output"  required:"true" short:"o"`
+	Level   int64  `description:"Level of the snapshot. The last indexed if not set" long:"level"   short:"l"`
+}
+
+var snapshotExportCmd snapshotExportCommand
+
+// Execute
+func (x *snapshotExportCommand) Execute(_ []string) error {
+	network := types.NewNetwork(x.Network)
+	ctx, err := ctxs.Get(network)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(x.Output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var rollbackTo snapshot.RollbackFunc
+	if x.Level > 0 {
+		rollbackTo = func(c context.Context, tx bun.Tx) error {
+			state, err := ctx.Blocks.Last(c)
+			if err != nil {
+				return err
+			}
+			if x.Level > state.Level {
+				return errors.Errorf("level is greater than indexed level: %d > %d", x.Level, state.Level)
+			}
+			if x.Level == state.Level {
+				return nil
+			}
+			manager := rollback.NewManager(ctx.Storage, ctx.Blocks, postgres.NewTxRollback(tx), ctx.Stats)
+			return manager.Rollback(c, network, state, x.Level)
+		}
+	}
+
+	manifest, err := snapshot.Export(context.Background(), ctx.StorageDB.DB, network.String(), file, rollbackTo)
+	if err != nil {
+		_ = os.Remove(x.Output)
+		return err
+	}
+
+	log.Info().
+		Str("network", manifest.Network).
+		Int64("level", manifest.Level).
+		Str("hash", manifest.Hash).
+		Str("file", x.Output).
+		Msg("Snapshot is exported")
+	return nil
+}
+
+type snapshotImportCommand struct {
+	Network string `description:"Network"              long:"network" short:"n"`
+	Input   string `description:"Path to the archive" long:"input"   required:"true" short:"i"`
+}
+
+var snapshotImportCmd snapshotImportCommand
+
+// Execute
+func (x *snapshotImportCommand) Execute(_ []string) error {
+	network := types.NewNetwork(x.Network)
+	ctx, err := ctxs.Get(network)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(x.Input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	c := context.Background()
+	if err := ctx.Storage.InitDatabase(c); err != nil {
+		return err
+	}
+
+	manifest, err := snapshot.Import(c, ctx.StorageDB.DB, file, func(manifest snapshot.Manifest) error {
+		if manifest.Network != network.String() {
+			return errors.Errorf("snapshot of %s can't be imported to %s", manifest.Network, network.String())
+		}
+		header, err := ctx.RPC.GetHeader(c, manifest.Level)
+		if err != nil {
+			return errors.Wrap(err, "receiving block from node")
+		}
+		if header.ChainID != manifest.ChainID {
+			return errors.Errorf("invalid chain id: %s (snapshot) != %s (node)", manifest.ChainID, header.ChainID)
+		}
+		if header.Hash != manifest.Hash {
+			return errors.Errorf("block %d of snapshot is absent in node's chain: %s (snapshot) != %s (node)", manifest.Level, manifest.Hash, header.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("network", manifest.Network).
+		Int64("level", manifest.Level).
+		Str("hash", manifest.Hash).
+		Msg("Snapshot is imported. Indexing will be resumed from its level.")
+	return nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/baking-bad/bcdhub/cmd/indexer/indexer"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/types"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/postgres"
+	"github.com/baking-bad/bcdhub/internal/rollback"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+)
+
+type verifyCommand struct {
+	Level       int64  `description:"Level to rollback"                                              long:"level"       short:"l"`
+	Network     string `description:"Network"                                                        long:"network"     short:"n"`
+	Destructive bool   `description:"Confirm that indexed data of the network is really rolled back" long:"destructive"`
+}
+
+var verifyCmd verifyCommand
+
+// Execute - rolls back the network state to the level, re-indexes rolled back blocks from the node and compares
+// account counters, ticket balances and big map states with the values before rollback.
+// The database of the network is really changed: if the command fails or is interrupted, the data stays rolled back
+// to the level or partially re-indexed until indexer is started. So it has to be run on a copy of the database.
+func (x *verifyCommand) Execute(_ []string) error {
+	if !x.Destructive {
+		return errors.New("verify rolls back indexed data of the network and re-indexes it: run it on a copy of the database and pass --destructive to confirm")
+	}
+
+	network := types.NewNetwork(x.Network)
+	ctx, err := ctxs.Get(network)
+	if err != nil {
+		return err
+	}
+
+	c := context.Background()
+	state, err := ctx.Blocks.Last(c)
+	if err != nil {
+		return err
+	}
+	if x.Level >= state.Level {
+		return errors.Errorf("level must be less than indexed level: %d >= %d", x.Level, state.Level)
+	}
+
+	first, err := ctx.Blocks.Get(c, x.Level+1)
+	if err != nil {
+		return errors.Wrapf(err, "receiving block %d", x.Level+1)
+	}
+
+	before, err := takeSnapshot(c, ctx.StorageDB.DB, x.Level, first.Timestamp)
+	if err != nil {
+		return errors.Wrap(err, "snapshot before rollback")
+	}
+
+	log.Warn().Msgf("Do you want to rollback '%s' from %d to %d and re-index it? (yes - continue. no - cancel)", network.String(), state.Level, x.Level)
+	if !yes() {
+		log.Info().Msg("Cancelled")
+		return nil
+	}
+
+	saver, err := postgres.NewRollback(ctx.StorageDB.DB)
+	if err != nil {
+		return err
+	}
+	manager := rollback.NewManager(ctx.Storage, ctx.Blocks, saver, ctx.Stats)
+	if err = manager.Rollback(c, network, state, x.Level); err != nil {
+		return err
+	}
+	if cached, ok := ctx.RPC.(*noderpc.CachedNode); ok {
+		if err := cached.Rollback(x.Level); err != nil {
+			return err
+		}
+	}
+
+	indexerCfg := config.IndexerConfig{}
+	if networkCfg, ok := cfg.Indexer.Networks[network.String()]; ok {
+		indexerCfg = networkCfg
+	}
+	bi, err := indexer.NewBlockchainIndexer(c, cfg, network.String(), indexerCfg)
+	if err != nil {
+		return err
+	}
+	defer bi.Close()
+
+	if err := bi.IndexTo(c, state.Level); err != nil {
+		return err
+	}
+
+	after, err := takeSnapshot(c, ctx.StorageDB.DB, x.Level, first.Timestamp)
+	if err != nil {
+		return errors.Wrap(err, "snapshot after re-index")
+	}
+
+	mismatches := compareSnapshots(before, after)
+	for _, m := range mismatches {
+		fmt.Println(m)
+	}
+	if len(mismatches) > 0 {
+		return errors.Errorf("%d mismatches found", len(mismatches))
+	}
+
+	log.Info().Int("checked", len(before)).Msg("State matches re-index")
+	return nil
+}
+
+type stateSnapshot map[string]string
+
+type snapshotQuery struct {
+	name  string
+	query string
+}
+
+// snapshotQueries - derived state which is changed by the blocks after the level. Every row is `key, value`.
+var snapshotQueries = []snapshotQuery{
+	{
+		name: "accounts",
+		query: `SELECT address AS key,
+			concat_ws('/', operations_count, migrations_count, events_count, ticket_updates_count, last_action) AS value
+			FROM accounts WHERE last_action >= ?1`,
+	},
+	{
+		name: "ticket_balances",
+		query: `SELECT concat_ws('/', tickets.hash, accounts.address) AS key, ticket_balances.amount::text AS value
+			FROM ticket_balances
+			JOIN tickets ON tickets.id = ticket_balances.ticket_id
+			JOIN accounts ON accounts.id = ticket_balances.account_id
+			WHERE ticket_balances.ticket_id IN (SELECT ticket_id FROM ticket_updates WHERE level > ?0)`,
+	},
+	{
+		name: "big_map_states",
+		query: `SELECT concat_ws('/', ptr, contract, key_hash) AS key,
+			concat_ws('/', last_update_level, removed, encode(value, 'hex')) AS value
+			FROM big_map_states
+			WHERE (ptr, key_hash) IN (SELECT ptr, key_hash FROM big_map_diffs WHERE level > ?0)`,
+	},
+}
+
+func takeSnapshot(ctx context.Context, db bun.IDB, level int64, since time.Time) (stateSnapshot, error) {
+	result := make(stateSnapshot)
+	for _, sq := range snapshotQueries {
+		var rows []struct {
+			Key   string `bun:"key"`
+			Value string `bun:"value"`
+		}
+		if err := db.NewRaw(sq.query, level, since).Scan(ctx, &rows); err != nil {
+			return nil, errors.Wrap(err, sq.name)
+		}
+		for i := range rows {
+			result[fmt.Sprintf("%s %s", sq.name, rows[i].Key)] = rows[i].Value
+		}
+	}
+	return result, nil
+}
+
+func compareSnapshots(before, after stateSnapshot) []string {
+	mismatches := make([]string, 0)
+	for key, value := range before {
+		newValue, ok := after[key]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s -> absent", key, value))
+		case newValue != value:
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s -> %s", key, value, newValue))
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: absent -> %s", key, value))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}